@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	apiInternal bool
+	apiFormat   string
+	apiOutput   string
+)
+
+// apiCmd lists the exported API of each package
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "List the exported API of each package",
+	Long: `List the exported functions, types, methods, struct fields, constants and
+variables of each package with their signatures, grouped by package and
+with fields and methods under their type. Deprecated symbols are marked.
+
+Main packages are left out, as are packages under an internal directory
+unless --internal is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		pkgs := graph.ExportedAPI(result, graph.APIOptions{Internal: apiInternal})
+		return graph.WriteOutput(apiOutput, func(w io.Writer) error {
+			switch apiFormat {
+			case "text":
+				return graph.WriteAPI(w, pkgs)
+			case "json":
+				return graph.WriteAPIJSON(w, pkgs)
+			default:
+				return fmt.Errorf("unknown api format %q (want text or json)", apiFormat)
+			}
+		})
+	},
+}
+
+func init() {
+	apiCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	apiCmd.Flags().BoolVar(&apiInternal, "internal", false, "Include packages under internal directories")
+	apiCmd.Flags().StringVarP(&apiFormat, "format", "f", "text", "Output format: text or json")
+	apiCmd.Flags().StringVarP(&apiOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(apiCmd)
+}
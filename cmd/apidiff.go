@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	apiDiffFailBreaking bool
+	apiDiffInternal     bool
+	apiDiffFormat       string
+	apiDiffOutput       string
+)
+
+// apiDiffCmd classifies the changes to the exported API between two versions
+var apiDiffCmd = &cobra.Command{
+	Use:   "api-diff <old> [<new>]",
+	Short: "Classify exported API changes as compatible or breaking",
+	Long: `Compare the exported API of two versions of the project, as listed by
+"codegraph api", and suggest a semantic version bump. Each side is a graph
+file or a git revision of the repository at --path, as for "codegraph diff";
+without <new> it is compared with the analysis of --path, e.g.
+
+  codegraph api-diff v1.4.0
+  codegraph api-diff v1.4.0 v1.5.0 -f markdown
+
+Removed symbols, changed parameter or result types, changed field,
+variable and constant types and methods added to an existing interface are
+breaking and suggest a major version. Added symbols and deprecations are
+compatible and suggest a minor version; anything else a patch. Modules
+before v1 conventionally bump the minor version for breaking changes.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		old, err := loadSnapshot(args[0])
+		if err != nil {
+			return err
+		}
+		var new graph.ProjectStructure
+		if len(args) == 2 {
+			new, err = loadSnapshot(args[1])
+		} else {
+			new, err = loadProject()
+		}
+		if err != nil {
+			return err
+		}
+
+		d := graph.DiffAPI(old, new, graph.APIOptions{Internal: apiDiffInternal})
+		err = graph.WriteOutput(apiDiffOutput, func(w io.Writer) error {
+			switch apiDiffFormat {
+			case "text":
+				return d.WriteText(w)
+			case "json":
+				return d.WriteJSON(w)
+			case "markdown", "md":
+				return d.WriteMarkdown(w)
+			default:
+				return fmt.Errorf("unknown api-diff format %q (want text, json or markdown)", apiDiffFormat)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if apiDiffFailBreaking && len(d.Breaking) > 0 {
+			// Breaking the API is not a usage error
+			cmd.SilenceUsage = true
+			return fmt.Errorf("breaking API changes: %d", len(d.Breaking))
+		}
+		return nil
+	},
+}
+
+func init() {
+	apiDiffCmd.Flags().BoolVar(&apiDiffFailBreaking, "fail-on-breaking", false, "Exit with an error when there are breaking changes")
+	apiDiffCmd.Flags().BoolVar(&apiDiffInternal, "internal", false, "Include packages under internal directories")
+	apiDiffCmd.Flags().StringVarP(&apiDiffFormat, "format", "f", "text", "Output format: text, json or markdown")
+	apiDiffCmd.Flags().StringVarP(&apiDiffOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(apiDiffCmd)
+}
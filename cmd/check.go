@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	checkConfig string
+	checkFormat string
+	checkOutput string
+)
+
+// checkCmd enforces the architecture rules of .codegraph.yaml
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check layering and dependency rules from .codegraph.yaml",
+	Long: `Check the project against the architecture rules in .codegraph.yaml, read
+from the --path directory unless --config names another file:
+
+  # Layers from top to bottom, each named after its directory...
+  layers: api -> service -> storage
+  # ...or listing its packages
+  # layers:
+  #   - name: api
+  #     packages: [cmd/..., api/...]
+
+  forbidden:              # package dependencies that must not exist
+    - from: storage/...
+      to: [net/http, api/...]
+      reason: storage is transport-agnostic
+
+  imports:                # third-party imports allowed per package
+    - packages: ...
+      allow: [github.com/spf13/cobra, gopkg.in/yaml.v3]
+
+  rules:                  # calls that must not be made
+    - name: no-sql-in-handlers
+      from: handlers/...
+      must_not_call: database/sql
+
+Every violation is printed with the offending import or call and its
+location, and the command fails when there is any.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile := checkConfig
+		if configFile == "" {
+			configFile = filepath.Join(projectPath, ".codegraph.yaml")
+			if info, err := os.Stat(projectPath); err == nil && !info.IsDir() {
+				configFile = ".codegraph.yaml"
+			}
+		}
+		cfg, err := graph.LoadCheckConfig(configFile)
+		if err != nil {
+			return err
+		}
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+
+		violations := graph.Check(result, cfg)
+		err = graph.WriteOutput(checkOutput, func(w io.Writer) error {
+			switch checkFormat {
+			case "text":
+				return graph.WriteViolations(w, violations)
+			case "json":
+				return graph.WriteViolationsJSON(w, violations)
+			default:
+				return fmt.Errorf("unknown check format %q (want text or json)", checkFormat)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			// Failing a rule is not a usage error
+			cmd.SilenceUsage = true
+			return fmt.Errorf("architecture rules violated: %d", len(violations))
+		}
+		return nil
+	},
+}
+
+func init() {
+	checkCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	checkCmd.Flags().StringVarP(&checkConfig, "config", "c", "", "Rule file (default .codegraph.yaml in --path)")
+	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "Output format: text or json")
+	checkCmd.Flags().StringVarP(&checkOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	cyclesKinds  []string
+	cyclesSelf   bool
+	cyclesFormat string
+	cyclesOutput string
+)
+
+// cyclesCmd reports import, call and type reference cycles
+var cyclesCmd = &cobra.Command{
+	Use:   "cycles",
+	Short: "Find import, call and type reference cycles",
+	Long: `Find the strongly connected components of three graphs:
+
+  import  packages of the project importing each other, through any path
+  call    functions and methods calling each other (mutual recursion)
+  type    structs referring to each other through field types or embedding
+
+Each cycle lists its members and every edge between them with its
+location, which shows what would have to move to break it up, e.g. before
+splitting a package in a way Go would reject as an import cycle.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		cycles, err := graph.FindCycles(result, graph.CycleOptions{Kinds: cyclesKinds, SelfLoops: cyclesSelf})
+		if err != nil {
+			return err
+		}
+		return graph.WriteOutput(cyclesOutput, func(w io.Writer) error {
+			switch cyclesFormat {
+			case "text":
+				return graph.WriteCycles(w, cycles)
+			case "json":
+				return graph.WriteCyclesJSON(w, cycles)
+			default:
+				return fmt.Errorf("unknown cycles format %q (want text or json)", cyclesFormat)
+			}
+		})
+	},
+}
+
+func init() {
+	cyclesCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	cyclesCmd.Flags().StringSliceVarP(&cyclesKinds, "kind", "k", nil, "Cycle kinds to find: "+strings.Join(graph.CycleKinds, ", ")+" (default all)")
+	cyclesCmd.Flags().BoolVar(&cyclesSelf, "self", false, "Also report direct recursion and self-referential types")
+	cyclesCmd.Flags().StringVarP(&cyclesFormat, "format", "f", "text", "Output format: text or json")
+	cyclesCmd.Flags().StringVarP(&cyclesOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(cyclesCmd)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	deadcodeExported bool
+	deadcodeFormat   string
+	deadcodeOutput   string
+)
+
+// deadcodeCmd lists symbols no entry point reaches
+var deadcodeCmd = &cobra.Command{
+	Use:   "deadcode",
+	Short: "Find functions, types, constants and variables nothing reaches",
+	Long: `Find symbols that no entry point reaches over the edges of the graph.
+Entry points are main and init functions, test functions, methods that
+satisfy an interface and, unless --exported-roots=false, exported symbols.
+
+Each candidate is printed with its file:line, kind, name and the reason it
+is considered dead. Test files are not analyzed, so code only used by tests
+shows up as dead, and calls through function values or method values
+stored elsewhere may be missed; review the candidates before deleting.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		dead := graph.FindDeadCode(result, graph.DeadCodeOptions{ExportedRoots: deadcodeExported})
+		return graph.WriteOutput(deadcodeOutput, func(w io.Writer) error {
+			switch deadcodeFormat {
+			case "text":
+				return graph.WriteDeadCode(w, dead)
+			case "json":
+				return graph.WriteDeadCodeJSON(w, dead)
+			default:
+				return fmt.Errorf("unknown deadcode format %q (want text or json)", deadcodeFormat)
+			}
+		})
+	},
+}
+
+func init() {
+	deadcodeCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	deadcodeCmd.Flags().BoolVar(&deadcodeExported, "exported-roots", true, "Treat exported symbols as entry points")
+	deadcodeCmd.Flags().StringVarP(&deadcodeFormat, "format", "f", "text", "Output format: text or json")
+	deadcodeCmd.Flags().StringVarP(&deadcodeOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(deadcodeCmd)
+}
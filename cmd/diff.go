@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	diffFormat string
+	diffOutput string
+)
+
+// diffCmd compares two versions of the code graph
+var diffCmd = &cobra.Command{
+	Use:   "diff <old> [<new>]",
+	Short: "Compare two graph files or git revisions",
+	Long: `Compare two versions of the code graph. Each side is either a graph file
+written by codegraph or a git revision of the repository at --path, read
+straight from git without a checkout. Without <new> it is compared with the
+analysis of --path (at --rev, if given), e.g.
+
+  codegraph diff main
+  codegraph diff v1.2.0 v1.3.0 -f markdown
+  codegraph diff old.json new.db`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		old, err := loadSnapshot(args[0])
+		if err != nil {
+			return err
+		}
+		var new graph.ProjectStructure
+		if len(args) == 2 {
+			new, err = loadSnapshot(args[1])
+		} else {
+			new, err = loadProject()
+		}
+		if err != nil {
+			return err
+		}
+
+		d := graph.Diff(old, new)
+		return graph.WriteOutput(diffOutput, func(w io.Writer) error {
+			switch diffFormat {
+			case "text":
+				return d.WriteText(w)
+			case "json":
+				return d.WriteJSON(w)
+			case "markdown", "md":
+				return d.WriteMarkdown(w)
+			default:
+				return fmt.Errorf("unknown diff format %q (want text, json or markdown)", diffFormat)
+			}
+		})
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format: text, json or markdown")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// loadSnapshot loads a graph file, or analyzes a git revision when no such
+// file exists.
+func loadSnapshot(arg string) (graph.ProjectStructure, error) {
+	if _, err := os.Stat(arg); err == nil {
+		return graph.Load(arg)
+	}
+	return graph.AnalyzeRevision(projectPath, projectName, arg)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var htmlOutputFile string
+
+// htmlCmd writes the interactive graph viewer
+var htmlCmd = &cobra.Command{
+	Use:   "html",
+	Short: "Generate a self-contained interactive HTML graph viewer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fromFile != "" {
+			return convertSnapshot(cmd, htmlOutputFile, "html")
+		}
+		src, err := projectSource()
+		if err != nil {
+			return err
+		}
+		return graph.ProcessSource(src, projectName, htmlOutputFile, "html")
+	},
+}
+
+func init() {
+	htmlCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	htmlCmd.Flags().StringVarP(&htmlOutputFile, "output", "o", "codegraph.html", "Output HTML file (\"-\" for stdout)")
+	rootCmd.AddCommand(htmlCmd)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+	"github.com/srinidhi-metadome/go-codegraph-cli/server"
+)
+
+var lspInterval time.Duration
+
+// lspCmd serves the graph to editors over the Language Server Protocol
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Serve call and type hierarchies, symbols and references over LSP on stdin and stdout",
+	Long: `Run a language server on stdin and stdout that answers from the code graph
+instead of type-checking, as a lightweight companion or alternative to gopls:
+
+  textDocument/prepareCallHierarchy, callHierarchy/incomingCalls and
+  callHierarchy/outgoingCalls     calls across packages
+  textDocument/prepareTypeHierarchy, typeHierarchy/supertypes and
+  typeHierarchy/subtypes          implemented interfaces and embedded structs
+  workspace/symbol                declarations by name
+  textDocument/references         calls, type uses and other references
+
+--path must be the workspace folder the editor opens. Changed Go files are
+picked up every --interval once saved.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var src graph.Source
+		if fromFile == "" {
+			var err error
+			if src, err = projectSource(); err != nil {
+				return err
+			}
+		}
+		srv, err := server.New(func() (graph.ProjectStructure, error) {
+			if fromFile != "" {
+				return graph.Load(fromFile)
+			}
+			return graph.AnalyzeSource(src, projectName)
+		}, server.Options{})
+		if err != nil {
+			return err
+		}
+
+		if info, err := os.Stat(projectPath); fromFile == "" && revision == "" && lspInterval > 0 && err == nil && info.IsDir() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go srv.Watch(ctx, src, lspInterval, func(err error) {
+				fmt.Fprintln(os.Stderr, "codegraph:", err)
+			})
+		}
+		return srv.ServeLSP(os.Stdin, os.Stdout, projectPath)
+	},
+}
+
+func init() {
+	lspCmd.Flags().DurationVar(&lspInterval, "interval", 2*time.Second, "How often to check for changed files (0 to never re-analyze)")
+	lspCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	rootCmd.AddCommand(lspCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+	"github.com/srinidhi-metadome/go-codegraph-cli/server"
+)
+
+var mcpInterval time.Duration
+
+// mcpCmd serves the graph to AI assistants over the Model Context Protocol
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve the code graph to AI assistants over MCP on stdin and stdout",
+	Long: `Run a Model Context Protocol server on stdin and stdout, offering tools
+that navigate the project by its code graph instead of by text search:
+
+  find_symbol          find declarations by symbol or regular expression
+  get_callers          who calls a function or method, as a tree
+  get_callees          what a function or method calls, as a tree
+  get_type_definition  a struct or interface with its methods and relations
+  list_package_api     exported declarations of a package with signatures
+  get_source           the source of a declaration, or lines of a file
+
+The project directory is checked for changed Go files every --interval and
+analyzed again when they change, so answers follow the code being edited.
+Register it with an assistant as a stdio server, e.g.
+
+  {"command": "codegraph", "args": ["mcp", "--path", "/path/to/project"]}`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var src graph.Source
+		var opts server.Options
+		if fromFile == "" {
+			var err error
+			if src, err = projectSource(); err != nil {
+				return err
+			}
+			opts.Files = src.FS
+		}
+		srv, err := server.New(func() (graph.ProjectStructure, error) {
+			if fromFile != "" {
+				return graph.Load(fromFile)
+			}
+			return graph.AnalyzeSource(src, projectName)
+		}, opts)
+		if err != nil {
+			return err
+		}
+
+		// Only a working tree changes; archives and revisions are fixed
+		if info, err := os.Stat(projectPath); fromFile == "" && revision == "" && mcpInterval > 0 && err == nil && info.IsDir() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go srv.Watch(ctx, src, mcpInterval, func(err error) {
+				fmt.Fprintln(os.Stderr, "codegraph:", err)
+			})
+		}
+		return srv.ServeMCP(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	mcpCmd.Flags().DurationVar(&mcpInterval, "interval", 2*time.Second, "How often to check for changed files (0 to never re-analyze)")
+	mcpCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	rootCmd.AddCommand(mcpCmd)
+}
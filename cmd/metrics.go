@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	metricsLevel  string
+	metricsFormat string
+	metricsOutput string
+	metricsSort   string
+	metricsTop    int
+	metricsMax    map[string]string
+)
+
+// metricsCmd reports code metrics and enforces thresholds on them
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Report complexity, size and coupling metrics",
+	Long: `Report code metrics at one level:
+
+  function  cyclomatic and cognitive complexity, lines, parameters, results,
+            nesting depth, fan-in and fan-out over calls edges
+  struct    field and method counts
+  file      lines, function count, total and maximum cyclomatic complexity
+  package   afferent and efferent coupling over imports, instability,
+            abstractness and distance from the main sequence
+
+Thresholds given with --max fail the command, for use in CI, e.g.
+
+  codegraph metrics --max cyclomatic=15,cognitive=20
+  codegraph metrics --level package --max distance=0.7 -f csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limits := make(map[string]float64, len(metricsMax))
+		for name, value := range metricsMax {
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --max %s=%s: want a number", name, value)
+			}
+			limits[name] = limit
+		}
+
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		report := graph.NewMetricsReport(result)
+		table, err := report.Table(metricsLevel)
+		if err != nil {
+			return err
+		}
+		violations, err := graph.CheckThresholds(table, limits)
+		if err != nil {
+			return err
+		}
+		if err := sortMetrics(table); err != nil {
+			return err
+		}
+
+		err = graph.WriteOutput(metricsOutput, func(w io.Writer) error {
+			switch metricsFormat {
+			case "table":
+				return table.WriteTable(w)
+			case "csv":
+				return table.WriteCSV(w)
+			case "json":
+				return table.WriteJSON(w)
+			default:
+				return fmt.Errorf("unknown metrics format %q (want table, csv or json)", metricsFormat)
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range violations {
+			fmt.Fprintln(cmd.ErrOrStderr(), v)
+		}
+		if len(violations) > 0 {
+			// Failing a threshold is not a usage error
+			cmd.SilenceUsage = true
+			return fmt.Errorf("metric thresholds exceeded: %d", len(violations))
+		}
+		return nil
+	},
+}
+
+func init() {
+	metricsCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	metricsCmd.Flags().StringVarP(&metricsLevel, "level", "l", "function", "Report level: "+strings.Join(graph.MetricsLevels, ", "))
+	metricsCmd.Flags().StringVarP(&metricsFormat, "format", "f", "table", "Output format: table, csv or json")
+	metricsCmd.Flags().StringVarP(&metricsOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+	metricsCmd.Flags().StringVarP(&metricsSort, "sort", "s", "", "Sort by this metric, highest first")
+	metricsCmd.Flags().IntVar(&metricsTop, "top", 0, "Only show the first N rows (0 for all)")
+	metricsCmd.Flags().StringToStringVar(&metricsMax, "max", nil, "Fail when a metric exceeds a maximum, e.g. cyclomatic=15")
+	rootCmd.AddCommand(metricsCmd)
+}
+
+// sortMetrics applies --sort and --top to a metrics table.
+func sortMetrics(table *graph.QueryResult) error {
+	if metricsSort != "" {
+		col := -1
+		for i, c := range table.Columns {
+			if c == metricsSort {
+				col = i
+			}
+		}
+		if col < 2 {
+			return fmt.Errorf("cannot sort by %q (want one of %s)", metricsSort, strings.Join(table.Columns[2:], ", "))
+		}
+		sort.SliceStable(table.Rows, func(i, j int) bool {
+			return metricValue(table.Rows[i][col]) > metricValue(table.Rows[j][col])
+		})
+	}
+	if metricsTop > 0 && len(table.Rows) > metricsTop {
+		table.Rows = table.Rows[:metricsTop]
+	}
+	return nil
+}
+
+func metricValue(v any) float64 {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
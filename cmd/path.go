@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	pathRelations []string
+	pathAll       bool
+	pathMaxLength int
+	pathLimit     int
+)
+
+// pathCmd finds how one symbol reaches another
+var pathCmd = &cobra.Command{
+	Use:   "path <from> <to>",
+	Short: "Find the shortest path, or all simple paths, between two symbols",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		idx := graph.NewIndex(result.CodeGraph)
+		from, err := resolveIDs(idx, args[0])
+		if err != nil {
+			return err
+		}
+		to, err := resolveIDs(idx, args[1])
+		if err != nil {
+			return err
+		}
+		opts := graph.TraverseOptions{Relations: pathRelations}
+
+		var paths [][]graph.Edge
+		if pathAll {
+			paths = idx.AllPaths(from, to, pathMaxLength, pathLimit, opts)
+		} else if p := idx.ShortestPath(from, to, opts); p != nil {
+			paths = [][]graph.Edge{p}
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no path from %s to %s over %v", args[0], args[1], pathRelations)
+		}
+
+		w := cmd.OutOrStdout()
+		for i, p := range paths {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if len(p) == 0 {
+				fmt.Fprintf(w, "%s is both source and target\n", args[0])
+				continue
+			}
+			fmt.Fprintf(w, "# %d hop(s)\n", len(p))
+			if err := idx.WritePath(w, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	pathCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	pathCmd.Flags().StringSliceVarP(&pathRelations, "relation", "r", []string{"calls"},
+		"Edge relations to follow, e.g. calls,uses,has_method,implements")
+	pathCmd.Flags().BoolVarP(&pathAll, "all", "a", false, "List all simple paths instead of only the shortest")
+	pathCmd.Flags().IntVar(&pathMaxLength, "max-length", 6, "Maximum number of hops per path with --all")
+	pathCmd.Flags().IntVar(&pathLimit, "limit", 100, "Maximum number of paths to list with --all (0 for no limit)")
+	rootCmd.AddCommand(pathCmd)
+}
+
+// resolveIDs resolves a symbol to node IDs, failing when nothing matches.
+func resolveIDs(idx *graph.Index, symbol string) ([]string, error) {
+	nodes := idx.Resolve(symbol)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no symbol matches %q", symbol)
+	}
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+var (
+	fromFile       string
+	queryDepth     int
+	queryRelations []string
+	queryView      string
+	queryFormat    string
+	queryOutput    string
+)
+
+// queryCmd runs a graph query expression and groups the canned query
+// subcommands
+var queryCmd = &cobra.Command{
+	Use:   "query '<expr>'",
+	Short: "Query the code graph",
+	Long: `Query the code graph with a Cypher-like expression, for example
+
+  codegraph query 'MATCH (f:function)-[:calls*1..3]->(g {package: "sql"})
+                   WHERE f.package = "handlers" RETURN DISTINCT f, g'
+
+Expression results are printed as a table by default; --view list prints
+tab-separated rows, --view json an array of objects and --view subgraph the
+matched nodes encoded in --format.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExpression(args[0])
+	},
+}
+
+var callersCmd = &cobra.Command{
+	Use:   "callers <symbol>",
+	Short: "Show who calls a symbol, transitively up to --depth",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTraversal(args[0], graph.Backward)
+	},
+}
+
+var calleesCmd = &cobra.Command{
+	Use:   "callees <symbol>",
+	Short: "Show what a symbol calls, transitively up to --depth",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTraversal(args[0], graph.Forward)
+	},
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <key>[:<name>]",
+	Short: "Find struct fields by struct tag key and name",
+	Long: `Find the struct fields with a struct tag key, or going by a name under it,
+for example every JSON payload with an "id" key or every db column:
+
+  codegraph query tag json:id
+  codegraph query tag db
+
+The name is the part of the tag value before the first comma. Exported
+fields of a struct with json tags are taken to go by their Go name when
+their tag names none, as encoding/json encodes them. Each field is printed
+with its struct's location, its type, its name under the key and its whole
+tag; --view json prints an array and --view subgraph the structs.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, name, _ := strings.Cut(args[0], ":")
+		if key == "" {
+			return fmt.Errorf("missing tag key in %q", args[0])
+		}
+		result, err := loadProject()
+		if err != nil {
+			return err
+		}
+		fields := graph.FindTaggedFields(result, key, name)
+
+		return graph.WriteOutput(queryOutput, func(w io.Writer) error {
+			switch queryView {
+			case "tree", "table", "list":
+				return graph.WriteTaggedFields(w, fields)
+			case "json":
+				return graph.WriteTaggedFieldsJSON(w, fields)
+			case "subgraph":
+				keep := make(map[string]bool)
+				for _, f := range fields {
+					keep[f.Struct.ID] = true
+				}
+				return graph.Encode(w, queryFormat, graph.Subgraph(result, keep),
+					graph.EncodeOptions{ProjectPath: projectPath, ProjectName: projectName})
+			default:
+				return fmt.Errorf("unknown view %q (want list, json or subgraph)", queryView)
+			}
+		})
+	},
+}
+
+func init() {
+	flags := queryCmd.PersistentFlags()
+	flags.StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	flags.IntVarP(&queryDepth, "depth", "d", 1, "Maximum number of hops to follow (0 for unlimited)")
+	flags.StringSliceVarP(&queryRelations, "relation", "r", []string{"calls"}, "Edge relations to follow")
+	flags.StringVar(&queryView, "view", "tree", "Result view: tree, list or subgraph (expressions: table, list, json or subgraph)")
+	flags.StringVarP(&queryFormat, "format", "f", "json", "Output format for --view subgraph")
+	flags.StringVarP(&queryOutput, "output", "o", "-", "Output file (\"-\" for stdout)")
+
+	queryCmd.AddCommand(callersCmd, calleesCmd, tagCmd)
+	rootCmd.AddCommand(queryCmd)
+}
+
+// loadProject returns the graph saved in --from, or analyzes --path (at
+// --rev, if given).
+func loadProject() (graph.ProjectStructure, error) {
+	if fromFile == "" {
+		src, err := projectSource()
+		if err != nil {
+			return graph.ProjectStructure{}, err
+		}
+		return graph.AnalyzeSource(src, projectName)
+	}
+	return graph.Load(fromFile)
+}
+
+func runTraversal(symbol string, dir graph.Direction) error {
+	result, err := loadProject()
+	if err != nil {
+		return err
+	}
+	idx := graph.NewIndex(result.CodeGraph)
+	rootIDs, err := resolveIDs(idx, symbol)
+	if err != nil {
+		return err
+	}
+	opts := graph.TraverseOptions{Depth: queryDepth, Relations: queryRelations}
+
+	return graph.WriteOutput(queryOutput, func(w io.Writer) error {
+		switch queryView {
+		case "tree":
+			for _, id := range rootIDs {
+				if err := idx.WriteTree(w, id, dir, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		case "list":
+			return graph.WriteList(w, idx.Traverse(rootIDs, dir, opts))
+		case "subgraph":
+			keep := make(map[string]bool)
+			for _, step := range idx.Traverse(rootIDs, dir, opts) {
+				keep[step.Node.ID] = true
+			}
+			return graph.Encode(w, queryFormat, graph.Subgraph(result, keep),
+				graph.EncodeOptions{ProjectPath: projectPath, ProjectName: projectName})
+		default:
+			return fmt.Errorf("unknown view %q (want tree, list or subgraph)", queryView)
+		}
+	})
+}
+
+func runExpression(expr string) error {
+	q, err := graph.ParseQuery(expr)
+	if err != nil {
+		return err
+	}
+	result, err := loadProject()
+	if err != nil {
+		return err
+	}
+	res, err := q.Run(graph.NewIndex(result.CodeGraph))
+	if err != nil {
+		return err
+	}
+
+	return graph.WriteOutput(queryOutput, func(w io.Writer) error {
+		switch queryView {
+		case "tree", "table":
+			return res.WriteTable(w)
+		case "list":
+			return res.WriteList(w)
+		case "json":
+			return res.WriteJSON(w)
+		case "subgraph":
+			return graph.Encode(w, queryFormat, graph.Subgraph(result, res.NodeIDs()),
+				graph.EncodeOptions{ProjectPath: projectPath, ProjectName: projectName})
+		default:
+			return fmt.Errorf("unknown view %q (want table, list, json or subgraph)", queryView)
+		}
+	})
+}
@@ -3,15 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/srinidhi-metadome/go-codegraph-cli/internal/graph"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
 )
 
 var (
 	projectPath string
 	projectName string
+	revision    string
 	outputFile  string
+	format      string
 )
 
 // rootCmd represents the base command
@@ -19,14 +22,52 @@ var rootCmd = &cobra.Command{
 	Use:   "codegraph",
 	Short: "Analyze a Go project and produce a codegraph JSON",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return graph.ProcessProject(projectPath, projectName, outputFile)
+		if fromFile != "" {
+			return convertSnapshot(cmd, outputFile, format)
+		}
+		src, err := projectSource()
+		if err != nil {
+			return err
+		}
+		return graph.ProcessSource(src, projectName, outputFile, format)
 	},
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&projectPath, "path", "p", ".", "Go project root path")
-	rootCmd.Flags().StringVarP(&projectName, "name", "n", "MyProject", "Project name in JSON")
-	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "output.json", "Output JSON file")
+	rootCmd.PersistentFlags().StringVarP(&projectPath, "path", "p", ".", "Go project root directory, or a .zip or .tar.gz archive of it")
+	rootCmd.PersistentFlags().StringVarP(&projectName, "name", "n", "MyProject", "Project name in JSON")
+	rootCmd.PersistentFlags().StringVar(&revision, "rev", "", "Analyze --path as of this git revision instead of the working tree")
+	rootCmd.Flags().StringVar(&fromFile, "from", "", "Convert a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "output.json", "Output file (\"-\" for stdout)")
+	rootCmd.Flags().StringVarP(&format, "format", "f", "json",
+		"Comma-separated output formats: "+strings.Join(graph.Formats(), ", "))
+}
+
+// projectSource returns the files to analyze: the --path directory or
+// archive, or the directory's tree at --rev.
+func projectSource() (graph.Source, error) {
+	if revision == "" {
+		return graph.OpenSource(projectPath)
+	}
+	return graph.RevisionSource(projectPath, revision)
+}
+
+// convertSnapshot writes the graph loaded by loadProject in the given
+// formats. The project keeps the name it was saved under unless --name is
+// given.
+func convertSnapshot(cmd *cobra.Command, output, formats string) error {
+	result, err := loadProject()
+	if err != nil {
+		return err
+	}
+	name := projectName
+	if !cmd.Flags().Changed("name") {
+		for saved := range result.Project {
+			name = saved
+		}
+	}
+	return graph.WriteFormats(result, output, formats,
+		graph.EncodeOptions{ProjectPath: projectPath, ProjectName: name})
 }
 
 func Execute() {
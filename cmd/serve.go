@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srinidhi-metadome/go-codegraph-cli/server"
+)
+
+var (
+	serveAddr     string
+	serveGraphQL  bool
+	serveGRPC     bool
+	serveGRPCAddr string
+	serveWatch    time.Duration
+)
+
+// serveCmd serves the graph over HTTP for editors, dashboards and other
+// tools
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the code graph over a REST/JSON API, GraphQL and gRPC",
+	Long: `Analyze the project once and serve the graph over HTTP:
+
+  GET  /api/project                      node, edge and file counts
+  POST /api/analyze                      analyze the project again
+  GET  /api/nodes?name=&match=&symbol=   search by name, regexp or symbol,
+                                         narrowed by &type= and &package=
+  GET  /api/nodes/{id}                   a node with its edges
+  GET  /api/nodes/{id}/neighbors         adjacent nodes, by &relation= and
+                                         &direction=out, in or both
+  GET  /api/nodes/{id}/callers?depth=    transitive callers
+  GET  /api/nodes/{id}/callees?depth=    transitive callees
+  GET  /api/paths?from=&to=              shortest call path, or every path
+                                         of at most &max= edges with &all=true
+  GET  /api/files                        analyzed files
+  GET  /api/files/{path}                 imports, structs and functions of a file
+  GET  /api/query?q=                     a graph query expression
+
+Errors are returned as {"error": "..."} with a 4xx or 5xx status.
+
+With --graphql the graph is also served as a GraphQL schema at /graphql,
+over Node, Edge, StructInfo, InterfaceInfo, FunctionInfo, File and Package
+with nested fields for their relations, so a client can fetch a whole
+neighborhood in one request:
+
+  { package(path: "graph") { structs { name methods { name callers { qualifiedName } } } } }
+
+With --grpc the CodeGraphService of proto/codegraph/v1/service.proto is
+served on --grpc-addr as well, offering Analyze, GetNode, Neighbors, Query
+and WatchChanges, which streams what changed after every re-analysis.
+Re-analysis happens on POST /api/analyze, the Analyze RPC, or, with
+--watch, whenever the Go files of the project change.
+
+Nothing is authenticated: anyone who can reach --addr or --grpc-addr can
+read the graph, including doc comments and source positions, and make the
+server analyze the project again, though always the one given on the
+command line. Both therefore default to the loopback interface; pass e.g.
+--addr :8080 only on a trusted network.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv, err := server.New(loadProject, server.Options{GraphQL: serveGraphQL})
+		if err != nil {
+			return err
+		}
+
+		// Only a working tree changes; archives and revisions are fixed
+		if info, err := os.Stat(projectPath); fromFile == "" && revision == "" && serveWatch > 0 && err == nil && info.IsDir() {
+			src, err := projectSource()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go srv.Watch(ctx, src, serveWatch, func(err error) {
+				fmt.Fprintln(os.Stderr, "codegraph:", err)
+			})
+		}
+
+		errc := make(chan error, 2)
+		if serveGRPC {
+			lis, err := net.Listen("tcp", serveGRPCAddr)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Serving %s over gRPC on %s\n", projectName, serveGRPCAddr)
+			go func() { errc <- srv.GRPCServer().Serve(lis) }()
+		}
+		fmt.Fprintf(os.Stderr, "Serving %s on %s\n", projectName, serveAddr)
+		go func() { errc <- http.ListenAndServe(serveAddr, srv.Handler()) }()
+		return <-errc
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().BoolVar(&serveGraphQL, "graphql", false, "Also serve a GraphQL endpoint at /graphql")
+	serveCmd.Flags().BoolVar(&serveGRPC, "grpc", false, "Also serve the gRPC API on --grpc-addr")
+	serveCmd.Flags().StringVar(&serveGRPCAddr, "grpc-addr", "127.0.0.1:9090", "Address to serve gRPC on")
+	serveCmd.Flags().DurationVar(&serveWatch, "watch", 0, "Analyze again when Go files change, checking this often (0 to never)")
+	serveCmd.Flags().StringVar(&fromFile, "from", "", "Load a previously generated graph file (json, jsonl, sqlite or proto) instead of analyzing --path")
+	rootCmd.AddCommand(serveCmd)
+}
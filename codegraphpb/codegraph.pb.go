@@ -0,0 +1,1668 @@
+// Protocol buffer encoding of the codegraph model. Messages mirror the Go
+// types in package graph field for field; see ProjectStructure.schema_version
+// for the compatibility rules.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: codegraph/v1/codegraph.proto
+
+package codegraphpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProjectStructure is the root message written by `codegraph --format proto`.
+type ProjectStructure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of this schema the message was written with. Fields are only
+	// ever added, which keeps the version unchanged; the version is bumped
+	// when a field changes meaning or is removed, and readers reject messages
+	// with a version newer than the one they were built against.
+	SchemaVersion uint32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Packages keyed by project name.
+	Project       map[string]*PackageInfo `protobuf:"bytes,2,rep,name=project,proto3" json:"project,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CodeGraph     *CodeGraph              `protobuf:"bytes,3,opt,name=code_graph,json=codeGraph,proto3" json:"code_graph,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectStructure) Reset() {
+	*x = ProjectStructure{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectStructure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectStructure) ProtoMessage() {}
+
+func (x *ProjectStructure) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectStructure.ProtoReflect.Descriptor instead.
+func (*ProjectStructure) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProjectStructure) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *ProjectStructure) GetProject() map[string]*PackageInfo {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *ProjectStructure) GetCodeGraph() *CodeGraph {
+	if x != nil {
+		return x.CodeGraph
+	}
+	return nil
+}
+
+type PackageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Files keyed by path relative to the project root.
+	Modules map[string]*ModuleInfo `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Module path declared by go.mod, if any.
+	Module        string `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageInfo) Reset() {
+	*x = PackageInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageInfo) ProtoMessage() {}
+
+func (x *PackageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
+func (*PackageInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PackageInfo) GetModules() map[string]*ModuleInfo {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *PackageInfo) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+// ModuleInfo describes a single Go file.
+type ModuleInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Package      string                 `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	Structs      []*StructInfo          `protobuf:"bytes,2,rep,name=structs,proto3" json:"structs,omitempty"`
+	Functions    []*FunctionInfo        `protobuf:"bytes,3,rep,name=functions,proto3" json:"functions,omitempty"`
+	Interfaces   []*InterfaceInfo       `protobuf:"bytes,4,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Dependencies []string               `protobuf:"bytes,5,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Constants    []*ConstantInfo        `protobuf:"bytes,6,rep,name=constants,proto3" json:"constants,omitempty"`
+	Variables    []*VariableInfo        `protobuf:"bytes,7,rep,name=variables,proto3" json:"variables,omitempty"`
+	// Number of lines in the file.
+	Lines int32       `protobuf:"varint,8,opt,name=lines,proto3" json:"lines,omitempty"`
+	Types []*TypeInfo `protobuf:"bytes,9,rep,name=types,proto3" json:"types,omitempty"`
+	// Line of the import spec of each of dependencies.
+	ImportLines   []int32 `protobuf:"varint,10,rep,packed,name=import_lines,json=importLines,proto3" json:"import_lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleInfo) Reset() {
+	*x = ModuleInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleInfo) ProtoMessage() {}
+
+func (x *ModuleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleInfo.ProtoReflect.Descriptor instead.
+func (*ModuleInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ModuleInfo) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *ModuleInfo) GetStructs() []*StructInfo {
+	if x != nil {
+		return x.Structs
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetInterfaces() []*InterfaceInfo {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetConstants() []*ConstantInfo {
+	if x != nil {
+		return x.Constants
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetVariables() []*VariableInfo {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *ModuleInfo) GetTypes() []*TypeInfo {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ModuleInfo) GetImportLines() []int32 {
+	if x != nil {
+		return x.ImportLines
+	}
+	return nil
+}
+
+type StructInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Methods declared on the struct.
+	Functions     []*FunctionInfo `protobuf:"bytes,2,rep,name=functions,proto3" json:"functions,omitempty"`
+	Properties    []*PropertyInfo `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty"`
+	Comment       string          `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	Id            string          `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	Doc           *Doc            `protobuf:"bytes,6,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StructInfo) Reset() {
+	*x = StructInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructInfo) ProtoMessage() {}
+
+func (x *StructInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructInfo.ProtoReflect.Descriptor instead.
+func (*StructInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StructInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructInfo) GetFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *StructInfo) GetProperties() []*PropertyInfo {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *StructInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *StructInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StructInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type InterfaceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Functions     []*FunctionInfo        `protobuf:"bytes,2,rep,name=functions,proto3" json:"functions,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Doc           *Doc                   `protobuf:"bytes,5,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceInfo) Reset() {
+	*x = InterfaceInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceInfo) ProtoMessage() {}
+
+func (x *InterfaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceInfo.ProtoReflect.Descriptor instead.
+func (*InterfaceInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InterfaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceInfo) GetFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *InterfaceInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *InterfaceInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InterfaceInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+// TypeInfo describes a named type other than a struct or interface.
+type TypeInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Underlying type, or the aliased type.
+	Type  string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Alias bool   `protobuf:"varint,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Methods declared on the type.
+	Functions     []*FunctionInfo `protobuf:"bytes,4,rep,name=functions,proto3" json:"functions,omitempty"`
+	Comment       string          `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	Id            string          `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Doc           *Doc            `protobuf:"bytes,7,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeInfo) Reset() {
+	*x = TypeInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeInfo) ProtoMessage() {}
+
+func (x *TypeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeInfo.ProtoReflect.Descriptor instead.
+func (*TypeInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TypeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TypeInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TypeInfo) GetAlias() bool {
+	if x != nil {
+		return x.Alias
+	}
+	return false
+}
+
+func (x *TypeInfo) GetFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *TypeInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *TypeInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TypeInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type FunctionInfo struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Parameters []*ParameterInfo       `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	ReturnType string                 `protobuf:"bytes,3,opt,name=return_type,json=returnType,proto3" json:"return_type,omitempty"`
+	Content    string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Comment    string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	Id         string                 `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Package    string                 `protobuf:"bytes,7,opt,name=package,proto3" json:"package,omitempty"`
+	FilePath   string                 `protobuf:"bytes,8,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Doc        *Doc                   `protobuf:"bytes,9,opt,name=doc,proto3" json:"doc,omitempty"`
+	// Method declared on *T.
+	PointerReceiver bool `protobuf:"varint,10,opt,name=pointer_receiver,json=pointerReceiver,proto3" json:"pointer_receiver,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FunctionInfo) Reset() {
+	*x = FunctionInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionInfo) ProtoMessage() {}
+
+func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionInfo.ProtoReflect.Descriptor instead.
+func (*FunctionInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FunctionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetParameters() []*ParameterInfo {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *FunctionInfo) GetReturnType() string {
+	if x != nil {
+		return x.ReturnType
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+func (x *FunctionInfo) GetPointerReceiver() bool {
+	if x != nil {
+		return x.PointerReceiver
+	}
+	return false
+}
+
+type PropertyInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The type for embedded fields.
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Doc     *Doc   `protobuf:"bytes,4,opt,name=doc,proto3" json:"doc,omitempty"`
+	// Struct tag, unquoted, and its values by key.
+	Tag           string            `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	Tags          map[string]string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Exported      bool              `protobuf:"varint,7,opt,name=exported,proto3" json:"exported,omitempty"`
+	Embedded      bool              `protobuf:"varint,8,opt,name=embedded,proto3" json:"embedded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PropertyInfo) Reset() {
+	*x = PropertyInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PropertyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertyInfo) ProtoMessage() {}
+
+func (x *PropertyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertyInfo.ProtoReflect.Descriptor instead.
+func (*PropertyInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PropertyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PropertyInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PropertyInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *PropertyInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+func (x *PropertyInfo) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *PropertyInfo) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PropertyInfo) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *PropertyInfo) GetEmbedded() bool {
+	if x != nil {
+		return x.Embedded
+	}
+	return false
+}
+
+type ParameterInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParameterInfo) Reset() {
+	*x = ParameterInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParameterInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterInfo) ProtoMessage() {}
+
+func (x *ParameterInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterInfo.ProtoReflect.Descriptor instead.
+func (*ParameterInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ParameterInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParameterInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ConstantInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	Doc           *Doc                   `protobuf:"bytes,6,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConstantInfo) Reset() {
+	*x = ConstantInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConstantInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConstantInfo) ProtoMessage() {}
+
+func (x *ConstantInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConstantInfo.ProtoReflect.Descriptor instead.
+func (*ConstantInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConstantInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConstantInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ConstantInfo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ConstantInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConstantInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ConstantInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type VariableInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	Doc           *Doc                   `protobuf:"bytes,6,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariableInfo) Reset() {
+	*x = VariableInfo{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariableInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariableInfo) ProtoMessage() {}
+
+func (x *VariableInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariableInfo.ProtoReflect.Descriptor instead.
+func (*VariableInfo) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VariableInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariableInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VariableInfo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *VariableInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VariableInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *VariableInfo) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+// Doc is a doc comment parsed into blocks.
+type Doc struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Blocks []*DocBlock            `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// Notice of a "Deprecated:" paragraph.
+	Deprecated string `protobuf:"bytes,2,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Doc link targets, e.g. "io.Reader" or "Index.Resolve".
+	Links         []string `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Doc) Reset() {
+	*x = Doc{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Doc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Doc) ProtoMessage() {}
+
+func (x *Doc) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Doc.ProtoReflect.Descriptor instead.
+func (*Doc) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Doc) GetBlocks() []*DocBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *Doc) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
+func (x *Doc) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type DocBlock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// paragraph, heading, code or list.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// List items.
+	Items         []string `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocBlock) Reset() {
+	*x = DocBlock{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocBlock) ProtoMessage() {}
+
+func (x *DocBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocBlock.ProtoReflect.Descriptor instead.
+func (*DocBlock) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DocBlock) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DocBlock) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DocBlock) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CodeGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*Edge                `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CodeGraph) Reset() {
+	*x = CodeGraph{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodeGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodeGraph) ProtoMessage() {}
+
+func (x *CodeGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodeGraph.ProtoReflect.Descriptor instead.
+func (*CodeGraph) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CodeGraph) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *CodeGraph) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type Node struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type    string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Package string                 `protobuf:"bytes,4,opt,name=package,proto3" json:"package,omitempty"`
+	File    string                 `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
+	// Declaring struct or interface of a method.
+	Receiver string `protobuf:"bytes,6,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Line     int32  `protobuf:"varint,7,opt,name=line,proto3" json:"line,omitempty"`
+	Column   int32  `protobuf:"varint,8,opt,name=column,proto3" json:"column,omitempty"`
+	// Size and complexity of functions and methods.
+	Metrics *Metrics `protobuf:"bytes,9,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	// Doc comment has a "Deprecated:" paragraph.
+	Deprecated bool `protobuf:"varint,10,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Doc comment of a package node.
+	Doc           *Doc `protobuf:"bytes,11,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Node) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Node) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Node) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *Node) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Node) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *Node) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Node) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *Node) GetMetrics() *Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Node) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *Node) GetDoc() *Doc {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type Metrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cyclomatic    int32                  `protobuf:"varint,1,opt,name=cyclomatic,proto3" json:"cyclomatic,omitempty"`
+	Cognitive     int32                  `protobuf:"varint,2,opt,name=cognitive,proto3" json:"cognitive,omitempty"`
+	Lines         int32                  `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	Params        int32                  `protobuf:"varint,4,opt,name=params,proto3" json:"params,omitempty"`
+	Returns       int32                  `protobuf:"varint,5,opt,name=returns,proto3" json:"returns,omitempty"`
+	Nesting       int32                  `protobuf:"varint,6,opt,name=nesting,proto3" json:"nesting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Metrics) GetCyclomatic() int32 {
+	if x != nil {
+		return x.Cyclomatic
+	}
+	return 0
+}
+
+func (x *Metrics) GetCognitive() int32 {
+	if x != nil {
+		return x.Cognitive
+	}
+	return 0
+}
+
+func (x *Metrics) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *Metrics) GetParams() int32 {
+	if x != nil {
+		return x.Params
+	}
+	return 0
+}
+
+func (x *Metrics) GetReturns() int32 {
+	if x != nil {
+		return x.Returns
+	}
+	return 0
+}
+
+func (x *Metrics) GetNesting() int32 {
+	if x != nil {
+		return x.Nesting
+	}
+	return 0
+}
+
+type Edge struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	From     string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To       string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Relation string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	// Position of the reference in the file of `from`, when known.
+	Line          int32 `protobuf:"varint,4,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32 `protobuf:"varint,5,opt,name=column,proto3" json:"column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_codegraph_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_codegraph_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Edge) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Edge) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Edge) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *Edge) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Edge) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+var File_codegraph_v1_codegraph_proto protoreflect.FileDescriptor
+
+const file_codegraph_v1_codegraph_proto_rawDesc = "" +
+	"\n" +
+	"\x1ccodegraph/v1/codegraph.proto\x12\fcodegraph.v1\"\x8f\x02\n" +
+	"\x10ProjectStructure\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12E\n" +
+	"\aproject\x18\x02 \x03(\v2+.codegraph.v1.ProjectStructure.ProjectEntryR\aproject\x126\n" +
+	"\n" +
+	"code_graph\x18\x03 \x01(\v2\x17.codegraph.v1.CodeGraphR\tcodeGraph\x1aU\n" +
+	"\fProjectEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.codegraph.v1.PackageInfoR\x05value:\x028\x01\"\xbd\x01\n" +
+	"\vPackageInfo\x12@\n" +
+	"\amodules\x18\x01 \x03(\v2&.codegraph.v1.PackageInfo.ModulesEntryR\amodules\x12\x16\n" +
+	"\x06module\x18\x02 \x01(\tR\x06module\x1aT\n" +
+	"\fModulesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.codegraph.v1.ModuleInfoR\x05value:\x028\x01\"\xd0\x03\n" +
+	"\n" +
+	"ModuleInfo\x12\x18\n" +
+	"\apackage\x18\x01 \x01(\tR\apackage\x122\n" +
+	"\astructs\x18\x02 \x03(\v2\x18.codegraph.v1.StructInfoR\astructs\x128\n" +
+	"\tfunctions\x18\x03 \x03(\v2\x1a.codegraph.v1.FunctionInfoR\tfunctions\x12;\n" +
+	"\n" +
+	"interfaces\x18\x04 \x03(\v2\x1b.codegraph.v1.InterfaceInfoR\n" +
+	"interfaces\x12\"\n" +
+	"\fdependencies\x18\x05 \x03(\tR\fdependencies\x128\n" +
+	"\tconstants\x18\x06 \x03(\v2\x1a.codegraph.v1.ConstantInfoR\tconstants\x128\n" +
+	"\tvariables\x18\a \x03(\v2\x1a.codegraph.v1.VariableInfoR\tvariables\x12\x14\n" +
+	"\x05lines\x18\b \x01(\x05R\x05lines\x12,\n" +
+	"\x05types\x18\t \x03(\v2\x16.codegraph.v1.TypeInfoR\x05types\x12!\n" +
+	"\fimport_lines\x18\n" +
+	" \x03(\x05R\vimportLines\"\xe5\x01\n" +
+	"\n" +
+	"StructInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\tfunctions\x18\x02 \x03(\v2\x1a.codegraph.v1.FunctionInfoR\tfunctions\x12:\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2\x1a.codegraph.v1.PropertyInfoR\n" +
+	"properties\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12\x0e\n" +
+	"\x02id\x18\x05 \x01(\tR\x02id\x12#\n" +
+	"\x03doc\x18\x06 \x01(\v2\x11.codegraph.v1.DocR\x03doc\"\xac\x01\n" +
+	"\rInterfaceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\tfunctions\x18\x02 \x03(\v2\x1a.codegraph.v1.FunctionInfoR\tfunctions\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12#\n" +
+	"\x03doc\x18\x05 \x01(\v2\x11.codegraph.v1.DocR\x03doc\"\xd1\x01\n" +
+	"\bTypeInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\bR\x05alias\x128\n" +
+	"\tfunctions\x18\x04 \x03(\v2\x1a.codegraph.v1.FunctionInfoR\tfunctions\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\x12#\n" +
+	"\x03doc\x18\a \x01(\v2\x11.codegraph.v1.DocR\x03doc\"\xcb\x02\n" +
+	"\fFunctionInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12;\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\v2\x1b.codegraph.v1.ParameterInfoR\n" +
+	"parameters\x12\x1f\n" +
+	"\vreturn_type\x18\x03 \x01(\tR\n" +
+	"returnType\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\x12\x18\n" +
+	"\apackage\x18\a \x01(\tR\apackage\x12\x1b\n" +
+	"\tfile_path\x18\b \x01(\tR\bfilePath\x12#\n" +
+	"\x03doc\x18\t \x01(\v2\x11.codegraph.v1.DocR\x03doc\x12)\n" +
+	"\x10pointer_receiver\x18\n" +
+	" \x01(\bR\x0fpointerReceiver\"\xb2\x02\n" +
+	"\fPropertyInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x12#\n" +
+	"\x03doc\x18\x04 \x01(\v2\x11.codegraph.v1.DocR\x03doc\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\x128\n" +
+	"\x04tags\x18\x06 \x03(\v2$.codegraph.v1.PropertyInfo.TagsEntryR\x04tags\x12\x1a\n" +
+	"\bexported\x18\a \x01(\bR\bexported\x12\x1a\n" +
+	"\bembedded\x18\b \x01(\bR\bembedded\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"7\n" +
+	"\rParameterInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\x9b\x01\n" +
+	"\fConstantInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12#\n" +
+	"\x03doc\x18\x06 \x01(\v2\x11.codegraph.v1.DocR\x03doc\"\x9b\x01\n" +
+	"\fVariableInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12#\n" +
+	"\x03doc\x18\x06 \x01(\v2\x11.codegraph.v1.DocR\x03doc\"k\n" +
+	"\x03Doc\x12.\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x16.codegraph.v1.DocBlockR\x06blocks\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x02 \x01(\tR\n" +
+	"deprecated\x12\x14\n" +
+	"\x05links\x18\x03 \x03(\tR\x05links\"H\n" +
+	"\bDocBlock\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05items\x18\x03 \x03(\tR\x05items\"_\n" +
+	"\tCodeGraph\x12(\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.codegraph.v1.NodeR\x05nodes\x12(\n" +
+	"\x05edges\x18\x02 \x03(\v2\x12.codegraph.v1.EdgeR\x05edges\"\xaa\x02\n" +
+	"\x04Node\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\apackage\x18\x04 \x01(\tR\apackage\x12\x12\n" +
+	"\x04file\x18\x05 \x01(\tR\x04file\x12\x1a\n" +
+	"\breceiver\x18\x06 \x01(\tR\breceiver\x12\x12\n" +
+	"\x04line\x18\a \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\b \x01(\x05R\x06column\x12/\n" +
+	"\ametrics\x18\t \x01(\v2\x15.codegraph.v1.MetricsR\ametrics\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\n" +
+	" \x01(\bR\n" +
+	"deprecated\x12#\n" +
+	"\x03doc\x18\v \x01(\v2\x11.codegraph.v1.DocR\x03doc\"\xa9\x01\n" +
+	"\aMetrics\x12\x1e\n" +
+	"\n" +
+	"cyclomatic\x18\x01 \x01(\x05R\n" +
+	"cyclomatic\x12\x1c\n" +
+	"\tcognitive\x18\x02 \x01(\x05R\tcognitive\x12\x14\n" +
+	"\x05lines\x18\x03 \x01(\x05R\x05lines\x12\x16\n" +
+	"\x06params\x18\x04 \x01(\x05R\x06params\x12\x18\n" +
+	"\areturns\x18\x05 \x01(\x05R\areturns\x12\x18\n" +
+	"\anesting\x18\x06 \x01(\x05R\anesting\"r\n" +
+	"\x04Edge\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x05 \x01(\x05R\x06columnBGZEgithub.com/srinidhi-metadome/go-codegraph-cli/codegraphpb;codegraphpbb\x06proto3"
+
+var (
+	file_codegraph_v1_codegraph_proto_rawDescOnce sync.Once
+	file_codegraph_v1_codegraph_proto_rawDescData []byte
+)
+
+func file_codegraph_v1_codegraph_proto_rawDescGZIP() []byte {
+	file_codegraph_v1_codegraph_proto_rawDescOnce.Do(func() {
+		file_codegraph_v1_codegraph_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_codegraph_v1_codegraph_proto_rawDesc), len(file_codegraph_v1_codegraph_proto_rawDesc)))
+	})
+	return file_codegraph_v1_codegraph_proto_rawDescData
+}
+
+var file_codegraph_v1_codegraph_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_codegraph_v1_codegraph_proto_goTypes = []any{
+	(*ProjectStructure)(nil), // 0: codegraph.v1.ProjectStructure
+	(*PackageInfo)(nil),      // 1: codegraph.v1.PackageInfo
+	(*ModuleInfo)(nil),       // 2: codegraph.v1.ModuleInfo
+	(*StructInfo)(nil),       // 3: codegraph.v1.StructInfo
+	(*InterfaceInfo)(nil),    // 4: codegraph.v1.InterfaceInfo
+	(*TypeInfo)(nil),         // 5: codegraph.v1.TypeInfo
+	(*FunctionInfo)(nil),     // 6: codegraph.v1.FunctionInfo
+	(*PropertyInfo)(nil),     // 7: codegraph.v1.PropertyInfo
+	(*ParameterInfo)(nil),    // 8: codegraph.v1.ParameterInfo
+	(*ConstantInfo)(nil),     // 9: codegraph.v1.ConstantInfo
+	(*VariableInfo)(nil),     // 10: codegraph.v1.VariableInfo
+	(*Doc)(nil),              // 11: codegraph.v1.Doc
+	(*DocBlock)(nil),         // 12: codegraph.v1.DocBlock
+	(*CodeGraph)(nil),        // 13: codegraph.v1.CodeGraph
+	(*Node)(nil),             // 14: codegraph.v1.Node
+	(*Metrics)(nil),          // 15: codegraph.v1.Metrics
+	(*Edge)(nil),             // 16: codegraph.v1.Edge
+	nil,                      // 17: codegraph.v1.ProjectStructure.ProjectEntry
+	nil,                      // 18: codegraph.v1.PackageInfo.ModulesEntry
+	nil,                      // 19: codegraph.v1.PropertyInfo.TagsEntry
+}
+var file_codegraph_v1_codegraph_proto_depIdxs = []int32{
+	17, // 0: codegraph.v1.ProjectStructure.project:type_name -> codegraph.v1.ProjectStructure.ProjectEntry
+	13, // 1: codegraph.v1.ProjectStructure.code_graph:type_name -> codegraph.v1.CodeGraph
+	18, // 2: codegraph.v1.PackageInfo.modules:type_name -> codegraph.v1.PackageInfo.ModulesEntry
+	3,  // 3: codegraph.v1.ModuleInfo.structs:type_name -> codegraph.v1.StructInfo
+	6,  // 4: codegraph.v1.ModuleInfo.functions:type_name -> codegraph.v1.FunctionInfo
+	4,  // 5: codegraph.v1.ModuleInfo.interfaces:type_name -> codegraph.v1.InterfaceInfo
+	9,  // 6: codegraph.v1.ModuleInfo.constants:type_name -> codegraph.v1.ConstantInfo
+	10, // 7: codegraph.v1.ModuleInfo.variables:type_name -> codegraph.v1.VariableInfo
+	5,  // 8: codegraph.v1.ModuleInfo.types:type_name -> codegraph.v1.TypeInfo
+	6,  // 9: codegraph.v1.StructInfo.functions:type_name -> codegraph.v1.FunctionInfo
+	7,  // 10: codegraph.v1.StructInfo.properties:type_name -> codegraph.v1.PropertyInfo
+	11, // 11: codegraph.v1.StructInfo.doc:type_name -> codegraph.v1.Doc
+	6,  // 12: codegraph.v1.InterfaceInfo.functions:type_name -> codegraph.v1.FunctionInfo
+	11, // 13: codegraph.v1.InterfaceInfo.doc:type_name -> codegraph.v1.Doc
+	6,  // 14: codegraph.v1.TypeInfo.functions:type_name -> codegraph.v1.FunctionInfo
+	11, // 15: codegraph.v1.TypeInfo.doc:type_name -> codegraph.v1.Doc
+	8,  // 16: codegraph.v1.FunctionInfo.parameters:type_name -> codegraph.v1.ParameterInfo
+	11, // 17: codegraph.v1.FunctionInfo.doc:type_name -> codegraph.v1.Doc
+	11, // 18: codegraph.v1.PropertyInfo.doc:type_name -> codegraph.v1.Doc
+	19, // 19: codegraph.v1.PropertyInfo.tags:type_name -> codegraph.v1.PropertyInfo.TagsEntry
+	11, // 20: codegraph.v1.ConstantInfo.doc:type_name -> codegraph.v1.Doc
+	11, // 21: codegraph.v1.VariableInfo.doc:type_name -> codegraph.v1.Doc
+	12, // 22: codegraph.v1.Doc.blocks:type_name -> codegraph.v1.DocBlock
+	14, // 23: codegraph.v1.CodeGraph.nodes:type_name -> codegraph.v1.Node
+	16, // 24: codegraph.v1.CodeGraph.edges:type_name -> codegraph.v1.Edge
+	15, // 25: codegraph.v1.Node.metrics:type_name -> codegraph.v1.Metrics
+	11, // 26: codegraph.v1.Node.doc:type_name -> codegraph.v1.Doc
+	1,  // 27: codegraph.v1.ProjectStructure.ProjectEntry.value:type_name -> codegraph.v1.PackageInfo
+	2,  // 28: codegraph.v1.PackageInfo.ModulesEntry.value:type_name -> codegraph.v1.ModuleInfo
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_codegraph_v1_codegraph_proto_init() }
+func file_codegraph_v1_codegraph_proto_init() {
+	if File_codegraph_v1_codegraph_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_codegraph_v1_codegraph_proto_rawDesc), len(file_codegraph_v1_codegraph_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_codegraph_v1_codegraph_proto_goTypes,
+		DependencyIndexes: file_codegraph_v1_codegraph_proto_depIdxs,
+		MessageInfos:      file_codegraph_v1_codegraph_proto_msgTypes,
+	}.Build()
+	File_codegraph_v1_codegraph_proto = out.File
+	file_codegraph_v1_codegraph_proto_goTypes = nil
+	file_codegraph_v1_codegraph_proto_depIdxs = nil
+}
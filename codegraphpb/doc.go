@@ -0,0 +1,4 @@
+// Package codegraphpb holds the protocol buffer messages and gRPC service
+// generated from proto/codegraph/v1, for clients of the proto output
+// format and of codegraph serve --grpc.
+package codegraphpb
@@ -0,0 +1,1475 @@
+// gRPC API served by `codegraph serve --grpc`. Nodes and edges are the
+// messages of codegraph.proto; node IDs are only stable within one
+// analysis, so clients should look nodes up again after a change.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: codegraph/v1/service.proto
+
+package codegraphpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNSPECIFIED Direction = 0 // Same as DIRECTION_BOTH
+	Direction_DIRECTION_OUT         Direction = 1
+	Direction_DIRECTION_IN          Direction = 2
+	Direction_DIRECTION_BOTH        Direction = 3
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "DIRECTION_OUT",
+		2: "DIRECTION_IN",
+		3: "DIRECTION_BOTH",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"DIRECTION_OUT":         1,
+		"DIRECTION_IN":          2,
+		"DIRECTION_BOTH":        3,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_codegraph_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_codegraph_v1_service_proto_enumTypes[0]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+// ProjectSummary describes the graph being served.
+type ProjectSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Nodes         int32                  `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         int32                  `protobuf:"varint,3,opt,name=edges,proto3" json:"edges,omitempty"`
+	Files         int32                  `protobuf:"varint,4,opt,name=files,proto3" json:"files,omitempty"`
+	AnalyzedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=analyzed_at,json=analyzedAt,proto3" json:"analyzed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectSummary) Reset() {
+	*x = ProjectSummary{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectSummary) ProtoMessage() {}
+
+func (x *ProjectSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectSummary.ProtoReflect.Descriptor instead.
+func (*ProjectSummary) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProjectSummary) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *ProjectSummary) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *ProjectSummary) GetEdges() int32 {
+	if x != nil {
+		return x.Edges
+	}
+	return 0
+}
+
+func (x *ProjectSummary) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *ProjectSummary) GetAnalyzedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnalyzedAt
+	}
+	return nil
+}
+
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *ProjectSummary        `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AnalyzeResponse) GetSummary() *ProjectSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type GetNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeRequest) Reset() {
+	*x = GetNodeRequest{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeRequest) ProtoMessage() {}
+
+func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeRequest) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetNodeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetNodeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Node  *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// File relative to the project root.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Edges leaving and entering the node.
+	Out           []*Edge `protobuf:"bytes,3,rep,name=out,proto3" json:"out,omitempty"`
+	In            []*Edge `protobuf:"bytes,4,rep,name=in,proto3" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeResponse) Reset() {
+	*x = GetNodeResponse{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeResponse) ProtoMessage() {}
+
+func (x *GetNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeResponse.ProtoReflect.Descriptor instead.
+func (*GetNodeResponse) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetNodeResponse) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *GetNodeResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetNodeResponse) GetOut() []*Edge {
+	if x != nil {
+		return x.Out
+	}
+	return nil
+}
+
+func (x *GetNodeResponse) GetIn() []*Edge {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+type NeighborsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Edge relations to follow; empty follows all.
+	Relations []string  `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	Direction Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=codegraph.v1.Direction" json:"direction,omitempty"`
+	// Maximum number of hops; 0 means 1.
+	Depth         int32 `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NeighborsRequest) Reset() {
+	*x = NeighborsRequest{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NeighborsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborsRequest) ProtoMessage() {}
+
+func (x *NeighborsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborsRequest.ProtoReflect.Descriptor instead.
+func (*NeighborsRequest) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NeighborsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NeighborsRequest) GetRelations() []string {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *NeighborsRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *NeighborsRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type NeighborsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Neighbors     []*Neighbor            `protobuf:"bytes,1,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NeighborsResponse) Reset() {
+	*x = NeighborsResponse{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NeighborsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborsResponse) ProtoMessage() {}
+
+func (x *NeighborsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborsResponse.ProtoReflect.Descriptor instead.
+func (*NeighborsResponse) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NeighborsResponse) GetNeighbors() []*Neighbor {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+// Neighbor is a node reached from the one asked about, with the edge it
+// was reached through.
+type Neighbor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Edge          *Edge                  `protobuf:"bytes,2,opt,name=edge,proto3" json:"edge,omitempty"`
+	Depth         int32                  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Neighbor) Reset() {
+	*x = Neighbor{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Neighbor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Neighbor) ProtoMessage() {}
+
+func (x *Neighbor) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Neighbor.ProtoReflect.Descriptor instead.
+func (*Neighbor) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Neighbor) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *Neighbor) GetEdge() *Edge {
+	if x != nil {
+		return x.Edge
+	}
+	return nil
+}
+
+func (x *Neighbor) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type QueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type QueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       []string               `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows          []*QueryRow            `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResponse) ProtoMessage() {}
+
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueryResponse) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetRows() []*QueryRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type QueryRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*QueryValue          `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRow) Reset() {
+	*x = QueryRow{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRow) ProtoMessage() {}
+
+func (x *QueryRow) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRow.ProtoReflect.Descriptor instead.
+func (*QueryRow) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueryRow) GetValues() []*QueryValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// QueryValue is one cell of a query result; no kind set means null.
+type QueryValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*QueryValue_StringValue
+	//	*QueryValue_IntValue
+	//	*QueryValue_BoolValue
+	//	*QueryValue_NodeValue
+	//	*QueryValue_EdgeValue
+	Kind          isQueryValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryValue) Reset() {
+	*x = QueryValue{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryValue) ProtoMessage() {}
+
+func (x *QueryValue) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryValue.ProtoReflect.Descriptor instead.
+func (*QueryValue) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueryValue) GetKind() isQueryValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *QueryValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*QueryValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *QueryValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*QueryValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *QueryValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*QueryValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *QueryValue) GetNodeValue() *Node {
+	if x != nil {
+		if x, ok := x.Kind.(*QueryValue_NodeValue); ok {
+			return x.NodeValue
+		}
+	}
+	return nil
+}
+
+func (x *QueryValue) GetEdgeValue() *Edge {
+	if x != nil {
+		if x, ok := x.Kind.(*QueryValue_EdgeValue); ok {
+			return x.EdgeValue
+		}
+	}
+	return nil
+}
+
+type isQueryValue_Kind interface {
+	isQueryValue_Kind()
+}
+
+type QueryValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type QueryValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type QueryValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type QueryValue_NodeValue struct {
+	NodeValue *Node `protobuf:"bytes,4,opt,name=node_value,json=nodeValue,proto3,oneof"`
+}
+
+type QueryValue_EdgeValue struct {
+	EdgeValue *Edge `protobuf:"bytes,5,opt,name=edge_value,json=edgeValue,proto3,oneof"`
+}
+
+func (*QueryValue_StringValue) isQueryValue_Kind() {}
+
+func (*QueryValue_IntValue) isQueryValue_Kind() {}
+
+func (*QueryValue_BoolValue) isQueryValue_Kind() {}
+
+func (*QueryValue_NodeValue) isQueryValue_Kind() {}
+
+func (*QueryValue_EdgeValue) isQueryValue_Kind() {}
+
+type WatchChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Send the current graph's summary first, with no changes listed.
+	Initial       bool `protobuf:"varint,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchChangesRequest) Reset() {
+	*x = WatchChangesRequest{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchChangesRequest) ProtoMessage() {}
+
+func (x *WatchChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchChangesRequest) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+// GraphChange lists what changed between two analyses. Nodes are matched
+// by type and qualified name, and edges are named by the qualified names
+// of their ends, since IDs differ from one analysis to the next.
+type GraphChange struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Summary             *ProjectSummary        `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	AddedNodes          []*Node                `protobuf:"bytes,2,rep,name=added_nodes,json=addedNodes,proto3" json:"added_nodes,omitempty"`
+	RemovedNodes        []*Node                `protobuf:"bytes,3,rep,name=removed_nodes,json=removedNodes,proto3" json:"removed_nodes,omitempty"`
+	ChangedNodes        []*NodeChange          `protobuf:"bytes,4,rep,name=changed_nodes,json=changedNodes,proto3" json:"changed_nodes,omitempty"`
+	AddedEdges          []*EdgeRef             `protobuf:"bytes,5,rep,name=added_edges,json=addedEdges,proto3" json:"added_edges,omitempty"`
+	RemovedEdges        []*EdgeRef             `protobuf:"bytes,6,rep,name=removed_edges,json=removedEdges,proto3" json:"removed_edges,omitempty"`
+	AddedDependencies   []*Dependency          `protobuf:"bytes,7,rep,name=added_dependencies,json=addedDependencies,proto3" json:"added_dependencies,omitempty"`
+	RemovedDependencies []*Dependency          `protobuf:"bytes,8,rep,name=removed_dependencies,json=removedDependencies,proto3" json:"removed_dependencies,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GraphChange) Reset() {
+	*x = GraphChange{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphChange) ProtoMessage() {}
+
+func (x *GraphChange) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphChange.ProtoReflect.Descriptor instead.
+func (*GraphChange) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GraphChange) GetSummary() *ProjectSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GraphChange) GetAddedNodes() []*Node {
+	if x != nil {
+		return x.AddedNodes
+	}
+	return nil
+}
+
+func (x *GraphChange) GetRemovedNodes() []*Node {
+	if x != nil {
+		return x.RemovedNodes
+	}
+	return nil
+}
+
+func (x *GraphChange) GetChangedNodes() []*NodeChange {
+	if x != nil {
+		return x.ChangedNodes
+	}
+	return nil
+}
+
+func (x *GraphChange) GetAddedEdges() []*EdgeRef {
+	if x != nil {
+		return x.AddedEdges
+	}
+	return nil
+}
+
+func (x *GraphChange) GetRemovedEdges() []*EdgeRef {
+	if x != nil {
+		return x.RemovedEdges
+	}
+	return nil
+}
+
+func (x *GraphChange) GetAddedDependencies() []*Dependency {
+	if x != nil {
+		return x.AddedDependencies
+	}
+	return nil
+}
+
+func (x *GraphChange) GetRemovedDependencies() []*Dependency {
+	if x != nil {
+		return x.RemovedDependencies
+	}
+	return nil
+}
+
+// NodeChange is a node present in both graphs; only what changed is set.
+type NodeChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// As found in the new graph.
+	Node          *Node          `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	File          *ValueChange   `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Signature     *ValueChange   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Type          *ValueChange   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Value         *ValueChange   `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Fields        []*FieldChange `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeChange) Reset() {
+	*x = NodeChange{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeChange) ProtoMessage() {}
+
+func (x *NodeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeChange.ProtoReflect.Descriptor instead.
+func (*NodeChange) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NodeChange) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *NodeChange) GetFile() *ValueChange {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *NodeChange) GetSignature() *ValueChange {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *NodeChange) GetType() *ValueChange {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *NodeChange) GetValue() *ValueChange {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *NodeChange) GetFields() []*FieldChange {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ValueChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Old           string                 `protobuf:"bytes,1,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,2,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValueChange) Reset() {
+	*x = ValueChange{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValueChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValueChange) ProtoMessage() {}
+
+func (x *ValueChange) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValueChange.ProtoReflect.Descriptor instead.
+func (*ValueChange) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ValueChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *ValueChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+// FieldChange is a struct field that was added (old is empty), removed
+// (new is empty) or changed type.
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Old           string                 `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FieldChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+type EdgeRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EdgeRef) Reset() {
+	*x = EdgeRef{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EdgeRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeRef) ProtoMessage() {}
+
+func (x *EdgeRef) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeRef.ProtoReflect.Descriptor instead.
+func (*EdgeRef) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EdgeRef) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *EdgeRef) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *EdgeRef) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// Dependency is an import of package path `to` by the package in
+// directory `from`.
+type Dependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_codegraph_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_codegraph_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_codegraph_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Dependency) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Dependency) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+var File_codegraph_v1_service_proto protoreflect.FileDescriptor
+
+const file_codegraph_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1acodegraph/v1/service.proto\x12\fcodegraph.v1\x1a\x1ccodegraph/v1/codegraph.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x01\n" +
+	"\x0eProjectSummary\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x14\n" +
+	"\x05nodes\x18\x02 \x01(\x05R\x05nodes\x12\x14\n" +
+	"\x05edges\x18\x03 \x01(\x05R\x05edges\x12\x14\n" +
+	"\x05files\x18\x04 \x01(\x05R\x05files\x12;\n" +
+	"\vanalyzed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"analyzedAt\"\x10\n" +
+	"\x0eAnalyzeRequest\"I\n" +
+	"\x0fAnalyzeResponse\x126\n" +
+	"\asummary\x18\x01 \x01(\v2\x1c.codegraph.v1.ProjectSummaryR\asummary\" \n" +
+	"\x0eGetNodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x97\x01\n" +
+	"\x0fGetNodeResponse\x12&\n" +
+	"\x04node\x18\x01 \x01(\v2\x12.codegraph.v1.NodeR\x04node\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12$\n" +
+	"\x03out\x18\x03 \x03(\v2\x12.codegraph.v1.EdgeR\x03out\x12\"\n" +
+	"\x02in\x18\x04 \x03(\v2\x12.codegraph.v1.EdgeR\x02in\"\x8d\x01\n" +
+	"\x10NeighborsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\trelations\x18\x02 \x03(\tR\trelations\x125\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x17.codegraph.v1.DirectionR\tdirection\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\"I\n" +
+	"\x11NeighborsResponse\x124\n" +
+	"\tneighbors\x18\x01 \x03(\v2\x16.codegraph.v1.NeighborR\tneighbors\"p\n" +
+	"\bNeighbor\x12&\n" +
+	"\x04node\x18\x01 \x01(\v2\x12.codegraph.v1.NodeR\x04node\x12&\n" +
+	"\x04edge\x18\x02 \x01(\v2\x12.codegraph.v1.EdgeR\x04edge\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\"$\n" +
+	"\fQueryRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\"U\n" +
+	"\rQueryResponse\x12\x18\n" +
+	"\acolumns\x18\x01 \x03(\tR\acolumns\x12*\n" +
+	"\x04rows\x18\x02 \x03(\v2\x16.codegraph.v1.QueryRowR\x04rows\"<\n" +
+	"\bQueryRow\x120\n" +
+	"\x06values\x18\x01 \x03(\v2\x18.codegraph.v1.QueryValueR\x06values\"\xe3\x01\n" +
+	"\n" +
+	"QueryValue\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x03 \x01(\bH\x00R\tboolValue\x123\n" +
+	"\n" +
+	"node_value\x18\x04 \x01(\v2\x12.codegraph.v1.NodeH\x00R\tnodeValue\x123\n" +
+	"\n" +
+	"edge_value\x18\x05 \x01(\v2\x12.codegraph.v1.EdgeH\x00R\tedgeValueB\x06\n" +
+	"\x04kind\"/\n" +
+	"\x13WatchChangesRequest\x12\x18\n" +
+	"\ainitial\x18\x01 \x01(\bR\ainitial\"\xfc\x03\n" +
+	"\vGraphChange\x126\n" +
+	"\asummary\x18\x01 \x01(\v2\x1c.codegraph.v1.ProjectSummaryR\asummary\x123\n" +
+	"\vadded_nodes\x18\x02 \x03(\v2\x12.codegraph.v1.NodeR\n" +
+	"addedNodes\x127\n" +
+	"\rremoved_nodes\x18\x03 \x03(\v2\x12.codegraph.v1.NodeR\fremovedNodes\x12=\n" +
+	"\rchanged_nodes\x18\x04 \x03(\v2\x18.codegraph.v1.NodeChangeR\fchangedNodes\x126\n" +
+	"\vadded_edges\x18\x05 \x03(\v2\x15.codegraph.v1.EdgeRefR\n" +
+	"addedEdges\x12:\n" +
+	"\rremoved_edges\x18\x06 \x03(\v2\x15.codegraph.v1.EdgeRefR\fremovedEdges\x12G\n" +
+	"\x12added_dependencies\x18\a \x03(\v2\x18.codegraph.v1.DependencyR\x11addedDependencies\x12K\n" +
+	"\x14removed_dependencies\x18\b \x03(\v2\x18.codegraph.v1.DependencyR\x13removedDependencies\"\xaf\x02\n" +
+	"\n" +
+	"NodeChange\x12&\n" +
+	"\x04node\x18\x01 \x01(\v2\x12.codegraph.v1.NodeR\x04node\x12-\n" +
+	"\x04file\x18\x02 \x01(\v2\x19.codegraph.v1.ValueChangeR\x04file\x127\n" +
+	"\tsignature\x18\x03 \x01(\v2\x19.codegraph.v1.ValueChangeR\tsignature\x12-\n" +
+	"\x04type\x18\x04 \x01(\v2\x19.codegraph.v1.ValueChangeR\x04type\x12/\n" +
+	"\x05value\x18\x05 \x01(\v2\x19.codegraph.v1.ValueChangeR\x05value\x121\n" +
+	"\x06fields\x18\x06 \x03(\v2\x19.codegraph.v1.FieldChangeR\x06fields\"1\n" +
+	"\vValueChange\x12\x10\n" +
+	"\x03old\x18\x01 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x02 \x01(\tR\x03new\"E\n" +
+	"\vFieldChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03old\x18\x02 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x03 \x01(\tR\x03new\"I\n" +
+	"\aEdgeRef\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"0\n" +
+	"\n" +
+	"Dependency\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to*_\n" +
+	"\tDirection\x12\x19\n" +
+	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rDIRECTION_OUT\x10\x01\x12\x10\n" +
+	"\fDIRECTION_IN\x10\x02\x12\x12\n" +
+	"\x0eDIRECTION_BOTH\x10\x032\x82\x03\n" +
+	"\x10CodeGraphService\x12F\n" +
+	"\aAnalyze\x12\x1c.codegraph.v1.AnalyzeRequest\x1a\x1d.codegraph.v1.AnalyzeResponse\x12F\n" +
+	"\aGetNode\x12\x1c.codegraph.v1.GetNodeRequest\x1a\x1d.codegraph.v1.GetNodeResponse\x12L\n" +
+	"\tNeighbors\x12\x1e.codegraph.v1.NeighborsRequest\x1a\x1f.codegraph.v1.NeighborsResponse\x12@\n" +
+	"\x05Query\x12\x1a.codegraph.v1.QueryRequest\x1a\x1b.codegraph.v1.QueryResponse\x12N\n" +
+	"\fWatchChanges\x12!.codegraph.v1.WatchChangesRequest\x1a\x19.codegraph.v1.GraphChange0\x01BGZEgithub.com/srinidhi-metadome/go-codegraph-cli/codegraphpb;codegraphpbb\x06proto3"
+
+var (
+	file_codegraph_v1_service_proto_rawDescOnce sync.Once
+	file_codegraph_v1_service_proto_rawDescData []byte
+)
+
+func file_codegraph_v1_service_proto_rawDescGZIP() []byte {
+	file_codegraph_v1_service_proto_rawDescOnce.Do(func() {
+		file_codegraph_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_codegraph_v1_service_proto_rawDesc), len(file_codegraph_v1_service_proto_rawDesc)))
+	})
+	return file_codegraph_v1_service_proto_rawDescData
+}
+
+var file_codegraph_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_codegraph_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_codegraph_v1_service_proto_goTypes = []any{
+	(Direction)(0),                // 0: codegraph.v1.Direction
+	(*ProjectSummary)(nil),        // 1: codegraph.v1.ProjectSummary
+	(*AnalyzeRequest)(nil),        // 2: codegraph.v1.AnalyzeRequest
+	(*AnalyzeResponse)(nil),       // 3: codegraph.v1.AnalyzeResponse
+	(*GetNodeRequest)(nil),        // 4: codegraph.v1.GetNodeRequest
+	(*GetNodeResponse)(nil),       // 5: codegraph.v1.GetNodeResponse
+	(*NeighborsRequest)(nil),      // 6: codegraph.v1.NeighborsRequest
+	(*NeighborsResponse)(nil),     // 7: codegraph.v1.NeighborsResponse
+	(*Neighbor)(nil),              // 8: codegraph.v1.Neighbor
+	(*QueryRequest)(nil),          // 9: codegraph.v1.QueryRequest
+	(*QueryResponse)(nil),         // 10: codegraph.v1.QueryResponse
+	(*QueryRow)(nil),              // 11: codegraph.v1.QueryRow
+	(*QueryValue)(nil),            // 12: codegraph.v1.QueryValue
+	(*WatchChangesRequest)(nil),   // 13: codegraph.v1.WatchChangesRequest
+	(*GraphChange)(nil),           // 14: codegraph.v1.GraphChange
+	(*NodeChange)(nil),            // 15: codegraph.v1.NodeChange
+	(*ValueChange)(nil),           // 16: codegraph.v1.ValueChange
+	(*FieldChange)(nil),           // 17: codegraph.v1.FieldChange
+	(*EdgeRef)(nil),               // 18: codegraph.v1.EdgeRef
+	(*Dependency)(nil),            // 19: codegraph.v1.Dependency
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+	(*Node)(nil),                  // 21: codegraph.v1.Node
+	(*Edge)(nil),                  // 22: codegraph.v1.Edge
+}
+var file_codegraph_v1_service_proto_depIdxs = []int32{
+	20, // 0: codegraph.v1.ProjectSummary.analyzed_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: codegraph.v1.AnalyzeResponse.summary:type_name -> codegraph.v1.ProjectSummary
+	21, // 2: codegraph.v1.GetNodeResponse.node:type_name -> codegraph.v1.Node
+	22, // 3: codegraph.v1.GetNodeResponse.out:type_name -> codegraph.v1.Edge
+	22, // 4: codegraph.v1.GetNodeResponse.in:type_name -> codegraph.v1.Edge
+	0,  // 5: codegraph.v1.NeighborsRequest.direction:type_name -> codegraph.v1.Direction
+	8,  // 6: codegraph.v1.NeighborsResponse.neighbors:type_name -> codegraph.v1.Neighbor
+	21, // 7: codegraph.v1.Neighbor.node:type_name -> codegraph.v1.Node
+	22, // 8: codegraph.v1.Neighbor.edge:type_name -> codegraph.v1.Edge
+	11, // 9: codegraph.v1.QueryResponse.rows:type_name -> codegraph.v1.QueryRow
+	12, // 10: codegraph.v1.QueryRow.values:type_name -> codegraph.v1.QueryValue
+	21, // 11: codegraph.v1.QueryValue.node_value:type_name -> codegraph.v1.Node
+	22, // 12: codegraph.v1.QueryValue.edge_value:type_name -> codegraph.v1.Edge
+	1,  // 13: codegraph.v1.GraphChange.summary:type_name -> codegraph.v1.ProjectSummary
+	21, // 14: codegraph.v1.GraphChange.added_nodes:type_name -> codegraph.v1.Node
+	21, // 15: codegraph.v1.GraphChange.removed_nodes:type_name -> codegraph.v1.Node
+	15, // 16: codegraph.v1.GraphChange.changed_nodes:type_name -> codegraph.v1.NodeChange
+	18, // 17: codegraph.v1.GraphChange.added_edges:type_name -> codegraph.v1.EdgeRef
+	18, // 18: codegraph.v1.GraphChange.removed_edges:type_name -> codegraph.v1.EdgeRef
+	19, // 19: codegraph.v1.GraphChange.added_dependencies:type_name -> codegraph.v1.Dependency
+	19, // 20: codegraph.v1.GraphChange.removed_dependencies:type_name -> codegraph.v1.Dependency
+	21, // 21: codegraph.v1.NodeChange.node:type_name -> codegraph.v1.Node
+	16, // 22: codegraph.v1.NodeChange.file:type_name -> codegraph.v1.ValueChange
+	16, // 23: codegraph.v1.NodeChange.signature:type_name -> codegraph.v1.ValueChange
+	16, // 24: codegraph.v1.NodeChange.type:type_name -> codegraph.v1.ValueChange
+	16, // 25: codegraph.v1.NodeChange.value:type_name -> codegraph.v1.ValueChange
+	17, // 26: codegraph.v1.NodeChange.fields:type_name -> codegraph.v1.FieldChange
+	2,  // 27: codegraph.v1.CodeGraphService.Analyze:input_type -> codegraph.v1.AnalyzeRequest
+	4,  // 28: codegraph.v1.CodeGraphService.GetNode:input_type -> codegraph.v1.GetNodeRequest
+	6,  // 29: codegraph.v1.CodeGraphService.Neighbors:input_type -> codegraph.v1.NeighborsRequest
+	9,  // 30: codegraph.v1.CodeGraphService.Query:input_type -> codegraph.v1.QueryRequest
+	13, // 31: codegraph.v1.CodeGraphService.WatchChanges:input_type -> codegraph.v1.WatchChangesRequest
+	3,  // 32: codegraph.v1.CodeGraphService.Analyze:output_type -> codegraph.v1.AnalyzeResponse
+	5,  // 33: codegraph.v1.CodeGraphService.GetNode:output_type -> codegraph.v1.GetNodeResponse
+	7,  // 34: codegraph.v1.CodeGraphService.Neighbors:output_type -> codegraph.v1.NeighborsResponse
+	10, // 35: codegraph.v1.CodeGraphService.Query:output_type -> codegraph.v1.QueryResponse
+	14, // 36: codegraph.v1.CodeGraphService.WatchChanges:output_type -> codegraph.v1.GraphChange
+	32, // [32:37] is the sub-list for method output_type
+	27, // [27:32] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_codegraph_v1_service_proto_init() }
+func file_codegraph_v1_service_proto_init() {
+	if File_codegraph_v1_service_proto != nil {
+		return
+	}
+	file_codegraph_v1_codegraph_proto_init()
+	file_codegraph_v1_service_proto_msgTypes[11].OneofWrappers = []any{
+		(*QueryValue_StringValue)(nil),
+		(*QueryValue_IntValue)(nil),
+		(*QueryValue_BoolValue)(nil),
+		(*QueryValue_NodeValue)(nil),
+		(*QueryValue_EdgeValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_codegraph_v1_service_proto_rawDesc), len(file_codegraph_v1_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_codegraph_v1_service_proto_goTypes,
+		DependencyIndexes: file_codegraph_v1_service_proto_depIdxs,
+		EnumInfos:         file_codegraph_v1_service_proto_enumTypes,
+		MessageInfos:      file_codegraph_v1_service_proto_msgTypes,
+	}.Build()
+	File_codegraph_v1_service_proto = out.File
+	file_codegraph_v1_service_proto_goTypes = nil
+	file_codegraph_v1_service_proto_depIdxs = nil
+}
@@ -0,0 +1,293 @@
+// gRPC API served by `codegraph serve --grpc`. Nodes and edges are the
+// messages of codegraph.proto; node IDs are only stable within one
+// analysis, so clients should look nodes up again after a change.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: codegraph/v1/service.proto
+
+package codegraphpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CodeGraphService_Analyze_FullMethodName      = "/codegraph.v1.CodeGraphService/Analyze"
+	CodeGraphService_GetNode_FullMethodName      = "/codegraph.v1.CodeGraphService/GetNode"
+	CodeGraphService_Neighbors_FullMethodName    = "/codegraph.v1.CodeGraphService/Neighbors"
+	CodeGraphService_Query_FullMethodName        = "/codegraph.v1.CodeGraphService/Query"
+	CodeGraphService_WatchChanges_FullMethodName = "/codegraph.v1.CodeGraphService/WatchChanges"
+)
+
+// CodeGraphServiceClient is the client API for CodeGraphService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CodeGraphServiceClient interface {
+	// Analyze analyzes the project again and returns the new graph's summary.
+	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// GetNode returns a node with the edges at it, or NOT_FOUND.
+	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
+	// Neighbors returns the nodes reachable from a node within some hops.
+	Neighbors(ctx context.Context, in *NeighborsRequest, opts ...grpc.CallOption) (*NeighborsResponse, error)
+	// Query runs a graph query expression, as `codegraph query` does.
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// WatchChanges streams what changed each time the graph is analyzed
+	// again, until the client cancels.
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GraphChange], error)
+}
+
+type codeGraphServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCodeGraphServiceClient(cc grpc.ClientConnInterface) CodeGraphServiceClient {
+	return &codeGraphServiceClient{cc}
+}
+
+func (c *codeGraphServiceClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeResponse)
+	err := c.cc.Invoke(ctx, CodeGraphService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeGraphServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNodeResponse)
+	err := c.cc.Invoke(ctx, CodeGraphService_GetNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeGraphServiceClient) Neighbors(ctx context.Context, in *NeighborsRequest, opts ...grpc.CallOption) (*NeighborsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NeighborsResponse)
+	err := c.cc.Invoke(ctx, CodeGraphService_Neighbors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeGraphServiceClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, CodeGraphService_Query_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *codeGraphServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GraphChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CodeGraphService_ServiceDesc.Streams[0], CodeGraphService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchChangesRequest, GraphChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeGraphService_WatchChangesClient = grpc.ServerStreamingClient[GraphChange]
+
+// CodeGraphServiceServer is the server API for CodeGraphService service.
+// All implementations must embed UnimplementedCodeGraphServiceServer
+// for forward compatibility.
+type CodeGraphServiceServer interface {
+	// Analyze analyzes the project again and returns the new graph's summary.
+	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	// GetNode returns a node with the edges at it, or NOT_FOUND.
+	GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
+	// Neighbors returns the nodes reachable from a node within some hops.
+	Neighbors(context.Context, *NeighborsRequest) (*NeighborsResponse, error)
+	// Query runs a graph query expression, as `codegraph query` does.
+	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+	// WatchChanges streams what changed each time the graph is analyzed
+	// again, until the client cancels.
+	WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[GraphChange]) error
+	mustEmbedUnimplementedCodeGraphServiceServer()
+}
+
+// UnimplementedCodeGraphServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCodeGraphServiceServer struct{}
+
+func (UnimplementedCodeGraphServiceServer) Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedCodeGraphServiceServer) GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+}
+func (UnimplementedCodeGraphServiceServer) Neighbors(context.Context, *NeighborsRequest) (*NeighborsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Neighbors not implemented")
+}
+func (UnimplementedCodeGraphServiceServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+func (UnimplementedCodeGraphServiceServer) WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[GraphChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedCodeGraphServiceServer) mustEmbedUnimplementedCodeGraphServiceServer() {}
+func (UnimplementedCodeGraphServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCodeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CodeGraphServiceServer will
+// result in compilation errors.
+type UnsafeCodeGraphServiceServer interface {
+	mustEmbedUnimplementedCodeGraphServiceServer()
+}
+
+func RegisterCodeGraphServiceServer(s grpc.ServiceRegistrar, srv CodeGraphServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCodeGraphServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CodeGraphService_ServiceDesc, srv)
+}
+
+func _CodeGraphService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeGraphServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeGraphService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeGraphServiceServer).Analyze(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeGraphService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeGraphServiceServer).GetNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeGraphService_GetNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeGraphServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeGraphService_Neighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NeighborsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeGraphServiceServer).Neighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeGraphService_Neighbors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeGraphServiceServer).Neighbors(ctx, req.(*NeighborsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeGraphService_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CodeGraphServiceServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CodeGraphService_Query_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CodeGraphServiceServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CodeGraphService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CodeGraphServiceServer).WatchChanges(m, &grpc.GenericServerStream[WatchChangesRequest, GraphChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CodeGraphService_WatchChangesServer = grpc.ServerStreamingServer[GraphChange]
+
+// CodeGraphService_ServiceDesc is the grpc.ServiceDesc for CodeGraphService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CodeGraphService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "codegraph.v1.CodeGraphService",
+	HandlerType: (*CodeGraphServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Analyze",
+			Handler:    _CodeGraphService_Analyze_Handler,
+		},
+		{
+			MethodName: "GetNode",
+			Handler:    _CodeGraphService_GetNode_Handler,
+		},
+		{
+			MethodName: "Neighbors",
+			Handler:    _CodeGraphService_Neighbors_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _CodeGraphService_Query_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _CodeGraphService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "codegraph/v1/service.proto",
+}
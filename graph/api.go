@@ -0,0 +1,451 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// APIPackage is the exported API of one package.
+type APIPackage struct {
+	Dir     string      `json:"dir"` // Relative to the project root
+	Name    string      `json:"name"`
+	Symbols []APISymbol `json:"symbols"`
+}
+
+// APISymbol is one exported declaration of a package.
+type APISymbol struct {
+	Kind       string `json:"kind"`      // Node type, or "field" for struct fields
+	Name       string `json:"name"`      // "Type.Name" for methods and fields
+	Signature  string `json:"signature"` // As declared, e.g. "func New(limit int) *Store"
+	Deprecated bool   `json:"deprecated,omitempty"`
+	File       string `json:"file"`
+
+	shape   string // What callers depend on: parameter and result types, or the type
+	value   string // Constants only
+	pointer bool   // Methods declared on a pointer receiver
+}
+
+// APIOptions controls which packages ExportedAPI lists.
+type APIOptions struct {
+	Internal bool // Include packages under an internal directory
+}
+
+// ExportedAPI lists the exported functions, types, methods, fields,
+// constants and variables of each package other than main, sorted by
+// directory. Packages under an internal directory cannot be imported from
+// other modules and are left out unless opts.Internal is set.
+//
+// Within a package, constants come first, then variables, functions and
+// types, each type followed by its fields and methods.
+func ExportedAPI(result ProjectStructure, opts APIOptions) []APIPackage {
+	type pkgAPI struct {
+		consts, vars, funcs []APISymbol
+		types               map[string][]APISymbol // Type, then fields and methods
+	}
+	byDir := make(map[string]*pkgAPI)
+	names := make(map[string]string)
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			path = filepath.ToSlash(path)
+			dir := filepath.ToSlash(filepath.Dir(path))
+			if m.Package == "main" || !opts.Internal && isInternalDir(dir) {
+				continue
+			}
+			t := byDir[dir]
+			if t == nil {
+				t = &pkgAPI{types: make(map[string][]APISymbol)}
+				byDir[dir] = t
+				names[dir] = m.Package
+			}
+
+			for _, c := range m.Constants {
+				if isExported(c.Name) {
+					t.consts = append(t.consts, APISymbol{
+						Kind: "constant", Name: c.Name, Signature: declaration("const", c.Name, c.Type, c.Value),
+						Deprecated: c.Doc.IsDeprecated(), File: path, shape: c.Type, value: c.Value,
+					})
+				}
+			}
+			for _, v := range m.Variables {
+				if isExported(v.Name) {
+					t.vars = append(t.vars, APISymbol{
+						Kind: "variable", Name: v.Name, Signature: declaration("var", v.Name, v.Type, ""),
+						Deprecated: v.Doc.IsDeprecated(), File: path, shape: v.Type,
+					})
+				}
+			}
+			for _, fn := range m.Functions {
+				if isExported(fn.Name) {
+					t.funcs = append(t.funcs, apiFunction("function", "", fn, path))
+				}
+			}
+			for _, st := range m.Structs {
+				if !isExported(st.Name) {
+					continue
+				}
+				syms := []APISymbol{{
+					Kind: "struct", Name: st.Name, Signature: "type " + st.Name + " struct",
+					Deprecated: st.Doc.IsDeprecated(), File: path, shape: "struct",
+				}}
+				for _, f := range st.Properties {
+					name := embeddedName(f.Name)
+					if !isExported(name) {
+						continue
+					}
+					sig := f.Name + " " + f.Type
+					if name != f.Name {
+						sig = f.Type
+					}
+					syms = append(syms, APISymbol{
+						Kind: "field", Name: st.Name + "." + name, Signature: sig,
+						Deprecated: f.Doc.IsDeprecated(), File: path, shape: f.Type,
+					})
+				}
+				syms = append(syms, apiMethods("method", st.Name, st.Functions, path)...)
+				t.types[st.Name] = syms
+			}
+			for _, iface := range m.Interfaces {
+				if !isExported(iface.Name) {
+					continue
+				}
+				syms := []APISymbol{{
+					Kind: "interface", Name: iface.Name, Signature: "type " + iface.Name + " interface",
+					Deprecated: iface.Doc.IsDeprecated(), File: path, shape: "interface",
+				}}
+				// Unexported methods are part of the API too: they keep other
+				// packages from implementing the interface
+				syms = append(syms, apiMethods("interface_method", iface.Name, iface.Functions, path)...)
+				t.types[iface.Name] = syms
+			}
+			for _, nt := range m.Types {
+				if !isExported(nt.Name) {
+					continue
+				}
+				decl := TypeDeclaration(nt)
+				syms := []APISymbol{{
+					Kind: "type", Name: nt.Name, Signature: decl,
+					Deprecated: nt.Doc.IsDeprecated(), File: path, shape: strings.TrimPrefix(decl, "type "+nt.Name+" "),
+				}}
+				syms = append(syms, apiMethods("method", nt.Name, nt.Functions, path)...)
+				t.types[nt.Name] = syms
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	pkgs := make([]APIPackage, 0, len(dirs))
+	for _, dir := range dirs {
+		t := byDir[dir]
+		p := APIPackage{Dir: dir, Name: names[dir], Symbols: []APISymbol{}}
+		for _, group := range [][]APISymbol{t.consts, t.vars, t.funcs} {
+			sortAPISymbols(group)
+			p.Symbols = append(p.Symbols, group...)
+		}
+		typeNames := make([]string, 0, len(t.types))
+		for name := range t.types {
+			typeNames = append(typeNames, name)
+		}
+		sort.Strings(typeNames)
+		for _, name := range typeNames {
+			p.Symbols = append(p.Symbols, t.types[name]...)
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs
+}
+
+func apiFunction(kind, receiver string, fn FunctionInfo, path string) APISymbol {
+	name := fn.Name
+	if receiver != "" {
+		name = receiver + "." + fn.Name
+	}
+	return APISymbol{
+		Kind: kind, Name: name, Signature: FunctionSignature(receiver, fn),
+		Deprecated: fn.Doc.IsDeprecated(), File: path, shape: signatureKey(fn.Parameters, fn.ReturnType),
+		pointer: fn.PointerReceiver,
+	}
+}
+
+// apiMethods returns the methods of a type declared in the file at path,
+// sorted by name; unexported ones are kept for interfaces only.
+func apiMethods(kind, receiver string, fns []FunctionInfo, path string) []APISymbol {
+	var syms []APISymbol
+	for _, fn := range fns {
+		if isExported(fn.Name) || kind == "interface_method" {
+			syms = append(syms, apiFunction(kind, receiver, fn, filepath.ToSlash(methodPath(path, fn))))
+		}
+	}
+	sortAPISymbols(syms)
+	return syms
+}
+
+func sortAPISymbols(syms []APISymbol) {
+	sort.SliceStable(syms, func(i, j int) bool { return syms[i].Name < syms[j].Name })
+}
+
+// isInternalDir reports whether dir is or lies under an internal directory.
+func isInternalDir(dir string) bool {
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteAPI prints each package's API as indented declarations, with fields
+// and methods under their type.
+func WriteAPI(w io.Writer, pkgs []APIPackage) error {
+	var b strings.Builder
+	for i, p := range pkgs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "package %s // %s\n", p.Name, p.Dir)
+		for _, s := range p.Symbols {
+			indent := "  "
+			if s.Kind == "field" || s.Kind == "method" || s.Kind == "interface_method" {
+				indent = "    "
+			}
+			sig := oneLine(s.Signature)
+			if s.Deprecated {
+				sig += " // Deprecated"
+			}
+			fmt.Fprintf(&b, "%s%s\n", indent, sig)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteAPIJSON prints the packages as an indented JSON array.
+func WriteAPIJSON(w io.Writer, pkgs []APIPackage) error {
+	if pkgs == nil {
+		pkgs = []APIPackage{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(pkgs)
+}
+
+// APIDiff classifies the changes between two versions of the exported API.
+type APIDiff struct {
+	Breaking   []APIChange `json:"breaking"`
+	Compatible []APIChange `json:"compatible"`
+	Bump       string      `json:"bump"` // Suggested semantic version bump: major, minor or patch
+}
+
+// APIChange is a change to one exported symbol.
+type APIChange struct {
+	Package string `json:"package"` // Directory of the package
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`        // E.g. "removed" or "changed parameter types"
+	Old     string `json:"old,omitempty"` // Signatures before and after
+	New     string `json:"new,omitempty"`
+}
+
+// DiffAPI compares the exported API of two analyses of a project. Removed
+// symbols, changed parameter or result types, changed field, variable and
+// constant types, changed kinds of declaration, methods added to an
+// existing interface and methods moved from a value to a pointer receiver,
+// which leaves them out of the method set of values, break callers; added
+// symbols, changed constant values, methods moved to a value receiver and
+// new deprecations do not. Renamed parameters are not changes.
+//
+// Bump is major when anything breaks, minor when symbols were added or
+// deprecated and patch otherwise. Modules before v1 conventionally bump the
+// minor version for breaking changes instead.
+func DiffAPI(old, new ProjectStructure, opts APIOptions) *APIDiff {
+	before, after := apiSymbols(ExportedAPI(old, opts)), apiSymbols(ExportedAPI(new, opts))
+	d := &APIDiff{Breaking: []APIChange{}, Compatible: []APIChange{}}
+	breaking := func(s APISymbol, dir, reason string, o, n string) {
+		d.Breaking = append(d.Breaking, APIChange{Package: dir, Kind: s.Kind, Name: s.Name, Reason: reason, Old: o, New: n})
+	}
+	compatible := func(s APISymbol, dir, reason string, o, n string) {
+		d.Compatible = append(d.Compatible, APIChange{Package: dir, Kind: s.Kind, Name: s.Name, Reason: reason, Old: o, New: n})
+	}
+
+	for key, o := range before {
+		dir, _, _ := strings.Cut(key, " ")
+		n, ok := after[key]
+		switch {
+		case !ok:
+			breaking(o, dir, "removed", o.Signature, "")
+		case o.Kind != n.Kind:
+			breaking(n, dir, "changed from "+o.Kind+" to "+n.Kind, o.Signature, n.Signature)
+		case o.shape != n.shape:
+			breaking(n, dir, shapeChange(o, n), o.Signature, n.Signature)
+		case !o.pointer && n.pointer:
+			breaking(n, dir, "changed to pointer receiver", o.Signature, n.Signature)
+		case o.pointer && !n.pointer:
+			compatible(n, dir, "changed to value receiver", o.Signature, n.Signature)
+		case o.value != n.value:
+			compatible(n, dir, "changed value", o.Signature, n.Signature)
+		case !o.Deprecated && n.Deprecated:
+			compatible(n, dir, "deprecated", "", n.Signature)
+		}
+	}
+	for key, n := range after {
+		if _, ok := before[key]; ok {
+			continue
+		}
+		dir, _, _ := strings.Cut(key, " ")
+		if n.Kind == "interface_method" {
+			iface, _, _ := strings.Cut(n.Name, ".")
+			if o, ok := before[dir+" "+iface]; ok && o.Kind == "interface" {
+				breaking(n, dir, "added interface method", "", n.Signature)
+				continue
+			}
+		}
+		compatible(n, dir, "added", "", n.Signature)
+	}
+
+	sortAPIChanges(d.Breaking)
+	sortAPIChanges(d.Compatible)
+	switch {
+	case len(d.Breaking) > 0:
+		d.Bump = "major"
+	case len(d.Compatible) > 0 && !onlyValueChanges(d.Compatible):
+		d.Bump = "minor"
+	default:
+		d.Bump = "patch"
+	}
+	return d
+}
+
+// apiSymbols keys the symbols of pkgs by package directory and name.
+func apiSymbols(pkgs []APIPackage) map[string]APISymbol {
+	syms := make(map[string]APISymbol)
+	for _, p := range pkgs {
+		for _, s := range p.Symbols {
+			syms[p.Dir+" "+s.Name] = s
+		}
+	}
+	return syms
+}
+
+// shapeChange names what changed between two versions of a symbol of the
+// same kind.
+func shapeChange(o, n APISymbol) string {
+	switch o.Kind {
+	case "function", "method", "interface_method":
+		oParams, oResults, _ := strings.Cut(o.shape, ") ")
+		nParams, nResults, _ := strings.Cut(n.shape, ") ")
+		if oParams != nParams {
+			return "changed parameter types"
+		}
+		if oResults != nResults {
+			return "changed result types"
+		}
+	case "field":
+		return "changed field type"
+	}
+	return "changed type"
+}
+
+func onlyValueChanges(changes []APIChange) bool {
+	for _, c := range changes {
+		if c.Reason != "changed value" {
+			return false
+		}
+	}
+	return true
+}
+
+func sortAPIChanges(changes []APIChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Package != changes[j].Package {
+			return changes[i].Package < changes[j].Package
+		}
+		return changes[i].Name < changes[j].Name
+	})
+}
+
+// line describes c on one line, passing signatures through code for
+// formatting.
+func (c APIChange) line(code func(string) string) string {
+	s := c.Package + ": " + c.Kind + " " + code(c.Name) + " " + c.Reason
+	switch {
+	case c.Old != "" && c.New != "" && c.Old != c.New:
+		s += ": " + code(oneLine(c.Old)) + " → " + code(oneLine(c.New))
+	case c.New != "":
+		s += ": " + code(oneLine(c.New))
+	case c.Old != "":
+		s += ": " + code(oneLine(c.Old))
+	}
+	return s
+}
+
+// oneLine cuts a multi-line signature, such as a constant with a long
+// value, at its first line.
+func oneLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
+
+// WriteText prints the breaking and compatible changes and the suggested
+// version bump.
+func (d *APIDiff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	if len(d.Breaking)+len(d.Compatible) == 0 {
+		b.WriteString("No API changes\n")
+	}
+	if len(d.Breaking) > 0 {
+		fmt.Fprintf(&b, "Breaking changes: %d\n", len(d.Breaking))
+		for _, c := range d.Breaking {
+			fmt.Fprintf(&b, "! %s\n", c.line(func(s string) string { return s }))
+		}
+		b.WriteString("\n")
+	}
+	if len(d.Compatible) > 0 {
+		fmt.Fprintf(&b, "Compatible changes: %d\n", len(d.Compatible))
+		for _, c := range d.Compatible {
+			fmt.Fprintf(&b, "~ %s\n", c.line(func(s string) string { return s }))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Suggested version bump: %s\n", d.Bump)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown prints the changes as Markdown suitable for a pull request
+// comment.
+func (d *APIDiff) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("## API changes\n\n")
+	fmt.Fprintf(&b, "Suggested version bump: **%s**\n", d.Bump)
+	code := func(s string) string { return "`" + s + "`" }
+	if len(d.Breaking) > 0 {
+		b.WriteString("\n### Breaking\n\n")
+		for _, c := range d.Breaking {
+			fmt.Fprintf(&b, "- :warning: %s\n", c.line(code))
+		}
+	}
+	if len(d.Compatible) > 0 {
+		b.WriteString("\n### Compatible\n\n")
+		for _, c := range d.Compatible {
+			fmt.Fprintf(&b, "- %s\n", c.line(code))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON prints the diff as an indented JSON object.
+func (d *APIDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
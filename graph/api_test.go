@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffAPI(t *testing.T) {
+	const base = "package lib\n\ntype S struct{ N int }\n\nfunc (s S) M() {}\n\nfunc New(n int) S { return S{N: n} }\n\nconst Limit = 1\n"
+	tests := []struct {
+		name     string
+		new      string
+		breaking []string // Reasons by name, with the new signature
+		bump     string
+	}{
+		{
+			name:     "value to pointer receiver",
+			new:      "package lib\n\ntype S struct{ N int }\n\nfunc (s *S) M() {}\n\nfunc New(n int) S { return S{N: n} }\n\nconst Limit = 1\n",
+			breaking: []string{"S.M changed to pointer receiver: func (*S) M()"},
+			bump:     "major",
+		},
+		{
+			name:     "changed parameter types",
+			new:      "package lib\n\ntype S struct{ N int }\n\nfunc (s S) M() {}\n\nfunc New(n int64) S { return S{N: int(n)} }\n\nconst Limit = 1\n",
+			breaking: []string{"New changed parameter types: func New(n int64) S"},
+			bump:     "major",
+		},
+		{
+			name:     "removed field",
+			new:      "package lib\n\ntype S struct{}\n\nfunc (s S) M() {}\n\nfunc New(n int) S { return S{} }\n\nconst Limit = 1\n",
+			breaking: []string{"S.N removed: "},
+			bump:     "major",
+		},
+		{
+			name: "renamed parameter",
+			new:  "package lib\n\ntype S struct{ N int }\n\nfunc (s S) M() {}\n\nfunc New(limit int) S { return S{N: limit} }\n\nconst Limit = 1\n",
+			bump: "patch",
+		},
+		{
+			name: "added function",
+			new:  base + "\nfunc Parse(s string) S { return S{} }\n",
+			bump: "minor",
+		},
+	}
+	old := analyzeFiles(t, map[string]string{"lib/lib.go": base})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiffAPI(old, analyzeFiles(t, map[string]string{"lib/lib.go": tt.new}), APIOptions{})
+			var got []string
+			for _, c := range d.Breaking {
+				got = append(got, c.Name+" "+c.Reason+": "+c.New)
+			}
+			if !reflect.DeepEqual(got, tt.breaking) {
+				t.Errorf("breaking = %q, want %q", got, tt.breaking)
+			}
+			if d.Bump != tt.bump {
+				t.Errorf("bump = %s, want %s", d.Bump, tt.bump)
+			}
+		})
+	}
+}
@@ -0,0 +1,501 @@
+// codegraph viewer: a dependency-free force-directed graph browser for the
+// ProjectStructure embedded in #graph-data.
+(function () {
+  "use strict";
+
+  var data = JSON.parse(document.getElementById("graph-data").textContent);
+  var graph = data.codeGraph || { nodes: [], edges: [] };
+
+  var COLORS = {
+    "function": "#0969da",
+    "method": "#8250df",
+    "struct": "#1a7f37",
+    "interface": "#bf8700",
+    "interface_method": "#d4a72c",
+    "type": "#1b7c83",
+    "constant": "#cf222e",
+    "variable": "#fa4549",
+    "external_function": "#8c959f",
+    "package": "#57606a"
+  };
+  function color(type) { return COLORS[type] || "#6e7781"; }
+
+  // ---- Model -------------------------------------------------------------
+
+  var nodes = (graph.nodes || []).map(function (n) {
+    return { data: n, x: 0, y: 0, vx: 0, vy: 0, fixed: false };
+  });
+  var byID = {};
+  nodes.forEach(function (n) { byID[n.data.id] = n; });
+
+  var edges = (graph.edges || []).filter(function (e) {
+    return byID[e.from] && byID[e.to];
+  }).map(function (e) {
+    return { data: e, source: byID[e.from], target: byID[e.to] };
+  });
+
+  var adjacency = {};
+  nodes.forEach(function (n) { adjacency[n.data.id] = []; });
+  edges.forEach(function (e) {
+    adjacency[e.source.data.id].push(e);
+    adjacency[e.target.data.id].push(e);
+  });
+
+  // Details (FunctionInfo, StructInfo, ...) keyed by node ID
+  var details = {};
+  function addFunctions(list, kind, path, owner) {
+    (list || []).forEach(function (f) {
+      details[f.id] = { kind: kind, info: f, path: kind === "method" ? methodPath(path, f) : path, owner: owner };
+    });
+  }
+  // Methods may be declared in any file of their receiver's package
+  function methodPath(path, f) {
+    if (!f.filePath) return path;
+    return path.slice(0, path.lastIndexOf("/") + 1) + f.filePath.split(/[\\/]/).pop();
+  }
+  Object.keys(data.project || {}).forEach(function (projectName) {
+    var modules = data.project[projectName].modules || {};
+    Object.keys(modules).forEach(function (path) {
+      var m = modules[path];
+      addFunctions(m.functions, "function", path);
+      (m.structs || []).forEach(function (s) {
+        details[s.id] = { kind: "struct", info: s, path: path };
+        addFunctions(s.functions, "method", path, s.name);
+      });
+      (m.interfaces || []).forEach(function (i) {
+        details[i.id] = { kind: "interface", info: i, path: path };
+        addFunctions(i.functions, "interface_method", path, i.name);
+      });
+      (m.types || []).forEach(function (t) {
+        details[t.id] = { kind: "type", info: t, path: path };
+        addFunctions(t.functions, "method", path, t.name);
+      });
+      (m.constants || []).forEach(function (c) { details[c.id] = { kind: "constant", info: c, path: path }; });
+      (m.variables || []).forEach(function (v) { details[v.id] = { kind: "variable", info: v, path: path }; });
+    });
+  });
+
+  // ---- Filters -----------------------------------------------------------
+
+  var types = {}, relations = {}, packages = {};
+  nodes.forEach(function (n) {
+    types[n.data.type] = true;
+    if (n.data.package) packages[n.data.package] = true;
+  });
+  edges.forEach(function (e) { relations[e.data.relation] = true; });
+
+  var state = {
+    types: Object.assign({}, types),
+    relations: Object.assign({}, relations),
+    pkg: "",
+    focus: null, // Set of node IDs when in focus mode
+    selected: null
+  };
+
+  function checkboxes(container, values, onChange, withColor) {
+    Object.keys(values).sort().forEach(function (value) {
+      var label = document.createElement("label");
+      var box = document.createElement("input");
+      box.type = "checkbox";
+      box.checked = true;
+      box.addEventListener("change", function () { onChange(value, box.checked); });
+      label.appendChild(box);
+      if (withColor) {
+        var swatch = document.createElement("span");
+        swatch.className = "swatch";
+        swatch.style.background = color(value);
+        label.appendChild(swatch);
+      }
+      label.appendChild(document.createTextNode(value));
+      container.appendChild(label);
+    });
+  }
+  checkboxes(document.getElementById("type-filters"), types, function (t, on) {
+    state.types[t] = on; refresh();
+  }, true);
+  checkboxes(document.getElementById("relation-filters"), relations, function (r, on) {
+    state.relations[r] = on; refresh();
+  });
+
+  var pkgSelect = document.getElementById("package-filter");
+  Object.keys(packages).sort().forEach(function (p) {
+    var opt = document.createElement("option");
+    opt.value = opt.textContent = p;
+    pkgSelect.appendChild(opt);
+  });
+  pkgSelect.addEventListener("change", function () { state.pkg = pkgSelect.value; refresh(); });
+
+  var visibleNodes = [], visibleEdges = [];
+  function nodeVisible(n) {
+    if (!state.types[n.data.type]) return false;
+    if (state.focus) return state.focus.has(n.data.id);
+    return !state.pkg || n.data.package === state.pkg;
+  }
+  function refresh() {
+    visibleNodes = nodes.filter(nodeVisible);
+    var shown = new Set(visibleNodes);
+    visibleEdges = edges.filter(function (e) {
+      return state.relations[e.data.relation] && shown.has(e.source) && shown.has(e.target);
+    });
+    alpha = Math.max(alpha, 0.5);
+    status();
+  }
+
+  // ---- Layout ------------------------------------------------------------
+
+  var canvas = document.getElementById("graph");
+  var ctx = canvas.getContext("2d");
+  var view = { x: 0, y: 0, k: 1 };
+  var alpha = 1;
+
+  nodes.forEach(function (n, i) {
+    var angle = i * 2.399963; // golden angle spiral
+    var r = 12 * Math.sqrt(i + 1);
+    n.x = r * Math.cos(angle);
+    n.y = r * Math.sin(angle);
+  });
+
+  function tick() {
+    var n = visibleNodes.length;
+    if (alpha < 0.005 || n === 0) return;
+    var i, j, a, b, dx, dy, d2, d, f;
+
+    // Repulsion, sampled for large graphs to stay interactive
+    var step = n > 1500 ? Math.ceil(n / 1500) : 1;
+    for (i = 0; i < n; i++) {
+      a = visibleNodes[i];
+      for (j = i + 1; j < n; j += step) {
+        b = visibleNodes[j];
+        dx = a.x - b.x; dy = a.y - b.y;
+        d2 = dx * dx + dy * dy || 0.01;
+        if (d2 > 250000) continue;
+        f = 900 * step * alpha / d2;
+        a.vx += dx * f; a.vy += dy * f;
+        b.vx -= dx * f; b.vy -= dy * f;
+      }
+    }
+    // Springs along edges
+    visibleEdges.forEach(function (e) {
+      dx = e.target.x - e.source.x; dy = e.target.y - e.source.y;
+      d = Math.sqrt(dx * dx + dy * dy) || 0.01;
+      f = (d - 60) * 0.04 * alpha / d;
+      e.source.vx += dx * f; e.source.vy += dy * f;
+      e.target.vx -= dx * f; e.target.vy -= dy * f;
+    });
+    // Gravity and integration
+    visibleNodes.forEach(function (v) {
+      v.vx -= v.x * 0.002 * alpha;
+      v.vy -= v.y * 0.002 * alpha;
+      if (!v.fixed) { v.x += v.vx; v.y += v.vy; }
+      v.vx *= 0.6; v.vy *= 0.6;
+    });
+    alpha *= 0.985;
+  }
+
+  // ---- Rendering ---------------------------------------------------------
+
+  function resize() {
+    var ratio = window.devicePixelRatio || 1;
+    canvas.width = canvas.clientWidth * ratio;
+    canvas.height = canvas.clientHeight * ratio;
+    ctx.setTransform(ratio, 0, 0, ratio, 0, 0);
+  }
+  window.addEventListener("resize", resize);
+
+  function neighborsOf(id) {
+    var set = new Set([id]);
+    adjacency[id].forEach(function (e) {
+      set.add(e.source.data.id);
+      set.add(e.target.data.id);
+    });
+    return set;
+  }
+
+  function draw() {
+    var w = canvas.clientWidth, h = canvas.clientHeight;
+    ctx.clearRect(0, 0, w, h);
+    ctx.save();
+    ctx.translate(w / 2 + view.x, h / 2 + view.y);
+    ctx.scale(view.k, view.k);
+
+    var highlight = state.selected ? neighborsOf(state.selected.data.id) : null;
+
+    ctx.lineWidth = 1 / view.k;
+    visibleEdges.forEach(function (e) {
+      var lit = highlight && (e.source === state.selected || e.target === state.selected);
+      ctx.strokeStyle = lit ? "rgba(9,105,218,0.8)" : (highlight ? "rgba(140,149,159,0.12)" : "rgba(140,149,159,0.45)");
+      ctx.beginPath();
+      ctx.moveTo(e.source.x, e.source.y);
+      ctx.lineTo(e.target.x, e.target.y);
+      ctx.stroke();
+      if (lit || view.k > 1.5) arrow(e);
+    });
+
+    visibleNodes.forEach(function (n) {
+      var dim = highlight && !highlight.has(n.data.id);
+      ctx.globalAlpha = dim ? 0.2 : 1;
+      ctx.fillStyle = color(n.data.type);
+      ctx.beginPath();
+      ctx.arc(n.x, n.y, n === state.selected ? 8 : 5, 0, 2 * Math.PI);
+      ctx.fill();
+      if (n === state.selected) {
+        ctx.strokeStyle = "#1f2328";
+        ctx.lineWidth = 2 / view.k;
+        ctx.stroke();
+        ctx.lineWidth = 1 / view.k;
+      }
+      if (!dim && (view.k > 1.2 || (highlight && highlight.has(n.data.id)))) {
+        ctx.fillStyle = "#1f2328";
+        ctx.font = 11 / view.k + "px sans-serif";
+        ctx.fillText(label(n.data), n.x + 8, n.y + 4);
+      }
+    });
+    ctx.globalAlpha = 1;
+    ctx.restore();
+  }
+
+  function arrow(e) {
+    var dx = e.target.x - e.source.x, dy = e.target.y - e.source.y;
+    var d = Math.sqrt(dx * dx + dy * dy) || 1;
+    var x = e.target.x - dx / d * 7, y = e.target.y - dy / d * 7;
+    var s = 4;
+    ctx.fillStyle = ctx.strokeStyle;
+    ctx.beginPath();
+    ctx.moveTo(x, y);
+    ctx.lineTo(x - dx / d * s * 1.5 + dy / d * s, y - dy / d * s * 1.5 - dx / d * s);
+    ctx.lineTo(x - dx / d * s * 1.5 - dy / d * s, y - dy / d * s * 1.5 + dx / d * s);
+    ctx.fill();
+  }
+
+  function label(n) {
+    return n.receiver ? n.receiver + "." + n.name : n.name;
+  }
+
+  function loop() {
+    tick();
+    draw();
+    requestAnimationFrame(loop);
+  }
+
+  // ---- Interaction -------------------------------------------------------
+
+  function toGraph(ev) {
+    var rect = canvas.getBoundingClientRect();
+    return {
+      x: (ev.clientX - rect.left - canvas.clientWidth / 2 - view.x) / view.k,
+      y: (ev.clientY - rect.top - canvas.clientHeight / 2 - view.y) / view.k
+    };
+  }
+  function hit(p) {
+    var best = null, bestD = 100 / (view.k * view.k);
+    visibleNodes.forEach(function (n) {
+      var d = (n.x - p.x) * (n.x - p.x) + (n.y - p.y) * (n.y - p.y);
+      if (d < bestD) { best = n; bestD = d; }
+    });
+    return best;
+  }
+
+  var drag = null;
+  canvas.addEventListener("mousedown", function (ev) {
+    var p = toGraph(ev), n = hit(p);
+    drag = { node: n, startX: ev.clientX, startY: ev.clientY, viewX: view.x, viewY: view.y, moved: false };
+    if (n) n.fixed = true;
+    canvas.classList.add("dragging");
+  });
+  window.addEventListener("mousemove", function (ev) {
+    if (!drag) return;
+    if (Math.abs(ev.clientX - drag.startX) + Math.abs(ev.clientY - drag.startY) > 3) drag.moved = true;
+    if (drag.node) {
+      var p = toGraph(ev);
+      drag.node.x = p.x; drag.node.y = p.y;
+      alpha = Math.max(alpha, 0.1);
+    } else {
+      view.x = drag.viewX + ev.clientX - drag.startX;
+      view.y = drag.viewY + ev.clientY - drag.startY;
+    }
+  });
+  window.addEventListener("mouseup", function () {
+    if (!drag) return;
+    if (drag.node) {
+      drag.node.fixed = false;
+      if (!drag.moved) select(drag.node, true);
+    } else if (!drag.moved) {
+      select(null);
+    }
+    drag = null;
+    canvas.classList.remove("dragging");
+  });
+  canvas.addEventListener("wheel", function (ev) {
+    ev.preventDefault();
+    var factor = ev.deltaY < 0 ? 1.1 : 1 / 1.1;
+    var rect = canvas.getBoundingClientRect();
+    var mx = ev.clientX - rect.left - canvas.clientWidth / 2, my = ev.clientY - rect.top - canvas.clientHeight / 2;
+    view.x = mx - (mx - view.x) * factor;
+    view.y = my - (my - view.y) * factor;
+    view.k *= factor;
+  }, { passive: false });
+
+  function select(n, expand) {
+    state.selected = n;
+    if (n && expand && state.focus) {
+      neighborsOf(n.data.id).forEach(function (id) {
+        if (!state.focus.has(id)) {
+          var m = byID[id];
+          m.x = n.x + (Math.random() - 0.5) * 40;
+          m.y = n.y + (Math.random() - 0.5) * 40;
+          state.focus.add(id);
+        }
+      });
+      refresh();
+    }
+    showDetails(n);
+  }
+
+  function fit() {
+    if (!visibleNodes.length) return;
+    var minX = Infinity, minY = Infinity, maxX = -Infinity, maxY = -Infinity;
+    visibleNodes.forEach(function (n) {
+      minX = Math.min(minX, n.x); maxX = Math.max(maxX, n.x);
+      minY = Math.min(minY, n.y); maxY = Math.max(maxY, n.y);
+    });
+    var k = Math.min(canvas.clientWidth / (maxX - minX + 80), canvas.clientHeight / (maxY - minY + 80));
+    view.k = Math.min(Math.max(k, 0.05), 4);
+    view.x = -(minX + maxX) / 2 * view.k;
+    view.y = -(minY + maxY) / 2 * view.k;
+  }
+
+  document.getElementById("show-all").addEventListener("click", function () {
+    state.focus = null; refresh();
+  });
+  document.getElementById("focus").addEventListener("click", function () {
+    if (!state.selected) return;
+    state.focus = neighborsOf(state.selected.data.id);
+    refresh();
+  });
+  document.getElementById("fit").addEventListener("click", fit);
+
+  // ---- Search ------------------------------------------------------------
+
+  var search = document.getElementById("search");
+  var results = document.getElementById("results");
+  search.addEventListener("input", function () {
+    results.innerHTML = "";
+    var q = search.value.trim().toLowerCase();
+    if (!q) return;
+    var matches = nodes.filter(function (n) {
+      return label(n.data).toLowerCase().indexOf(q) >= 0 ||
+        (n.data.package + "." + n.data.name).toLowerCase().indexOf(q) >= 0;
+    }).slice(0, 50);
+    matches.forEach(function (n) {
+      var li = document.createElement("li");
+      li.innerHTML = '<span class="swatch"></span> ';
+      li.firstChild.style.background = color(n.data.type);
+      li.appendChild(document.createTextNode(label(n.data)));
+      var pkg = document.createElement("span");
+      pkg.className = "muted";
+      pkg.textContent = " " + (n.data.package || "");
+      li.appendChild(pkg);
+      li.addEventListener("click", function () { reveal(n); });
+      results.appendChild(li);
+    });
+  });
+
+  // reveal makes n visible, selects it and centers the view on it
+  function reveal(n) {
+    if (state.focus) state.focus.add(n.data.id);
+    else if (!nodeVisible(n)) { state.pkg = ""; pkgSelect.value = ""; }
+    state.types[n.data.type] = true;
+    refresh();
+    select(n);
+    view.x = -n.x * view.k;
+    view.y = -n.y * view.k;
+  }
+
+  // ---- Details panel -----------------------------------------------------
+
+  var panel = document.getElementById("details");
+  function el(tag, text, cls) {
+    var e = document.createElement(tag);
+    if (text !== undefined) e.textContent = text;
+    if (cls) e.className = cls;
+    return e;
+  }
+
+  function signature(f, owner) {
+    var params = (f.parameters || []).map(function (p) {
+      return p.name ? p.name + " " + p.type : p.type;
+    }).join(", ");
+    var ret = f.returnType && f.returnType !== "void" ? " " + f.returnType : "";
+    return "func " + (owner ? "(" + owner + ") " : "") + f.name + "(" + params + ")" + ret;
+  }
+
+  function showDetails(n) {
+    panel.innerHTML = "";
+    if (!n) {
+      panel.appendChild(el("p", "Select a node to see its details.", "muted"));
+      return;
+    }
+    var d = n.data, info = details[d.id];
+    panel.appendChild(el("h3", label(d)));
+    var loc = d.type + (d.package ? " in " + d.package : "");
+    var file = info ? info.path : d.file;
+    if (file) loc += " · " + file + (d.line ? ":" + d.line : "");
+    panel.appendChild(el("div", loc, "muted"));
+
+    if (info) {
+      var i = info.info;
+      if (info.kind === "function" || info.kind === "method" || info.kind === "interface_method") {
+        panel.appendChild(el("pre", signature(i, info.owner)));
+      } else if (info.kind === "struct") {
+        var src = "type " + i.name + " struct {\n";
+        (i.properties || []).forEach(function (p) {
+          src += "\t" + (p.name === p.type ? p.type : p.name + " " + p.type) + "\n";
+        });
+        panel.appendChild(el("pre", src + "}"));
+      } else if (info.kind === "interface") {
+        var body = "type " + i.name + " interface {\n";
+        (i.functions || []).forEach(function (f) {
+          body += "\t" + signature(f).replace(/^func /, "") + "\n";
+        });
+        panel.appendChild(el("pre", body + "}"));
+      } else if (info.kind === "type") {
+        panel.appendChild(el("pre", "type " + i.name + (i.alias ? " = " : " ") + i.type));
+      } else {
+        var decl = (info.kind === "constant" ? "const " : "var ") + i.name +
+          (i.type ? " " + i.type : "") + (i.value ? " = " + i.value : "");
+        panel.appendChild(el("pre", decl));
+      }
+      if (i.comment) panel.appendChild(el("div", i.comment, "comment"));
+    }
+
+    var outgoing = {}, incoming = {};
+    adjacency[d.id].forEach(function (e) {
+      var out = e.source === n;
+      var group = out ? outgoing : incoming;
+      (group[e.data.relation] = group[e.data.relation] || []).push(out ? e.target : e.source);
+    });
+    [["Outgoing", outgoing, "→"], ["Incoming", incoming, "←"]].forEach(function (g) {
+      Object.keys(g[1]).sort().forEach(function (rel) {
+        panel.appendChild(el("h2", g[0] + " · " + rel));
+        var ul = el("ul", undefined, "neighbors");
+        g[1][rel].forEach(function (m) {
+          var li = el("li", g[2] + " " + label(m.data));
+          li.addEventListener("click", function () { reveal(m); });
+          ul.appendChild(li);
+        });
+        panel.appendChild(ul);
+      });
+    });
+  }
+
+  function status() {
+    document.getElementById("status").textContent =
+      visibleNodes.length + " of " + nodes.length + " nodes · " + visibleEdges.length + " edges";
+  }
+
+  resize();
+  refresh();
+  for (var warm = 0; warm < 150; warm++) tick();
+  fit();
+  loop();
+})();
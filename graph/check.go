@@ -0,0 +1,331 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckConfig is a set of architecture rules, usually read from a
+// .codegraph.yaml at the project root. Packages are named by their
+// directory relative to the project root, other packages by import path;
+// patterns ending in "/..." also match everything below, and "*" matches
+// within one path element.
+//
+//	layers: api -> service -> storage
+//	forbidden:
+//	  - from: storage/...
+//	    to: net/http
+//	imports:
+//	  - packages: ...
+//	    allow: [github.com/spf13/cobra, gopkg.in/yaml.v3]
+//	rules:
+//	  - name: handlers use the storage layer
+//	    from: handlers/...
+//	    must_not_call: database/sql
+type CheckConfig struct {
+	// Layers from top to bottom; a layer may depend on the layers below it
+	// but not on those above
+	Layers    Layers                `yaml:"layers"`
+	Forbidden []ForbiddenDependency `yaml:"forbidden"`
+	Imports   []ImportAllowList     `yaml:"imports"`
+	Rules     []CallRule            `yaml:"rules"`
+}
+
+// Layer is a named group of packages; Packages defaults to the layer name
+// and everything below it.
+type Layer struct {
+	Name     string   `yaml:"name"`
+	Packages Patterns `yaml:"packages"`
+}
+
+// Layers is written either as a list of Layer or as "a -> b -> c".
+type Layers []Layer
+
+// ForbiddenDependency forbids packages matching From to import packages
+// matching To.
+type ForbiddenDependency struct {
+	From   Patterns `yaml:"from"`
+	To     Patterns `yaml:"to"`
+	Reason string   `yaml:"reason"`
+}
+
+// ImportAllowList limits the third-party packages that packages matching
+// Packages may import to those matching Allow. The standard library and
+// the project's own packages are always allowed.
+type ImportAllowList struct {
+	Packages Patterns `yaml:"packages"`
+	Allow    Patterns `yaml:"allow"`
+}
+
+// CallRule forbids functions in packages matching From to call functions
+// matching MustNotCall, given as package patterns or qualified names such
+// as "database/sql.Open".
+type CallRule struct {
+	Name        string   `yaml:"name"`
+	From        Patterns `yaml:"from"`
+	MustNotCall Patterns `yaml:"must_not_call"`
+	Reason      string   `yaml:"reason"`
+}
+
+// Patterns is written either as a single pattern or as a list.
+type Patterns []string
+
+// UnmarshalYAML accepts a scalar as a one-element list.
+func (p *Patterns) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*p = Patterns{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*p = list
+	return nil
+}
+
+// UnmarshalYAML accepts "a -> b -> c" for layers named after their
+// packages.
+func (l *Layers) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, name := range strings.Split(node.Value, "->") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return fmt.Errorf("line %d: empty layer in %q", node.Line, node.Value)
+			}
+			*l = append(*l, Layer{Name: name})
+		}
+		return nil
+	}
+	var list []Layer
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// LoadCheckConfig reads a rule file, rejecting unknown keys so that a typo
+// does not silently disable a rule.
+func LoadCheckConfig(filename string) (*CheckConfig, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var cfg CheckConfig
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	for i, layer := range cfg.Layers {
+		if layer.Name == "" {
+			return nil, fmt.Errorf("%s: layer %d has no name", filename, i+1)
+		}
+		if len(layer.Packages) == 0 {
+			cfg.Layers[i].Packages = Patterns{layer.Name, layer.Name + "/..."}
+		}
+	}
+	return &cfg, nil
+}
+
+// Violation is an edge breaking an architecture rule.
+type Violation struct {
+	Rule     string `json:"rule"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"` // "imports" or "calls"
+	Location string `json:"location"` // file or file:line of the edge
+	Message  string `json:"message"`
+}
+
+// Match reports whether pkg, a directory or import path, matches any of
+// the patterns.
+func (p Patterns) Match(pkg string) bool {
+	for _, pattern := range p {
+		switch {
+		case pattern == "...":
+			return true
+		case strings.HasSuffix(pattern, "/..."):
+			prefix := strings.TrimSuffix(pattern, "/...")
+			if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+				return true
+			}
+		default:
+			if ok, _ := path.Match(pattern, pkg); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStandardPackage reports whether an import path belongs to the standard
+// library, whose first element never contains a dot.
+func isStandardPackage(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// Check returns the edges of result that break the rules of cfg, ordered
+// by location.
+func Check(result ProjectStructure, cfg *CheckConfig) []Violation {
+	var violations []Violation
+	layerOf := func(pkg string) int {
+		for i, layer := range cfg.Layers {
+			if layer.Packages.Match(pkg) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	imports, _ := projectImports(result)
+	for _, imp := range imports {
+		to := imp.To
+		if to == "" {
+			to = imp.Path
+		}
+		if to == imp.From {
+			continue
+		}
+		edge := Violation{From: imp.From, To: to, Relation: "imports", Location: imp.Location()}
+
+		if imp.To != "" {
+			if from, target := layerOf(imp.From), layerOf(imp.To); from >= 0 && target >= 0 && target < from {
+				v := edge
+				v.Rule = "layers"
+				v.Message = fmt.Sprintf("layer %s must not depend on layer %s above it", cfg.Layers[from].Name, cfg.Layers[target].Name)
+				violations = append(violations, v)
+			}
+		}
+		for _, rule := range cfg.Forbidden {
+			if rule.From.Match(imp.From) && rule.To.Match(to) {
+				v := edge
+				v.Rule = "forbidden"
+				v.Message = withReason("forbidden dependency", rule.Reason)
+				violations = append(violations, v)
+			}
+		}
+		if imp.To == "" && !isStandardPackage(imp.Path) {
+			for _, list := range cfg.Imports {
+				if list.Packages.Match(imp.From) && !list.Allow.Match(imp.Path) {
+					v := edge
+					v.Rule = "imports"
+					v.Message = "not an allowed third-party import"
+					violations = append(violations, v)
+				}
+			}
+		}
+	}
+
+	if len(cfg.Rules) > 0 {
+		idx := NewIndex(result.CodeGraph)
+		paths := NodePaths(result)
+		for _, e := range result.CodeGraph.Edges {
+			if e.Relation != "calls" || paths[e.From] == "" {
+				continue
+			}
+			caller, callee := idx.Nodes[e.From], idx.Nodes[e.To]
+			fromPkg := path.Dir(paths[e.From])
+
+			// A callee is named by its package, directory or import path,
+			// or by its qualified name
+			var targets []string
+			if callee.Type == "external_function" {
+				targets = []string{callee.Package, callee.Package + "." + callee.Name}
+			} else if p, ok := paths[e.To]; ok {
+				targets = []string{path.Dir(p), callee.QualifiedName()}
+			} else {
+				continue
+			}
+			location := paths[e.From]
+			if e.Line > 0 {
+				location += ":" + strconv.Itoa(e.Line)
+			}
+			for _, rule := range cfg.Rules {
+				if !rule.From.Match(fromPkg) || !matchAny(rule.MustNotCall, targets) {
+					continue
+				}
+				name := rule.Name
+				if name == "" {
+					name = "rules"
+				}
+				violations = append(violations, Violation{
+					Rule:     name,
+					From:     caller.QualifiedName(),
+					To:       targets[len(targets)-1],
+					Relation: "calls",
+					Location: location,
+					Message:  withReason("forbidden call", rule.Reason),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return lessLocation(violations[i].Location, violations[j].Location)
+	})
+	return violations
+}
+
+func matchAny(p Patterns, candidates []string) bool {
+	for _, c := range candidates {
+		if p.Match(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func withReason(msg, reason string) string {
+	if reason == "" {
+		return msg
+	}
+	return msg + ": " + reason
+}
+
+// lessLocation orders "file:line" locations by file, then numerically by
+// line.
+func lessLocation(a, b string) bool {
+	fileA, lineA, _ := strings.Cut(a, ":")
+	fileB, lineB, _ := strings.Cut(b, ":")
+	if fileA != fileB {
+		return fileA < fileB
+	}
+	na, _ := strconv.Atoi(lineA)
+	nb, _ := strconv.Atoi(lineB)
+	return na < nb
+}
+
+// WriteViolations prints one violation per line as location, rule, the
+// offending edge and message.
+func WriteViolations(w io.Writer, violations []Violation) error {
+	var b strings.Builder
+	for _, v := range violations {
+		fmt.Fprintf(&b, "%s: [%s] %s -[%s]-> %s: %s\n", v.Location, v.Rule, v.From, v.Relation, v.To, v.Message)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteViolationsJSON prints the violations as an indented JSON array.
+func WriteViolationsJSON(w io.Writer, violations []Violation) error {
+	if violations == nil {
+		violations = []Violation{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(violations)
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	files := map[string]string{
+		"api/api.go": "package api\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/m/storage\"\n\t\"github.com/other/api\"\n)\n\nfunc Serve() { fmt.Println(storage.Load()) }\n",
+		"storage/storage.go": "package storage\n\nimport (\n\t\"database/sql\"\n\n\t\"example.com/m/api\"\n\t\"github.com/other/storage\"\n)\n\n" +
+			"func Load() *sql.DB {\n\tapi.Serve()\n\tdb, _ := sql.Open(\"sqlite\", \"\")\n\treturn db\n}\n",
+	}
+	tests := []struct {
+		name   string
+		config string
+		want   []string // Violations as location, rule and target
+	}{
+		{
+			name:   "layers",
+			config: "layers: api -> storage\n",
+			want:   []string{"storage/storage.go:6 layers api"},
+		},
+		{
+			name:   "forbidden dependency",
+			config: "forbidden:\n  - from: api/...\n    to: storage\n",
+			want:   []string{"api/api.go:6 forbidden storage"},
+		},
+		{
+			// Third-party imports ending in a project directory are not
+			// the project's packages
+			name:   "third-party imports",
+			config: "imports:\n  - packages: ...\n    allow: github.com/other/api\n",
+			want:   []string{"storage/storage.go:7 imports github.com/other/storage"},
+		},
+		{
+			name:   "call rule",
+			config: "rules:\n  - name: no-sql\n    from: storage\n    must_not_call: database/sql.Open\n",
+			want:   []string{"storage/storage.go:12 no-sql database/sql.Open"},
+		},
+	}
+	result := analyzeFiles(t, files)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), ".codegraph.yaml")
+			if err := os.WriteFile(filename, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := LoadCheckConfig(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range Check(result, cfg) {
+				got = append(got, v.Location+" "+v.Rule+" "+v.To)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package graph
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// symbolIndex gathers what code intelligence exports (SCIP, LSIF) need from
+// a ProjectStructure: where every node is defined, where it is referenced,
+// how to describe it on hover and which symbols implement which.
+type symbolIndex struct {
+	projectName string
+	nodes       map[string]Node
+	paths       map[string]string // Maps node ID to the module path declaring it
+	hovers      map[string]string // Maps node ID to its Go signature
+	comments    map[string]string // Maps node ID to its doc comment
+	symbols     map[string]string // Maps node ID to its SCIP symbol
+
+	documents   []string                // Module paths, sorted
+	occurrences map[string][]occurrence // Occurrences per module path
+	implements  map[string][]string     // Maps node ID to the IDs it implements
+}
+
+// occurrence is a definition of, or reference to, a node within a file
+type occurrence struct {
+	node       string
+	line       int // 1-based, as reported by go/token
+	column     int // 1-based byte column
+	definition bool
+}
+
+func newSymbolIndex(result ProjectStructure) *symbolIndex {
+	idx := &symbolIndex{
+		nodes:       make(map[string]Node),
+		paths:       make(map[string]string),
+		hovers:      make(map[string]string),
+		comments:    make(map[string]string),
+		symbols:     make(map[string]string),
+		occurrences: make(map[string][]occurrence),
+		implements:  make(map[string][]string),
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		idx.nodes[n.ID] = n
+	}
+
+	for projectName, pkgInfo := range result.Project {
+		idx.projectName = projectName
+		for modulePath, module := range pkgInfo.Modules {
+			modulePath = filepath.ToSlash(modulePath)
+			idx.documents = append(idx.documents, modulePath)
+			idx.addModule(modulePath, module)
+		}
+	}
+	sort.Strings(idx.documents)
+
+	for _, n := range result.CodeGraph.Nodes {
+		id := n.ID
+		if modulePath, ok := idx.paths[id]; ok {
+			idx.symbols[id] = scipSymbol(idx.projectName, modulePath, n)
+			if n.Line > 0 {
+				idx.addOccurrence(modulePath, occurrence{node: id, line: n.Line, column: n.Column, definition: true})
+			}
+		}
+	}
+
+	methods := make(map[string]map[string]string) // Maps owner ID to method name to method ID
+	for _, e := range result.CodeGraph.Edges {
+		switch e.Relation {
+		case "has_method", "declares":
+			if methods[e.From] == nil {
+				methods[e.From] = make(map[string]string)
+			}
+			methods[e.From][idx.nodes[e.To].Name] = e.To
+		}
+		if e.Line == 0 {
+			continue
+		}
+		if _, ok := idx.symbols[e.To]; !ok {
+			continue
+		}
+		if modulePath, ok := idx.paths[e.From]; ok {
+			idx.addOccurrence(modulePath, occurrence{node: e.To, line: e.Line, column: e.Column})
+		}
+	}
+
+	for _, e := range result.CodeGraph.Edges {
+		if e.Relation != "implements" {
+			continue
+		}
+		idx.implements[e.From] = append(idx.implements[e.From], e.To)
+		names := make([]string, 0, len(methods[e.To]))
+		for name := range methods[e.To] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if structMethod, ok := methods[e.From][name]; ok {
+				idx.implements[structMethod] = append(idx.implements[structMethod], methods[e.To][name])
+			}
+		}
+	}
+
+	for _, occs := range idx.occurrences {
+		sort.SliceStable(occs, func(i, j int) bool {
+			if occs[i].line != occs[j].line {
+				return occs[i].line < occs[j].line
+			}
+			return occs[i].column < occs[j].column
+		})
+	}
+	return idx
+}
+
+func (idx *symbolIndex) addOccurrence(modulePath string, occ occurrence) {
+	idx.occurrences[modulePath] = append(idx.occurrences[modulePath], occ)
+}
+
+// addModule records the declaring file, signature and comment of everything
+// declared in module.
+func (idx *symbolIndex) addModule(modulePath string, module ModuleInfo) {
+	for _, fn := range module.Functions {
+		idx.addFunction(modulePath, "", fn)
+	}
+	for _, st := range module.Structs {
+		idx.paths[st.ID] = modulePath
+		idx.hovers[st.ID] = "type " + st.Name + " struct"
+		idx.comments[st.ID] = st.Comment
+		for _, fn := range st.Functions {
+			idx.addFunction(filepath.ToSlash(methodPath(modulePath, fn)), st.Name, fn)
+		}
+	}
+	for _, iface := range module.Interfaces {
+		idx.paths[iface.ID] = modulePath
+		idx.hovers[iface.ID] = "type " + iface.Name + " interface"
+		idx.comments[iface.ID] = iface.Comment
+		for _, fn := range iface.Functions {
+			idx.addFunction(modulePath, iface.Name, fn)
+		}
+	}
+	for _, t := range module.Types {
+		idx.paths[t.ID] = modulePath
+		idx.hovers[t.ID] = TypeDeclaration(t)
+		idx.comments[t.ID] = t.Comment
+		for _, fn := range t.Functions {
+			idx.addFunction(filepath.ToSlash(methodPath(modulePath, fn)), t.Name, fn)
+		}
+	}
+	for _, c := range module.Constants {
+		idx.paths[c.ID] = modulePath
+		idx.hovers[c.ID] = declaration("const", c.Name, c.Type, c.Value)
+	}
+	for _, v := range module.Variables {
+		idx.paths[v.ID] = modulePath
+		idx.hovers[v.ID] = declaration("var", v.Name, v.Type, v.Value)
+	}
+}
+
+func (idx *symbolIndex) addFunction(modulePath, receiver string, fn FunctionInfo) {
+	idx.paths[fn.ID] = modulePath
+	idx.hovers[fn.ID] = FunctionSignature(receiver, fn)
+	idx.comments[fn.ID] = fn.Comment
+}
+
+// FunctionSignature renders fn as Go source, e.g. "func (*T) Name(a int)
+// error".
+func FunctionSignature(receiver string, fn FunctionInfo) string {
+	var b strings.Builder
+	b.WriteString("func ")
+	if receiver != "" && fn.PointerReceiver {
+		b.WriteString("(*" + receiver + ") ")
+	} else if receiver != "" {
+		b.WriteString("(" + receiver + ") ")
+	}
+	b.WriteString(fn.Name + "(")
+	for i, p := range fn.Parameters {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if p.Name != "" {
+			b.WriteString(p.Name + " ")
+		}
+		b.WriteString(p.Type)
+	}
+	b.WriteString(")")
+	if fn.ReturnType != "" && fn.ReturnType != "void" {
+		b.WriteString(" " + fn.ReturnType)
+	}
+	return b.String()
+}
+
+// TypeDeclaration renders a named type as Go source, e.g. "type Celsius
+// float64" or "type Alias = pkg.Type".
+func TypeDeclaration(t TypeInfo) string {
+	if t.Alias {
+		return "type " + t.Name + " = " + t.Type
+	}
+	return "type " + t.Name + " " + t.Type
+}
+
+func declaration(keyword, name, typ, value string) string {
+	decl := keyword + " " + name
+	if typ != "" {
+		decl += " " + typ
+	}
+	if value != "" {
+		decl += " = " + value
+	}
+	return decl
+}
+
+// scipSymbol builds a global SCIP symbol for n, using the directory of the
+// declaring file as the package namespace:
+//
+//	codegraph gomod <project> . `pkg/dir`/Type#Method().
+func scipSymbol(projectName, modulePath string, n Node) string {
+	namespace := path.Dir(modulePath)
+	if namespace == "." {
+		namespace = n.Package
+	}
+	symbol := "codegraph gomod " + escapeSymbolPackage(projectName) + " . " +
+		escapeDescriptor(namespace) + "/"
+
+	switch n.Type {
+	case "function":
+		return symbol + escapeDescriptor(n.Name) + "()."
+	case "method", "interface_method":
+		return symbol + escapeDescriptor(n.Receiver) + "#" + escapeDescriptor(n.Name) + "()."
+	case "struct", "interface", "type":
+		return symbol + escapeDescriptor(n.Name) + "#"
+	default:
+		return symbol + escapeDescriptor(n.Name) + "."
+	}
+}
+
+// escapeDescriptor backtick-quotes names that are not simple identifiers,
+// as required by the SCIP symbol grammar.
+func escapeDescriptor(name string) string {
+	for _, r := range name {
+		if !(r == '_' || r == '+' || r == '-' || r == '$' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+		}
+	}
+	return name
+}
+
+// escapeSymbolPackage doubles spaces in package names, which SCIP symbols
+// otherwise use as separators.
+func escapeSymbolPackage(name string) string {
+	if name == "" {
+		return "."
+	}
+	return strings.ReplaceAll(name, " ", "  ")
+}
@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CycleKinds are the graphs FindCycles looks for cycles in.
+var CycleKinds = []string{"import", "call", "type"}
+
+// Cycle is a strongly connected component of the package import graph, the
+// call graph or the graph of struct field types: every member reaches every
+// other over Edges.
+type Cycle struct {
+	Kind  string      `json:"kind"`  // "import", "call" or "type"
+	Nodes []string    `json:"nodes"` // Package directories or qualified names
+	Edges []CycleEdge `json:"edges"`
+}
+
+// CycleEdge is an edge between two members of a cycle, with where it
+// occurs.
+type CycleEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Location string `json:"location,omitempty"`
+}
+
+// CycleOptions controls FindCycles.
+type CycleOptions struct {
+	Kinds     []string // Graphs to search; all when empty
+	SelfLoops bool     // Also report single nodes referring to themselves, such as recursive functions
+}
+
+// cycleGraph is a directed graph over string keys with the edges kept for
+// reporting.
+type cycleGraph struct {
+	keys  []string
+	succ  map[string][]string
+	edges map[[2]string][]CycleEdge
+}
+
+func newCycleGraph() *cycleGraph {
+	return &cycleGraph{succ: make(map[string][]string), edges: make(map[[2]string][]CycleEdge)}
+}
+
+// add records edge e from from to to. Of several edges between the same
+// pair at the same location, such as the field type of a struct that is
+// also a reference, only the most specific one is kept.
+func (g *cycleGraph) add(from, to string, e CycleEdge) {
+	pair := [2]string{from, to}
+	if _, ok := g.edges[pair]; !ok {
+		g.succ[from] = append(g.succ[from], to)
+	}
+	for i, old := range g.edges[pair] {
+		if old.Location == e.Location {
+			if old.Relation == "references" {
+				g.edges[pair][i] = e
+			}
+			return
+		}
+	}
+	g.edges[pair] = append(g.edges[pair], e)
+}
+
+// FindCycles reports the cycles of the kinds in opts, ordered by kind and
+// then by their first member.
+func FindCycles(result ProjectStructure, opts CycleOptions) ([]Cycle, error) {
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = CycleKinds
+	}
+	var cycles []Cycle
+	for _, kind := range kinds {
+		var g *cycleGraph
+		switch kind {
+		case "import":
+			g = importGraph(result)
+		case "call":
+			g = relationGraph(result, func(e Edge, from, to Node) bool {
+				return e.Relation == "calls"
+			})
+		case "type":
+			// Field types show up as references from the struct as well
+			g = relationGraph(result, func(e Edge, from, to Node) bool {
+				switch e.Relation {
+				case "has_field_of_type", "embeds", "references":
+					return from.Type == "struct" && to.Type == "struct"
+				}
+				return false
+			})
+		default:
+			return nil, fmt.Errorf("unknown cycle kind %q (want %s)", kind, strings.Join(CycleKinds, ", "))
+		}
+		for _, scc := range g.components() {
+			if len(scc) == 1 && (!opts.SelfLoops || g.edges[[2]string{scc[0], scc[0]}] == nil) {
+				continue
+			}
+			c := Cycle{Kind: kind, Nodes: scc}
+			in := make(map[string]bool, len(scc))
+			for _, k := range scc {
+				in[k] = true
+			}
+			for _, from := range scc {
+				for _, to := range g.succ[from] {
+					if in[to] && (from != to || opts.SelfLoops) {
+						c.Edges = append(c.Edges, g.edges[[2]string{from, to}]...)
+					}
+				}
+			}
+			cycles = append(cycles, c)
+		}
+	}
+	return cycles, nil
+}
+
+// importGraph links package directories by their imports of other
+// packages of the project.
+func importGraph(result ProjectStructure) *cycleGraph {
+	g := newCycleGraph()
+	imports, dirs := projectImports(result)
+	for _, imp := range imports {
+		if imp.To != "" && imp.To != imp.From {
+			g.add(imp.From, imp.To, CycleEdge{From: imp.From, To: imp.To, Relation: "imports", Location: imp.Location()})
+		}
+	}
+	g.keys = dirs
+	return g
+}
+
+// relationGraph links nodes by the edges accept picks, keyed by qualified
+// name.
+func relationGraph(result ProjectStructure, accept func(e Edge, from, to Node) bool) *cycleGraph {
+	g := newCycleGraph()
+	idx := NewIndex(result.CodeGraph)
+	paths := NodePaths(result)
+	names := make(map[string]bool)
+	for _, e := range result.CodeGraph.Edges {
+		from, okFrom := idx.Nodes[e.From]
+		to, okTo := idx.Nodes[e.To]
+		if !okFrom || !okTo || !accept(e, from, to) {
+			continue
+		}
+		location := paths[e.From]
+		if location == "" {
+			location = from.File
+		}
+		if e.Line > 0 {
+			location += ":" + strconv.Itoa(e.Line)
+		}
+		f, t := from.QualifiedName(), to.QualifiedName()
+		names[f], names[t] = true, true
+		g.add(f, t, CycleEdge{From: f, To: t, Relation: e.Relation, Location: location})
+	}
+	for name := range names {
+		g.keys = append(g.keys, name)
+	}
+	sort.Strings(g.keys)
+	for _, succ := range g.succ {
+		sort.Strings(succ)
+	}
+	return g
+}
+
+// components returns the strongly connected components of g using
+// Tarjan's algorithm, each sorted, in order of their first member.
+func (g *cycleGraph) components() [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var sccs [][]string
+
+	var connect func(v string)
+	connect = func(v string) {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range g.succ[v] {
+			if _, seen := index[w]; !seen {
+				connect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] == index[v] {
+			var scc []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Strings(scc)
+			sccs = append(sccs, scc)
+		}
+	}
+	for _, k := range g.keys {
+		if _, seen := index[k]; !seen {
+			connect(k)
+		}
+	}
+	sort.Slice(sccs, func(i, j int) bool { return sccs[i][0] < sccs[j][0] })
+	return sccs
+}
+
+// WriteCycles prints each cycle as a heading followed by its edges.
+func WriteCycles(w io.Writer, cycles []Cycle) error {
+	var b strings.Builder
+	for i, c := range cycles {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s cycle: %s\n", c.Kind, strings.Join(c.Nodes, ", "))
+		for _, e := range c.Edges {
+			fmt.Fprintf(&b, "  %s -[%s]-> %s", e.From, e.Relation, e.To)
+			if e.Location != "" {
+				fmt.Fprintf(&b, "  (%s)", e.Location)
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCyclesJSON prints the cycles as an indented JSON array.
+func WriteCyclesJSON(w io.Writer, cycles []Cycle) error {
+	if cycles == nil {
+		cycles = []Cycle{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cycles)
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindCycles(t *testing.T) {
+	// Go rejects import cycles, but the graph of a broken tree has them
+	result := analyzeFiles(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"example.com/m/b\"\n\ntype Node struct{ out *b.Edge }\n\n" +
+			"func Ping(n int) { b.Pong(n) }\n\nfunc fact(n int) int {\n\tif n == 0 {\n\t\treturn 1\n\t}\n\treturn n * fact(n-1)\n}\n",
+		"b/b.go": "package b\n\nimport \"example.com/m/a\"\n\ntype Edge struct{ to *a.Node }\n\nfunc Pong(n int) { a.Ping(n - 1) }\n",
+		"c/c.go": "package c\n\nimport \"example.com/m/a\"\n\nfunc Run() { a.Ping(1) }\n",
+	})
+	tests := []struct {
+		name string
+		opts CycleOptions
+		want string // As printed by WriteCycles
+	}{
+		{
+			name: "imports",
+			opts: CycleOptions{Kinds: []string{"import"}},
+			want: "import cycle: a, b\n" +
+				"  a -[imports]-> b  (a/a.go:3)\n" +
+				"  b -[imports]-> a  (b/b.go:3)\n",
+		},
+		{
+			name: "calls",
+			opts: CycleOptions{Kinds: []string{"call"}},
+			want: "call cycle: a.Ping, b.Pong\n" +
+				"  a.Ping -[calls]-> b.Pong  (a/a.go:7)\n" +
+				"  b.Pong -[calls]-> a.Ping  (b/b.go:7)\n",
+		},
+		{
+			name: "recursion",
+			opts: CycleOptions{Kinds: []string{"call"}, SelfLoops: true},
+			want: "call cycle: a.Ping, b.Pong\n" +
+				"  a.Ping -[calls]-> b.Pong  (a/a.go:7)\n" +
+				"  b.Pong -[calls]-> a.Ping  (b/b.go:7)\n" +
+				"\n" +
+				"call cycle: a.fact\n" +
+				"  a.fact -[calls]-> a.fact  (a/a.go:13)\n",
+		},
+		{
+			name: "types",
+			opts: CycleOptions{Kinds: []string{"type"}},
+			want: "type cycle: a.Node, b.Edge\n" +
+				"  a.Node -[has_field_of_type]-> b.Edge  (a/a.go:5)\n" +
+				"  b.Edge -[has_field_of_type]-> a.Node  (b/b.go:5)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycles, err := FindCycles(result, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b strings.Builder
+			if err := WriteCycles(&b, cycles); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("cycles:\n%s\nwant:\n%s", b.String(), tt.want)
+			}
+		})
+	}
+
+	if _, err := FindCycles(result, CycleOptions{Kinds: []string{"field"}}); err == nil {
+		t.Error("no error for an unknown kind of cycle")
+	}
+}
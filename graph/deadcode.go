@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// DeadCodeOptions controls what FindDeadCode treats as entry points.
+type DeadCodeOptions struct {
+	// ExportedRoots makes every exported symbol an entry point, as suits a
+	// library. Without it exported symbols are reported too, as suits a
+	// program whose only entry points are main and init.
+	ExportedRoots bool
+}
+
+// DeadSymbol is a symbol no entry point reaches.
+type DeadSymbol struct {
+	Node   Node   `json:"node"`
+	Path   string `json:"path"` // File path relative to the project root
+	Reason string `json:"reason"`
+}
+
+// Location returns "path:line" of the symbol.
+func (d DeadSymbol) Location() string {
+	if d.Node.Line == 0 {
+		return d.Path
+	}
+	return fmt.Sprintf("%s:%d", d.Path, d.Node.Line)
+}
+
+// wellKnownMethods are methods of standard library interfaces, which are
+// usually called through an interface the graph does not see.
+var wellKnownMethods = map[string]bool{
+	"String": true, "GoString": true, "Error": true, "Unwrap": true, "Is": true, "As": true,
+	"Format": true, "Read": true, "Write": true, "Close": true, "Seek": true,
+	"ReadFrom": true, "WriteTo": true, "ReadAt": true, "WriteAt": true,
+	"Len": true, "Less": true, "Swap": true, "Push": true, "Pop": true,
+	"MarshalJSON": true, "UnmarshalJSON": true, "MarshalText": true, "UnmarshalText": true,
+	"MarshalBinary": true, "UnmarshalBinary": true, "Scan": true, "Value": true,
+	"ServeHTTP": true, "Open": true, "Stat": true, "ReadDir": true, "ReadFile": true,
+	"Name": true, "Size": true, "Mode": true, "ModTime": true, "IsDir": true, "Sys": true,
+	"Info": true, "Type": true,
+}
+
+// isTestEntry reports whether a function is run by go test.
+func isTestEntry(n Node) bool {
+	if !strings.HasSuffix(n.File, "_test.go") || n.Receiver != "" {
+		return false
+	}
+	for _, prefix := range []string{"Test", "Benchmark", "Example", "Fuzz"} {
+		if strings.HasPrefix(n.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isExported(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// FindDeadCode returns the functions, methods, types, constants and
+// variables that no entry point reaches, sorted by location. Entry points
+// are main and init functions, test functions, methods that satisfy an
+// interface and, with opts.ExportedRoots, exported symbols.
+//
+// Calls through a value are not resolved to the method called, so a method
+// is only reported when its receiver is dead too.
+func FindDeadCode(result ProjectStructure, opts DeadCodeOptions) []DeadSymbol {
+	idx := NewIndex(result.CodeGraph)
+
+	paths := NodePaths(result)
+
+	// Methods whose receiver implements an interface declaring them
+	satisfying := make(map[string]bool)
+	for _, e := range result.CodeGraph.Edges {
+		if e.Relation != "implements" {
+			continue
+		}
+		declared := make(map[string]bool)
+		for _, d := range idx.Out[e.To] {
+			if d.Relation == "declares" {
+				declared[idx.Nodes[d.To].Name] = true
+			}
+		}
+		for _, m := range idx.Out[e.From] {
+			if m.Relation == "has_method" && declared[idx.Nodes[m.To].Name] {
+				satisfying[m.To] = true
+			}
+		}
+	}
+
+	var queue []string
+	live := make(map[string]bool)
+	reach := func(id string) {
+		if !live[id] {
+			live[id] = true
+			queue = append(queue, id)
+		}
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		switch {
+		case n.Type == "function" && (n.Name == "init" || n.Name == "main" && n.Package == "main"):
+		case n.Type == "function" && isTestEntry(n):
+		case n.Type == "method" && (satisfying[n.ID] || wellKnownMethods[n.Name]):
+		case n.Name == "_":
+		case opts.ExportedRoots && isExported(n.Name) && n.Type != "interface_method":
+		default:
+			continue
+		}
+		reach(n.ID)
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		// A live type keeps its methods live and a live method its receiver
+		for _, e := range idx.Out[id] {
+			if e.Relation != "references_doc" {
+				reach(e.To)
+			}
+		}
+		for _, e := range idx.In[id] {
+			if e.Relation == "has_method" {
+				reach(e.From)
+			}
+		}
+	}
+
+	var dead []DeadSymbol
+	for _, n := range result.CodeGraph.Nodes {
+		if live[n.ID] || n.Type == "interface_method" || n.Type == "package" || paths[n.ID] == "" {
+			continue
+		}
+		reason := deadReason(idx, n.ID)
+		if n.Type == "method" {
+			reason = "receiver " + n.Receiver + " is unreachable"
+		}
+		dead = append(dead, DeadSymbol{Node: n, Path: paths[n.ID], Reason: reason})
+	}
+	sort.Slice(dead, func(i, j int) bool {
+		a, b := dead[i], dead[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Node.Line < b.Node.Line || a.Node.Line == b.Node.Line && a.Node.Column < b.Node.Column
+	})
+	return dead
+}
+
+// deadReason explains why an unreached symbol is dead: nothing refers to it
+// at all, or only other dead code does.
+func deadReason(idx *Index, id string) string {
+	var users []string
+	seen := make(map[string]bool)
+	for _, e := range idx.In[id] {
+		if e.From == id || seen[e.From] || e.Relation == "references_doc" {
+			continue
+		}
+		seen[e.From] = true
+		users = append(users, idx.Nodes[e.From].QualifiedName())
+	}
+	if len(users) == 0 {
+		return "never referenced"
+	}
+	sort.Strings(users)
+	if len(users) > 3 {
+		users = append(users[:3], fmt.Sprintf("and %d more", len(users)-3))
+	}
+	return "only referenced by unreachable " + strings.Join(users, ", ")
+}
+
+// WriteDeadCode prints one dead symbol per line as location, kind, name
+// and reason.
+func WriteDeadCode(w io.Writer, dead []DeadSymbol) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, d := range dead {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Location(), d.Node.Type, d.Node.QualifiedName(), d.Reason)
+	}
+	return tw.Flush()
+}
+
+// WriteDeadCodeJSON prints the dead symbols as an indented JSON array.
+func WriteDeadCodeJSON(w io.Writer, dead []DeadSymbol) error {
+	if dead == nil {
+		dead = []DeadSymbol{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dead)
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// analyzeFiles analyzes a project held in memory, keyed by slash-separated
+// path; a go.mod is added when files has none.
+func analyzeFiles(t *testing.T, files map[string]string) ProjectStructure {
+	t.Helper()
+	data := map[string][]byte{"go.mod": []byte("module example.com/m\n\ngo 1.23\n")}
+	for name, content := range files {
+		data[name] = []byte(content)
+	}
+	result, err := AnalyzeSource(MemorySource(data), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestFindDeadCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		opts  DeadCodeOptions
+		want  []string // Qualified names, by location
+	}{
+		{
+			name: "same-named types in two packages",
+			files: map[string]string{
+				"x/x.go": "package x\n\ntype config struct{ n int }\n\ntype Holder struct{ c *config }\n",
+				"y/y.go": "package y\n\ntype config struct{ s string }\n",
+			},
+			opts: DeadCodeOptions{ExportedRoots: true},
+			want: []string{"y.config"},
+		},
+		{
+			name: "embedded type of another package",
+			files: map[string]string{
+				"main.go": "package main\n\nimport \"example.com/m/b\"\n\ntype T struct{ b.Base }\n\nfunc main() { _ = T{} }\n",
+				"b/b.go":  "package b\n\ntype Base struct{ ID int }\n\ntype Unused struct{}\n",
+			},
+			want: []string{"b.Unused"},
+		},
+		{
+			name: "method declared in another file",
+			files: map[string]string{
+				"main.go":   "package main\n\ntype evaluator struct{}\n\nfunc main() { _ = evaluator{} }\n",
+				"eval.go":   "package main\n\nfunc (e evaluator) eval() { helper() }\n",
+				"helper.go": "package main\n\nfunc helper() {}\n\nfunc unused() {}\n",
+			},
+			want: []string{"main.unused"},
+		},
+		{
+			name: "parameter shadowing a package-level name",
+			files: map[string]string{
+				"main.go": "package main\n\nvar limit = 1\n\nfunc check(limit int) int { return limit }\n\nfunc main() { check(2) }\n",
+			},
+			want: []string{"main.limit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range FindDeadCode(analyzeFiles(t, tt.files), tt.opts) {
+				got = append(got, d.Node.QualifiedName())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dead code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
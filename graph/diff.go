@@ -0,0 +1,475 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphDiff lists what changed between two graphs. Node IDs differ from one
+// analysis to the next, so nodes are matched by type, qualified name and
+// directory.
+type GraphDiff struct {
+	AddedDependencies   []Dependency `json:"addedDependencies"`
+	RemovedDependencies []Dependency `json:"removedDependencies"`
+	AddedNodes          []Node       `json:"addedNodes"`
+	RemovedNodes        []Node       `json:"removedNodes"`
+	ChangedNodes        []NodeChange `json:"changedNodes"`
+	AddedEdges          []EdgeRef    `json:"addedEdges"`
+	RemovedEdges        []EdgeRef    `json:"removedEdges"`
+}
+
+// Dependency is an import of package path To by the package in directory
+// From.
+type Dependency struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NodeChange describes how a node present in both graphs differs. Only the
+// aspects that changed are set.
+type NodeChange struct {
+	Node      Node          `json:"node"` // As found in the new graph
+	File      *ValueChange  `json:"file,omitempty"`
+	Signature *ValueChange  `json:"signature,omitempty"` // Functions and methods
+	Type      *ValueChange  `json:"type,omitempty"`      // Constants and variables
+	Value     *ValueChange  `json:"value,omitempty"`     // Constants and variables
+	Fields    []FieldChange `json:"fields,omitempty"`    // Structs
+}
+
+// ValueChange is an old and a new value.
+type ValueChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// FieldChange is a struct field that was added (Old is empty), removed (New
+// is empty) or changed type.
+type FieldChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// EdgeRef is an edge between nodes named by their qualified names.
+type EdgeRef struct {
+	From     string `json:"from"`
+	Relation string `json:"relation"`
+	To       string `json:"to"`
+}
+
+// Empty reports whether the two graphs are equivalent.
+func (d *GraphDiff) Empty() bool {
+	return len(d.AddedDependencies)+len(d.RemovedDependencies)+len(d.AddedNodes)+len(d.RemovedNodes)+
+		len(d.ChangedNodes)+len(d.AddedEdges)+len(d.RemovedEdges) == 0
+}
+
+// diffSide is one graph prepared for comparison.
+type diffSide struct {
+	nodes   map[string]Node       // Keyed by type, qualified name and directory
+	details map[string]nodeDetail // Keyed by node ID
+	edges   map[EdgeRef]bool
+	deps    map[Dependency]bool
+}
+
+// nodeDetail holds the declaration details compared for changed nodes.
+type nodeDetail struct {
+	signature   string
+	typ, value  string
+	fields      []PropertyInfo
+	hasFields   bool
+	isConstLike bool
+}
+
+func newDiffSide(result ProjectStructure) *diffSide {
+	s := &diffSide{
+		nodes:   make(map[string]Node),
+		details: make(map[string]nodeDetail),
+		edges:   make(map[EdgeRef]bool),
+		deps:    make(map[Dependency]bool),
+	}
+
+	paths := NodePaths(result)
+	packages := make(map[string]int)
+	for _, n := range result.CodeGraph.Nodes {
+		key := n.Type + " " + n.QualifiedName()
+		switch {
+		case n.Type == "package":
+			// Packages of the same name, such as several main packages,
+			// are matched in the order of their directories; their file
+			// is whichever carries the package doc comment
+			if k := packages[key]; k > 0 {
+				key += " #" + strconv.Itoa(k)
+			}
+			packages[n.Type+" "+n.Name]++
+		case n.Name == "init" || n.Name == "_":
+			// Symbols that may be declared more than once in a package
+			// are told apart by file
+			if p, ok := paths[n.ID]; ok {
+				key += " @" + p
+			} else {
+				key += " @" + n.File
+			}
+		default:
+			// Packages of the same name are told apart by directory
+			if p, ok := paths[n.ID]; ok {
+				key += " @" + path.Dir(p)
+			}
+		}
+		s.nodes[key] = n
+	}
+
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			dir := filepath.ToSlash(filepath.Dir(path))
+			for _, dep := range m.Dependencies {
+				fields := strings.Fields(dep)
+				if len(fields) == 0 {
+					continue
+				}
+				if target, err := strconv.Unquote(fields[len(fields)-1]); err == nil {
+					s.deps[Dependency{From: dir, To: target}] = true
+				}
+			}
+			for _, fn := range m.Functions {
+				s.details[fn.ID] = nodeDetail{signature: FunctionSignature("", fn)}
+			}
+			for _, st := range m.Structs {
+				s.details[st.ID] = nodeDetail{fields: st.Properties, hasFields: true}
+				for _, fn := range st.Functions {
+					s.details[fn.ID] = nodeDetail{signature: FunctionSignature(st.Name, fn)}
+				}
+			}
+			for _, iface := range m.Interfaces {
+				for _, fn := range iface.Functions {
+					s.details[fn.ID] = nodeDetail{signature: FunctionSignature(iface.Name, fn)}
+				}
+			}
+			for _, t := range m.Types {
+				s.details[t.ID] = nodeDetail{signature: TypeDeclaration(t)}
+				for _, fn := range t.Functions {
+					s.details[fn.ID] = nodeDetail{signature: FunctionSignature(t.Name, fn)}
+				}
+			}
+			for _, c := range m.Constants {
+				s.details[c.ID] = nodeDetail{typ: c.Type, value: c.Value, isConstLike: true}
+			}
+			for _, v := range m.Variables {
+				s.details[v.ID] = nodeDetail{typ: v.Type, value: v.Value, isConstLike: true}
+			}
+		}
+	}
+
+	byID := make(map[string]Node, len(result.CodeGraph.Nodes))
+	for _, n := range result.CodeGraph.Nodes {
+		byID[n.ID] = n
+	}
+	for _, e := range result.CodeGraph.Edges {
+		from, okFrom := byID[e.From]
+		to, okTo := byID[e.To]
+		if okFrom && okTo {
+			s.edges[EdgeRef{From: from.QualifiedName(), Relation: e.Relation, To: to.QualifiedName()}] = true
+		}
+	}
+	return s
+}
+
+// Diff compares two analyses of a project.
+func Diff(old, new ProjectStructure) *GraphDiff {
+	before, after := newDiffSide(old), newDiffSide(new)
+	d := &GraphDiff{
+		AddedDependencies:   []Dependency{},
+		RemovedDependencies: []Dependency{},
+		AddedNodes:          []Node{},
+		RemovedNodes:        []Node{},
+		ChangedNodes:        []NodeChange{},
+		AddedEdges:          []EdgeRef{},
+		RemovedEdges:        []EdgeRef{},
+	}
+
+	for dep := range after.deps {
+		if !before.deps[dep] {
+			d.AddedDependencies = append(d.AddedDependencies, dep)
+		}
+	}
+	for dep := range before.deps {
+		if !after.deps[dep] {
+			d.RemovedDependencies = append(d.RemovedDependencies, dep)
+		}
+	}
+
+	for key, n := range after.nodes {
+		o, ok := before.nodes[key]
+		if !ok {
+			d.AddedNodes = append(d.AddedNodes, n)
+			continue
+		}
+		if c, changed := compareNodes(o, n, before.details[o.ID], after.details[n.ID]); changed {
+			d.ChangedNodes = append(d.ChangedNodes, c)
+		}
+	}
+	for key, n := range before.nodes {
+		if _, ok := after.nodes[key]; !ok {
+			d.RemovedNodes = append(d.RemovedNodes, n)
+		}
+	}
+
+	for e := range after.edges {
+		if !before.edges[e] {
+			d.AddedEdges = append(d.AddedEdges, e)
+		}
+	}
+	for e := range before.edges {
+		if !after.edges[e] {
+			d.RemovedEdges = append(d.RemovedEdges, e)
+		}
+	}
+
+	sortDependencies(d.AddedDependencies)
+	sortDependencies(d.RemovedDependencies)
+	sortNodes(d.AddedNodes)
+	sortNodes(d.RemovedNodes)
+	sort.Slice(d.ChangedNodes, func(i, j int) bool {
+		return d.ChangedNodes[i].Node.QualifiedName() < d.ChangedNodes[j].Node.QualifiedName()
+	})
+	sortEdgeRefs(d.AddedEdges)
+	sortEdgeRefs(d.RemovedEdges)
+	return d
+}
+
+// compareNodes reports how n differs from its earlier version o.
+func compareNodes(o, n Node, od, nd nodeDetail) (NodeChange, bool) {
+	c := NodeChange{Node: n}
+	changed := false
+	// A package is not moved by documenting it in another file
+	if o.File != n.File && n.Type != "package" {
+		c.File = &ValueChange{Old: o.File, New: n.File}
+		changed = true
+	}
+	if od.signature != nd.signature && od.signature != "" && nd.signature != "" {
+		c.Signature = &ValueChange{Old: od.signature, New: nd.signature}
+		changed = true
+	}
+	if od.isConstLike && nd.isConstLike {
+		if od.typ != nd.typ {
+			c.Type = &ValueChange{Old: od.typ, New: nd.typ}
+			changed = true
+		}
+		if od.value != nd.value {
+			c.Value = &ValueChange{Old: od.value, New: nd.value}
+			changed = true
+		}
+	}
+	if od.hasFields && nd.hasFields {
+		c.Fields = compareFields(od.fields, nd.fields)
+		changed = changed || len(c.Fields) > 0
+	}
+	return c, changed
+}
+
+// compareFields lists field changes in the order of the new struct, followed
+// by the removed fields.
+func compareFields(old, new []PropertyInfo) []FieldChange {
+	oldTypes := make(map[string]string, len(old))
+	for _, f := range old {
+		oldTypes[f.Name] = f.Type
+	}
+	newNames := make(map[string]bool, len(new))
+	var changes []FieldChange
+	for _, f := range new {
+		newNames[f.Name] = true
+		if t, ok := oldTypes[f.Name]; !ok || t != f.Type {
+			changes = append(changes, FieldChange{Name: f.Name, Old: t, New: f.Type})
+		}
+	}
+	for _, f := range old {
+		if !newNames[f.Name] {
+			changes = append(changes, FieldChange{Name: f.Name, Old: f.Type})
+		}
+	}
+	return changes
+}
+
+func sortDependencies(deps []Dependency) {
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].From != deps[j].From {
+			return deps[i].From < deps[j].From
+		}
+		return deps[i].To < deps[j].To
+	})
+}
+
+func sortNodes(ns []Node) {
+	sort.Slice(ns, func(i, j int) bool {
+		if a, b := ns[i].QualifiedName(), ns[j].QualifiedName(); a != b {
+			return a < b
+		}
+		return ns[i].Type < ns[j].Type
+	})
+}
+
+func sortEdgeRefs(es []EdgeRef) {
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].From != es[j].From {
+			return es[i].From < es[j].From
+		}
+		if es[i].Relation != es[j].Relation {
+			return es[i].Relation < es[j].Relation
+		}
+		return es[i].To < es[j].To
+	})
+}
+
+// details returns one line per changed aspect of c, passing declarations
+// through code for formatting.
+func (c NodeChange) details(code func(string) string) []string {
+	var lines []string
+	if c.Signature != nil {
+		lines = append(lines, "signature: "+code(c.Signature.Old)+" → "+code(c.Signature.New))
+	}
+	if c.Type != nil {
+		lines = append(lines, "type: "+code(c.Type.Old)+" → "+code(c.Type.New))
+	}
+	if c.Value != nil {
+		if strings.Contains(c.Value.Old+c.Value.New, "\n") {
+			lines = append(lines, "value changed") // Too long to show inline
+		} else {
+			lines = append(lines, "value: "+code(c.Value.Old)+" → "+code(c.Value.New))
+		}
+	}
+	for _, f := range c.Fields {
+		switch {
+		case f.Old == "":
+			lines = append(lines, "field added: "+code(f.Name+" "+f.New))
+		case f.New == "":
+			lines = append(lines, "field removed: "+code(f.Name+" "+f.Old))
+		default:
+			lines = append(lines, "field "+f.Name+": "+code(f.Old)+" → "+code(f.New))
+		}
+	}
+	if c.File != nil {
+		lines = append(lines, "moved: "+c.File.Old+" → "+c.File.New)
+	}
+	return lines
+}
+
+func (e EdgeRef) String() string {
+	return e.From + " -[" + e.Relation + "]-> " + e.To
+}
+
+// WriteText prints the diff in a compact, diff-like form.
+func (d *GraphDiff) WriteText(w io.Writer) error {
+	if d.Empty() {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	var b strings.Builder
+	if len(d.AddedDependencies)+len(d.RemovedDependencies) > 0 {
+		fmt.Fprintf(&b, "Package dependencies: +%d -%d\n", len(d.AddedDependencies), len(d.RemovedDependencies))
+		for _, dep := range d.AddedDependencies {
+			fmt.Fprintf(&b, "+ %s → %s\n", dep.From, dep.To)
+		}
+		for _, dep := range d.RemovedDependencies {
+			fmt.Fprintf(&b, "- %s → %s\n", dep.From, dep.To)
+		}
+		b.WriteString("\n")
+	}
+	if len(d.AddedNodes)+len(d.RemovedNodes)+len(d.ChangedNodes) > 0 {
+		fmt.Fprintf(&b, "Nodes: +%d -%d ~%d\n", len(d.AddedNodes), len(d.RemovedNodes), len(d.ChangedNodes))
+		for _, n := range d.AddedNodes {
+			fmt.Fprintf(&b, "+ %s %s  %s\n", n.Type, n.QualifiedName(), n.Location())
+		}
+		for _, n := range d.RemovedNodes {
+			fmt.Fprintf(&b, "- %s %s  %s\n", n.Type, n.QualifiedName(), n.Location())
+		}
+		for _, c := range d.ChangedNodes {
+			fmt.Fprintf(&b, "~ %s %s  %s\n", c.Node.Type, c.Node.QualifiedName(), c.Node.Location())
+			for _, line := range c.details(func(s string) string { return s }) {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+		b.WriteString("\n")
+	}
+	if len(d.AddedEdges)+len(d.RemovedEdges) > 0 {
+		fmt.Fprintf(&b, "Edges: +%d -%d\n", len(d.AddedEdges), len(d.RemovedEdges))
+		for _, e := range d.AddedEdges {
+			fmt.Fprintf(&b, "+ %s\n", e)
+		}
+		for _, e := range d.RemovedEdges {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+	}
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// WriteMarkdown prints the diff as Markdown suitable for a pull request
+// comment: a summary table, then one section per kind of change.
+func (d *GraphDiff) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("## Code graph changes\n\n")
+	if d.Empty() {
+		b.WriteString("No changes.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("| | Added | Removed | Changed |\n|---|---:|---:|---:|\n")
+	fmt.Fprintf(&b, "| Package dependencies | %d | %d | |\n", len(d.AddedDependencies), len(d.RemovedDependencies))
+	fmt.Fprintf(&b, "| Nodes | %d | %d | %d |\n", len(d.AddedNodes), len(d.RemovedNodes), len(d.ChangedNodes))
+	fmt.Fprintf(&b, "| Edges | %d | %d | |\n", len(d.AddedEdges), len(d.RemovedEdges))
+
+	if len(d.AddedDependencies)+len(d.RemovedDependencies) > 0 {
+		b.WriteString("\n### Package dependencies\n\n")
+		for _, dep := range d.AddedDependencies {
+			fmt.Fprintf(&b, "- :heavy_plus_sign: `%s` now imports `%s`\n", dep.From, dep.To)
+		}
+		for _, dep := range d.RemovedDependencies {
+			fmt.Fprintf(&b, "- :heavy_minus_sign: `%s` no longer imports `%s`\n", dep.From, dep.To)
+		}
+	}
+	if len(d.AddedNodes) > 0 {
+		b.WriteString("\n### Added\n\n")
+		for _, n := range d.AddedNodes {
+			fmt.Fprintf(&b, "- %s `%s` (%s)\n", n.Type, n.QualifiedName(), n.Location())
+		}
+	}
+	if len(d.RemovedNodes) > 0 {
+		b.WriteString("\n### Removed\n\n")
+		for _, n := range d.RemovedNodes {
+			fmt.Fprintf(&b, "- %s `%s` (%s)\n", n.Type, n.QualifiedName(), n.Location())
+		}
+	}
+	if len(d.ChangedNodes) > 0 {
+		b.WriteString("\n### Changed\n\n")
+		for _, c := range d.ChangedNodes {
+			fmt.Fprintf(&b, "- %s `%s`\n", c.Node.Type, c.Node.QualifiedName())
+			for _, line := range c.details(func(s string) string { return "`" + s + "`" }) {
+				fmt.Fprintf(&b, "  - %s\n", line)
+			}
+		}
+	}
+	if len(d.AddedEdges)+len(d.RemovedEdges) > 0 {
+		b.WriteString("\n<details>\n<summary>Edges</summary>\n\n```diff\n")
+		for _, e := range d.AddedEdges {
+			fmt.Fprintf(&b, "+ %s\n", e)
+		}
+		for _, e := range d.RemovedEdges {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+		b.WriteString("```\n\n</details>\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON prints the diff as an indented JSON object.
+func (d *GraphDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
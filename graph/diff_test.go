@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := map[string]string{
+		"cmd/a/main.go": "package main\n\nfunc main() {}\n",
+		"cmd/b/main.go": "package main\n\nfunc main() {}\n",
+		"lib/lib.go":    "package lib\n\nfunc Parse(s string) int { return len(s) }\n",
+	}
+	with := func(changes map[string]string) map[string]string {
+		files := make(map[string]string)
+		for name, content := range base {
+			files[name] = content
+		}
+		for name, content := range changes {
+			if content == "" {
+				delete(files, name)
+			} else {
+				files[name] = content
+			}
+		}
+		return files
+	}
+	tests := []struct {
+		name    string
+		changes map[string]string
+		want    []string // Added, removed and changed nodes
+	}{
+		{
+			name:    "package documented in another file",
+			changes: map[string]string{"lib/doc.go": "// Package lib parses.\npackage lib\n"},
+		},
+		{
+			name:    "main package documented in another file",
+			changes: map[string]string{"cmd/b/doc.go": "// Command b.\npackage main\n"},
+		},
+		{
+			name:    "function moved",
+			changes: map[string]string{"lib/lib.go": "package lib\n", "lib/parse.go": "package lib\n\nfunc Parse(s string) int { return len(s) }\n"},
+			want:    []string{"changed lib.Parse moved: lib.go → parse.go"},
+		},
+		{
+			name:    "signature changed",
+			changes: map[string]string{"lib/lib.go": "package lib\n\nfunc Parse(b []byte) int { return len(b) }\n"},
+			want:    []string{"changed lib.Parse signature: func Parse(s string) int → func Parse(b []byte) int"},
+		},
+		{
+			name:    "package added",
+			changes: map[string]string{"lib/sub/sub.go": "package sub\n"},
+			want:    []string{"added sub"},
+		},
+		{
+			name:    "function removed",
+			changes: map[string]string{"cmd/b/main.go": "", "cmd/b/b.go": "package main\n"},
+			want:    []string{"removed main.main"},
+		},
+	}
+	old := analyzeFiles(t, base)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Diff(old, analyzeFiles(t, with(tt.changes)))
+			var got []string
+			for _, n := range d.AddedNodes {
+				got = append(got, "added "+n.QualifiedName())
+			}
+			for _, n := range d.RemovedNodes {
+				got = append(got, "removed "+n.QualifiedName())
+			}
+			for _, c := range d.ChangedNodes {
+				for _, line := range c.details(func(s string) string { return s }) {
+					got = append(got, "changed "+c.Node.QualifiedName()+" "+line)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diff = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphDiffWriteText(t *testing.T) {
+	old := analyzeFiles(t, callChain)
+	files := make(map[string]string)
+	for name, content := range callChain {
+		files[name] = content
+	}
+	files["main.go"] = "package main\n\nimport \"fmt\"\n\nfunc main() { run() }\n\nfunc run() { load(); fmt.Println() }\n\n" +
+		"func load() {}\n\nfunc save() { load() }\n\nconst Version = \"1\"\n"
+	d := Diff(old, analyzeFiles(t, files))
+
+	var b strings.Builder
+	if err := d.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "Package dependencies: +1 -1\n" +
+		"+ . → fmt\n" +
+		"- . → example.com/m/store\n" +
+		"\n" +
+		"Nodes: +2 -0 ~0\n" +
+		"+ external_function fmt.Println  \n" +
+		"+ constant main.Version  main.go:13\n" +
+		"\n" +
+		"Edges: +1 -2\n" +
+		"+ main.run -[calls]-> fmt.Println\n" +
+		"- main.load -[calls]-> store.Put\n" +
+		"- main.run -[calls]-> main.save\n"
+	if b.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	var empty strings.Builder
+	if err := Diff(old, old).WriteText(&empty); err != nil {
+		t.Fatal(err)
+	}
+	if empty.String() != "No changes\n" {
+		t.Errorf("diff of a graph with itself = %q", empty.String())
+	}
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"strings"
+)
+
+// Doc is a doc comment parsed into blocks by the rules of go/doc/comment.
+type Doc struct {
+	Blocks     []DocBlock `json:"blocks"`
+	Deprecated string     `json:"deprecated,omitempty"` // Notice of a "Deprecated:" paragraph
+	Links      []string   `json:"links,omitempty"`      // Doc link targets, e.g. "io.Reader" or "Index.Resolve"
+}
+
+// DocBlock is one block of a doc comment.
+type DocBlock struct {
+	Kind  string   `json:"kind"`            // paragraph, heading, code or list
+	Text  string   `json:"text,omitempty"`  // Paragraphs and headings on one line; code verbatim
+	Items []string `json:"items,omitempty"` // List items
+}
+
+// IsDeprecated reports whether the comment marks its declaration as
+// deprecated; d may be nil.
+func (d *Doc) IsDeprecated() bool {
+	return d != nil && d.Deprecated != ""
+}
+
+// pendingDocLink is a doc link waiting for resolveDocLinks, written in the
+// doc comment of node fromID in package packageName, declared in dir
+type pendingDocLink struct {
+	fromID      string
+	packageName string
+	dir         string
+	link        comment.DocLink
+}
+
+// extractComment returns the text of a doc comment on a single line, for
+// the Comment fields; both // and /* */ comments are understood
+func extractComment(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+// extractDoc parses the doc comment of node id, declared in directory dir
+// as part of package packageName, and records its doc links for
+// resolveDocLinks. [Name] is a link when Name is declared in dir, and
+// [pkg.Name] when pkg is imported by the file being processed.
+func extractDoc(group *ast.CommentGroup, id, packageName, dir string) *Doc {
+	if group == nil {
+		return nil
+	}
+	text := group.Text()
+	if text == "" {
+		return nil
+	}
+	symbols := packageSymbols[dir]
+	p := comment.Parser{
+		LookupPackage: func(name string) (string, bool) {
+			importPath, ok := fileImports[name]
+			return importPath, ok
+		},
+		LookupSym: func(recv, name string) bool {
+			if recv != "" {
+				name = recv + "." + name
+			}
+			return symbols[name]
+		},
+	}
+
+	doc := &Doc{Blocks: []DocBlock{}}
+	seen := make(map[string]bool)
+	inline := func(text []comment.Text) string {
+		var b strings.Builder
+		var write func(text []comment.Text)
+		write = func(text []comment.Text) {
+			for _, t := range text {
+				switch t := t.(type) {
+				case comment.Plain:
+					b.WriteString(string(t))
+				case comment.Italic:
+					b.WriteString(string(t))
+				case *comment.Link:
+					write(t.Text)
+				case *comment.DocLink:
+					write(t.Text)
+					target := docLinkTarget(t)
+					if !seen[target] {
+						seen[target] = true
+						doc.Links = append(doc.Links, target)
+						pendingDocLinks = append(pendingDocLinks, pendingDocLink{fromID: id, packageName: packageName, dir: dir, link: *t})
+					}
+				}
+			}
+		}
+		write(text)
+		return strings.Join(strings.Fields(b.String()), " ")
+	}
+
+	for _, block := range p.Parse(text).Content {
+		switch block := block.(type) {
+		case *comment.Paragraph:
+			text := inline(block.Text)
+			if notice, ok := strings.CutPrefix(text, "Deprecated: "); ok && doc.Deprecated == "" {
+				doc.Deprecated = notice
+			}
+			doc.Blocks = append(doc.Blocks, DocBlock{Kind: "paragraph", Text: text})
+		case *comment.Heading:
+			doc.Blocks = append(doc.Blocks, DocBlock{Kind: "heading", Text: inline(block.Text)})
+		case *comment.Code:
+			doc.Blocks = append(doc.Blocks, DocBlock{Kind: "code", Text: block.Text})
+		case *comment.List:
+			list := DocBlock{Kind: "list"}
+			for _, item := range block.Items {
+				var paragraphs []string
+				for _, b := range item.Content {
+					if para, ok := b.(*comment.Paragraph); ok {
+						paragraphs = append(paragraphs, inline(para.Text))
+					}
+				}
+				list.Items = append(list.Items, strings.Join(paragraphs, " "))
+			}
+			doc.Blocks = append(doc.Blocks, list)
+		}
+	}
+	return doc
+}
+
+// docLinkTarget writes the target of a doc link as it appears between the
+// brackets, with the full import path
+func docLinkTarget(l *comment.DocLink) string {
+	parts := make([]string, 0, 3)
+	for _, s := range []string{l.ImportPath, l.Recv, l.Name} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// specDoc returns the doc comment of a type, const or var spec, which for
+// the only spec of a declaration may be written on the declaration instead
+func specDoc(d *ast.GenDecl, doc *ast.CommentGroup) *ast.CommentGroup {
+	if doc == nil && len(d.Specs) == 1 {
+		return d.Doc
+	}
+	return doc
+}
+
+// collectSymbols adds the top-level names declared in file to symbols, as
+// "Name" or, for methods of types and interfaces, "Type.Name"
+func collectSymbols(file *ast.File, symbols map[string]bool) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			name := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := receiverTypeName(d)
+				if recv == "" {
+					continue
+				}
+				name = recv + "." + name
+			}
+			symbols[name] = true
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					symbols[s.Name.Name] = true
+					if iface, ok := s.Type.(*ast.InterfaceType); ok && iface.Methods != nil {
+						for _, m := range iface.Methods.List {
+							for _, name := range m.Names {
+								symbols[s.Name.Name+"."+name.Name] = true
+							}
+						}
+					}
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						symbols[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// packageDecl is what processPackage learns from the files of a package
+// for its package node: the file holding the package doc comment, or else
+// the first file, with that file's imports
+type packageDecl struct {
+	name    string
+	file    string
+	pos     token.Position
+	doc     *ast.CommentGroup
+	imports map[string]string
+}
+
+// resolveDocLinks turns the doc links recorded by extractDoc into
+// "references_doc" edges. Links into packages outside the project are
+// dropped, as are links naming nothing the analyzer knows.
+func resolveDocLinks() {
+	for _, pending := range pendingDocLinks {
+		l := pending.link
+		pkg, dir := pending.packageName, pending.dir
+		if l.ImportPath != "" {
+			pkg = importName(l.ImportPath)
+			if _, ok := packageMap[pkg]; !ok {
+				continue
+			}
+			dir, _ = importDir(l.ImportPath)
+		}
+		var targetID string
+		var exists bool
+		switch {
+		case l.Name == "":
+			targetID, exists = packageMap[pkg]
+		case l.Recv != "":
+			targetID, exists = funcMap[l.Recv+"."+l.Name]
+		default:
+			targetID, exists = valueMap[pkg+"."+l.Name]
+			if !exists {
+				targetID, exists = funcMap[pkg+"."+l.Name]
+			}
+			if !exists {
+				targetID, exists = typeMap[typeKey(dir, l.Name)]
+			}
+		}
+		if exists && targetID != pending.fromID {
+			addEdge(Edge{
+				From:     pending.fromID,
+				To:       targetID,
+				Relation: "references_doc",
+			})
+		}
+	}
+	pendingDocLinks = nil
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+func init() {
+	RegisterFormat(Format{Name: "dot", Extension: ".dot", Encoder: EncoderFunc(writeDOT)})
+}
+
+// dotShapes gives each node type a distinct Graphviz shape.
+var dotShapes = map[string]string{
+	"function":          "ellipse",
+	"method":            "ellipse",
+	"struct":            "box",
+	"interface":         "component",
+	"interface_method":  "note",
+	"type":              "box3d",
+	"constant":          "plaintext",
+	"variable":          "plaintext",
+	"external_function": "cds",
+	"package":           "folder",
+}
+
+// writeDOT writes the code graph in Graphviz DOT syntax, with one cluster
+// per package.
+func writeDOT(out io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	w := bufio.NewWriter(out)
+	fmt.Fprintf(w, "digraph %s {\n", dotQuote(opts.ProjectName))
+	fmt.Fprintln(w, "\trankdir=LR;")
+	fmt.Fprintln(w, "\tnode [fontname=\"Helvetica\", fontsize=10];")
+	fmt.Fprintln(w, "\tedge [fontname=\"Helvetica\", fontsize=8];")
+
+	byPackage := make(map[string][]Node)
+	for _, n := range result.CodeGraph.Nodes {
+		byPackage[n.Package] = append(byPackage[n.Package], n)
+	}
+	packages := make([]string, 0, len(byPackage))
+	for pkg := range byPackage {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	for i, pkg := range packages {
+		pkgNodes := byPackage[pkg]
+		sort.Slice(pkgNodes, func(a, b int) bool { return pkgNodes[a].ID < pkgNodes[b].ID })
+
+		indent := "\t"
+		if pkg != "" {
+			fmt.Fprintf(w, "\tsubgraph cluster_%d {\n", i)
+			fmt.Fprintf(w, "\t\tlabel=%s;\n", dotQuote(pkg))
+			indent = "\t\t"
+		}
+		for _, n := range pkgNodes {
+			label := n.Name
+			if n.Receiver != "" {
+				label = n.Receiver + "." + n.Name
+			}
+			shape := dotShapes[n.Type]
+			if shape == "" {
+				shape = "ellipse"
+			}
+			fmt.Fprintf(w, "%s%s [label=%s, shape=%s];\n", indent, dotQuote(n.ID), dotQuote(label), shape)
+		}
+		if pkg != "" {
+			fmt.Fprintln(w, "\t}")
+		}
+	}
+
+	for _, e := range result.CodeGraph.Edges {
+		fmt.Fprintf(w, "\t%s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Relation))
+	}
+	fmt.Fprintln(w, "}")
+	return w.Flush()
+}
+
+// dotQuote returns s as a double-quoted DOT ID.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EncodeOptions describes the analysis an Encoder is writing out.
+type EncodeOptions struct {
+	ProjectPath string // Root directory that was analyzed
+	ProjectName string
+	FS          fs.FS // Files analyzed by a StreamEncoder; nil reads ProjectPath
+}
+
+// source returns the files a StreamEncoder analyzes.
+func (o EncodeOptions) source() Source {
+	if o.FS == nil {
+		return DirSource(o.ProjectPath)
+	}
+	return Source{FS: o.FS, Root: o.ProjectPath}
+}
+
+// Encoder writes an analyzed project in one output format.
+type Encoder interface {
+	Encode(w io.Writer, result ProjectStructure, opts EncodeOptions) error
+}
+
+// EncoderFunc adapts an ordinary function to the Encoder interface.
+type EncoderFunc func(w io.Writer, result ProjectStructure, opts EncodeOptions) error
+
+// Encode calls f(w, result, opts).
+func (f EncoderFunc) Encode(w io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	return f(w, result, opts)
+}
+
+// StreamEncoder is implemented by encoders that can write records while the
+// analyzer runs, so the whole ProjectStructure never has to be in memory.
+type StreamEncoder interface {
+	Encoder
+	EncodeStream(w io.Writer, opts EncodeOptions) error
+}
+
+// Decoder reads back a project written by the Encoder of the same format.
+type Decoder interface {
+	Decode(r io.Reader) (ProjectStructure, error)
+}
+
+// DecoderFunc adapts an ordinary function to the Decoder interface.
+type DecoderFunc func(r io.Reader) (ProjectStructure, error)
+
+// Decode calls f(r).
+func (f DecoderFunc) Decode(r io.Reader) (ProjectStructure, error) {
+	return f(r)
+}
+
+// Format is a named output format selectable with --format.
+type Format struct {
+	Name      string
+	Extension string // File extension including the dot, e.g. ".json"
+	Encoder   Encoder
+	Decoder   Decoder // Nil for formats that cannot be read back, e.g. dot
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]Format)
+)
+
+// RegisterFormat makes an output format available by name. It panics if the
+// name is empty, already registered, or the encoder is nil, so it is best
+// called from an init function.
+func RegisterFormat(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if f.Name == "" || f.Encoder == nil {
+		panic("graph: RegisterFormat needs a name and an encoder")
+	}
+	if _, dup := formats[f.Name]; dup {
+		panic("graph: RegisterFormat called twice for format " + f.Name)
+	}
+	formats[f.Name] = f
+}
+
+// LookupFormat returns the format registered under name.
+func LookupFormat(name string) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	f, ok := formats[name]
+	return f, ok
+}
+
+// Formats returns the names of all registered formats, sorted.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Encode writes result to w in the named format.
+func Encode(w io.Writer, format string, result ProjectStructure, opts EncodeOptions) error {
+	f, ok := LookupFormat(format)
+	if !ok {
+		return unknownFormat(format)
+	}
+	return f.Encoder.Encode(w, result, opts)
+}
+
+// parseFormats resolves a comma-separated list such as "json,dot".
+func parseFormats(list string) ([]Format, error) {
+	var selected []Format
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		f, ok := LookupFormat(name)
+		if !ok {
+			return nil, unknownFormat(name)
+		}
+		selected = append(selected, f)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no output format given")
+	}
+	return selected, nil
+}
+
+func unknownFormat(name string) error {
+	return fmt.Errorf("unknown output format %q (available: %s)", name, strings.Join(Formats(), ", "))
+}
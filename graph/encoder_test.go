@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleProject holds one declaration of every kind the formats store.
+var sampleProject = map[string]string{
+	"lib/doc.go": "// Package lib stores records.\npackage lib\n",
+	"lib/lib.go": "package lib\n\nimport \"fmt\"\n\n// Limit caps a Store.\nconst Limit = 10\n\nvar Default = New(Limit)\n\n" +
+		"type Store struct {\n\tN    int    `json:\"n,omitempty\"`\n\tname string\n}\n\n" +
+		"type Reader interface{ Read(p []byte) (int, error) }\n\ntype Celsius float64\n\n" +
+		"// New returns a Store.\n//\n// Deprecated: use Open.\nfunc New(n int) *Store { return &Store{N: n} }\n\n" +
+		"func (s *Store) Read(p []byte) (int, error) { fmt.Println(s.name); return 0, nil }\n\nfunc (c Celsius) String() string { return \"\" }\n",
+}
+
+func TestFormatsRoundTrip(t *testing.T) {
+	result := analyzeFiles(t, sampleProject)
+	for _, name := range Formats() {
+		f, _ := LookupFormat(name)
+		if f.Decoder == nil {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, name, result, EncodeOptions{ProjectName: "test"}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decode(&buf, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, result) {
+				t.Errorf("read back:\n%+v\nwant\n%+v", got, result)
+			}
+		})
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		list string
+		want []string
+		err  string
+	}{
+		{list: "json", want: []string{"json"}},
+		{list: "json, dot,", want: []string{"json", "dot"}},
+		{list: "", err: "no output format given"},
+		{list: "json,yaml", err: `unknown output format "yaml"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			formats, err := parseFormats(tt.list)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range formats {
+				got = append(got, f.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formats = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
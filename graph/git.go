@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// AnalyzeRevision analyzes projectPath as of the git revision rev.
+func AnalyzeRevision(projectPath, projectName, rev string) (ProjectStructure, error) {
+	src, err := RevisionSource(projectPath, rev)
+	if err != nil {
+		return ProjectStructure{}, err
+	}
+	return AnalyzeSource(src, projectName)
+}
+
+// RevisionSource reads the Go files and go.mod under projectPath, which
+// must be inside a git repository, from the tree of revision rev. Files are
+// read straight from the object database, so neither the checkout nor the
+// index is touched.
+func RevisionSource(projectPath, rev string) (Source, error) {
+	if info, err := os.Stat(projectPath); err != nil {
+		return Source{}, err
+	} else if !info.IsDir() {
+		return Source{}, fmt.Errorf("%s is not a directory in a git repository", projectPath)
+	}
+	if _, err := git(projectPath, nil, "rev-parse", "--git-dir"); err != nil {
+		return Source{}, err
+	}
+	if _, err := git(projectPath, nil, "rev-parse", "--verify", "--quiet", rev+"^{commit}"); err != nil {
+		return Source{}, fmt.Errorf("unknown git revision %q", rev)
+	}
+
+	// "rev:./" names the tree of projectPath itself, wherever it sits in
+	// the repository; --full-tree lists all of it rather than only what lies
+	// under the working directory again
+	listing, err := git(projectPath, nil, "ls-tree", "-r", "-z", "--full-tree", rev+":./")
+	if err != nil {
+		return Source{}, err
+	}
+	var names, objects []string
+	for _, entry := range strings.Split(string(listing), "\x00") {
+		// <mode> SP <type> SP <object> TAB <path>
+		meta, name, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" || !strings.HasSuffix(name, ".go") && name != "go.mod" {
+			continue
+		}
+		names = append(names, name)
+		objects = append(objects, fields[2])
+	}
+
+	files := make(memFS, len(names))
+	if len(names) > 0 {
+		out, err := git(projectPath, strings.NewReader(strings.Join(objects, "\n")+"\n"), "cat-file", "--batch")
+		if err != nil {
+			return Source{}, err
+		}
+		r := bufio.NewReader(bytes.NewReader(out))
+		for _, name := range names {
+			content, err := readBatchObject(r)
+			if err != nil {
+				return Source{}, fmt.Errorf("reading %s at %s: %w", name, rev, err)
+			}
+			files[name] = content
+		}
+	}
+	return Source{FS: files, Root: projectPath}, nil
+}
+
+// readBatchObject reads one object from "git cat-file --batch" output:
+// a "<object> <type> <size>" header line, the content and a newline.
+func readBatchObject(r *bufio.Reader) ([]byte, error) {
+	header, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected cat-file header %q", strings.TrimSpace(header))
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	content := make([]byte, size+1)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+	return content[:size], nil
+}
+
+// git runs a git command in dir, feeding it stdin when not nil, and returns
+// its standard output.
+func git(dir string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRevisionSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		if _, err := git(repo, nil, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		filename := filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+	run("config", "commit.gpgsign", "false")
+	write("README.md", "project\n")
+	write("svc/go.mod", "module example.com/svc\n")
+	write("svc/main.go", "package main\n\nfunc main() {}\n")
+	write("svc/lib/lib.go", "package lib\n\nfunc Old() {}\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "first")
+	write("svc/lib/lib.go", "package lib\n\nfunc New() {}\n")
+	run("commit", "-q", "-a", "-m", "second")
+	// Uncommitted changes are not part of any revision
+	write("svc/lib/extra.go", "package lib\n\nfunc Extra() {}\n")
+
+	tests := []struct {
+		rev   string
+		files []string
+		lib   string
+	}{
+		{"HEAD~1", []string{"go.mod", "lib/lib.go", "main.go"}, "func Old() {}"},
+		{"HEAD", []string{"go.mod", "lib/lib.go", "main.go"}, "func New() {}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rev, func(t *testing.T) {
+			src, err := RevisionSource(filepath.Join(repo, "svc"), tt.rev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var files []string
+			err = fs.WalkDir(src.FS, ".", func(path string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() {
+					files = append(files, path)
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(files)
+			if !reflect.DeepEqual(files, tt.files) {
+				t.Errorf("files = %v, want %v", files, tt.files)
+			}
+			lib, err := fs.ReadFile(src.FS, "lib/lib.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(lib), tt.lib) {
+				t.Errorf("lib/lib.go = %q, want it to contain %q", lib, tt.lib)
+			}
+		})
+	}
+
+	result, err := AnalyzeRevision(filepath.Join(repo, "svc"), "test", "HEAD~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if module := result.Project["test"].Module; module != "example.com/svc" {
+		t.Errorf("module = %q, want example.com/svc", module)
+	}
+
+	if _, err := RevisionSource(repo, "no-such-branch"); err == nil || !strings.Contains(err.Error(), "unknown git revision") {
+		t.Errorf("error for an unknown revision = %v", err)
+	}
+}
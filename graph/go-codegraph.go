@@ -0,0 +1,1453 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ProjectStructure represents the entire project structure
+type ProjectStructure struct {
+	SchemaVersion int                    `json:"schemaVersion,omitempty"` // See SchemaVersion; 0 in graphs predating it
+	Project       map[string]PackageInfo `json:"project"`
+	CodeGraph     CodeGraph              `json:"codeGraph"`
+}
+
+// PackageInfo represents information about a Go package
+type PackageInfo struct {
+	Module  string                `json:"module,omitempty"` // Module path declared by go.mod
+	Modules map[string]ModuleInfo `json:"modules"`
+}
+
+// ModuleInfo represents information about a Go file
+type ModuleInfo struct {
+	Package      string          `json:"package,omitempty"`
+	Structs      []StructInfo    `json:"structs"`
+	Functions    []FunctionInfo  `json:"functions"`
+	Interfaces   []InterfaceInfo `json:"interfaces"`
+	Types        []TypeInfo      `json:"types"`
+	Dependencies []string        `json:"dependencies"`
+	ImportLines  []int           `json:"importLines,omitempty"` // Line of the import spec of each dependency
+	Constants    []ConstantInfo  `json:"constants"`
+	Variables    []VariableInfo  `json:"variables"`
+	Lines        int             `json:"lines,omitempty"`
+}
+
+// StructInfo represents information about a Go struct
+type StructInfo struct {
+	Name       string         `json:"name"`
+	Functions  []FunctionInfo `json:"functions"` // Methods
+	Properties []PropertyInfo `json:"properties"`
+	Comment    string         `json:"comment,omitempty"`
+	Doc        *Doc           `json:"doc,omitempty"`
+	ID         string         `json:"id"`
+}
+
+// InterfaceInfo represents information about a Go interface
+type InterfaceInfo struct {
+	Name      string         `json:"name"`
+	Functions []FunctionInfo `json:"functions"`
+	Comment   string         `json:"comment,omitempty"`
+	Doc       *Doc           `json:"doc,omitempty"`
+	ID        string         `json:"id"`
+}
+
+// TypeInfo represents a named type other than a struct or interface, such
+// as "type Celsius float64", or an alias
+type TypeInfo struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"` // Underlying type, or the aliased type
+	Alias     bool           `json:"alias,omitempty"`
+	Functions []FunctionInfo `json:"functions"` // Methods
+	Comment   string         `json:"comment,omitempty"`
+	Doc       *Doc           `json:"doc,omitempty"`
+	ID        string         `json:"id"`
+}
+
+// FunctionInfo represents information about a Go function
+type FunctionInfo struct {
+	Name            string          `json:"name"`
+	Parameters      []ParameterInfo `json:"parameters"`
+	ReturnType      string          `json:"returnType"`
+	Content         string          `json:"content,omitempty"`
+	Comment         string          `json:"comment,omitempty"`
+	Doc             *Doc            `json:"doc,omitempty"`
+	ID              string          `json:"id"`
+	Package         string          `json:"package,omitempty"`
+	FilePath        string          `json:"filePath,omitempty"`
+	PointerReceiver bool            `json:"pointerReceiver,omitempty"` // Method declared on *T
+}
+
+// PropertyInfo represents information about a struct field
+type PropertyInfo struct {
+	Name     string            `json:"name"` // The type for embedded fields
+	Type     string            `json:"type"`
+	Tag      string            `json:"tag,omitempty"`  // Struct tag, unquoted
+	Tags     map[string]string `json:"tags,omitempty"` // Struct tag values by key, e.g. "json": "id,omitempty"
+	Exported bool              `json:"exported"`
+	Embedded bool              `json:"embedded,omitempty"`
+	Comment  string            `json:"comment,omitempty"`
+	Doc      *Doc              `json:"doc,omitempty"`
+}
+
+// ParameterInfo represents information about a function parameter
+type ParameterInfo struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ConstantInfo represents information about a constant
+type ConstantInfo struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+	Doc     *Doc   `json:"doc,omitempty"`
+	ID      string `json:"id"`
+}
+
+// VariableInfo represents information about a variable
+type VariableInfo struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	Doc     *Doc   `json:"doc,omitempty"`
+	ID      string `json:"id"`
+}
+
+// CodeGraph represents the relationships between code entities
+type CodeGraph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node represents a single entity in the code graph
+type Node struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	Package    string   `json:"package,omitempty"`
+	File       string   `json:"file,omitempty"`
+	Receiver   string   `json:"receiver,omitempty"` // Declaring struct or interface of a method
+	Line       int      `json:"line,omitempty"`
+	Column     int      `json:"column,omitempty"`
+	Metrics    *Metrics `json:"metrics,omitempty"`    // Functions and methods only
+	Deprecated bool     `json:"deprecated,omitempty"` // Doc comment has a "Deprecated:" paragraph
+	Doc        *Doc     `json:"doc,omitempty"`        // Packages only; declarations keep theirs in their Info
+}
+
+// Edge represents a relationship between two nodes
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Line     int    `json:"line,omitempty"` // Position of the reference in the file of From
+	Column   int    `json:"column,omitempty"`
+}
+
+// Global variable to store nodes and edges
+var (
+	nodes     = []Node{} // In the order they are produced, keeping output stable
+	edges     = []Edge{}
+	funcMap   = make(map[string]string) // Maps function name to ID
+	typeMap   = make(map[string]string) // Maps typeKey of directory and type name to ID
+	valueMap  = make(map[string]string) // Maps package.name of constants and variables to ID
+	idCounter = 0
+
+	// Root of the source and the module path its go.mod declares, which
+	// locate the directory of an imported package of the module
+	sourceRoot string
+	modulePath string
+
+	// Directory of the file being processed, its imports by local name and
+	// its scope, and the nodes made for functions of imported packages by
+	// import path and name
+	fileDir     string
+	fileImports = make(map[string]string)
+	fileScope   *ast.Scope
+	externalMap = make(map[string]string)
+
+	// Method signatures by struct, named type or interface ID, used to link
+	// implementations
+	structMethodSigs    = make(map[string]map[string]string)
+	interfaceMethodSigs = make(map[string]map[string]string)
+
+	// Call sites and other references collected while walking, resolved
+	// after the last file
+	pendingCalls    []pendingCall
+	pendingRefs     []pendingCall
+	pendingTypeUses []pendingTypeUse
+	pendingDocLinks []pendingDocLink
+
+	// Names declared in each package directory, telling doc comments which
+	// [Name] are links, and package node IDs by package name
+	packageSymbols = make(map[string]map[string]bool)
+	packageMap     = make(map[string]string)
+
+	// Files of the package being processed, searched for methods declared
+	// away from their receiver, and the method declarations already given
+	// a node
+	packageFiles   []packageFile
+	claimedMethods = make(map[*ast.FuncDecl]bool)
+
+	// sink, when set, receives nodes and edges as they are produced instead
+	// of having them accumulated in nodes and edges
+	sink recordSink
+)
+
+// analyzeMu serializes analyses, which share the globals above
+var analyzeMu sync.Mutex
+
+// resetAnalyzer clears the state left behind by a previous analysis
+func resetAnalyzer() {
+	nodes = []Node{}
+	edges = []Edge{}
+	funcMap = make(map[string]string)
+	typeMap = make(map[string]string)
+	valueMap = make(map[string]string)
+	idCounter = 0
+	sourceRoot, modulePath = "", ""
+	fileDir = ""
+	fileImports = make(map[string]string)
+	fileScope = nil
+	externalMap = make(map[string]string)
+	structMethodSigs = make(map[string]map[string]string)
+	interfaceMethodSigs = make(map[string]map[string]string)
+	pendingCalls = nil
+	pendingRefs = nil
+	pendingTypeUses = nil
+	pendingDocLinks = nil
+	packageSymbols = make(map[string]map[string]bool)
+	packageMap = make(map[string]string)
+	packageFiles = nil
+	claimedMethods = make(map[*ast.FuncDecl]bool)
+}
+
+// packageFile is a parsed file of the package being processed
+type packageFile struct {
+	name    string // Slash-separated path in the source
+	path    string // Joined with the root of the source
+	fset    *token.FileSet
+	node    *ast.File
+	imports map[string]string // By local name, as in fileImports
+}
+
+// pendingCall is a call site or reference waiting for resolveCalls;
+// candidates are the funcMap keys to try in order, and external the import
+// path of the package the callee belongs to when none of them is found.
+// References may also name a type, under typeKey when set.
+type pendingCall struct {
+	callerID   string
+	candidates []string
+	external   string
+	typeKey    string
+	pos        token.Position
+}
+
+// pendingTypeUse is a use of a type waiting for resolveCalls, which adds an
+// edge of relation from fromID when typeMap knows key. Types of later files
+// and packages are not registered yet when the use is found.
+type pendingTypeUse struct {
+	fromID   string
+	key      string
+	relation string
+	pos      token.Position
+}
+
+// recordSink receives graph records while the analyzer runs
+type recordSink interface {
+	node(n Node)
+	edge(e Edge)
+	module(path string, m ModuleInfo)
+}
+
+// addNode records a node, forwarding it to the sink when streaming
+func addNode(n Node) {
+	if sink != nil {
+		sink.node(n)
+		return
+	}
+	nodes = append(nodes, n)
+}
+
+// addEdge records an edge, forwarding it to the sink when streaming
+func addEdge(e Edge) {
+	if sink != nil {
+		sink.edge(e)
+		return
+	}
+	edges = append(edges, e)
+}
+
+// Helper function to generate unique IDs
+func generateID(prefix string) string {
+	idCounter++
+	return prefix + strconv.Itoa(idCounter)
+}
+
+func extractFuncType(f *ast.FuncType) ([]ParameterInfo, string) {
+	var params []ParameterInfo
+	var returnType string
+
+	// Extract parameters
+	if f.Params != nil && f.Params.List != nil {
+		for _, p := range f.Params.List {
+			typeName := ""
+			typeName = exprToString(p.Type)
+
+			// Handle multiple names in the same parameter declaration
+			if len(p.Names) > 0 {
+				for _, name := range p.Names {
+					params = append(params, ParameterInfo{
+						Name: name.Name,
+						Type: typeName,
+					})
+				}
+			} else {
+				// For unnamed parameters (like interface methods)
+				params = append(params, ParameterInfo{
+					Name: "",
+					Type: typeName,
+				})
+			}
+		}
+	}
+
+	// Extract return type
+	if f.Results != nil && f.Results.List != nil {
+		var returns []string
+		for _, r := range f.Results.List {
+			typeName := exprToString(r.Type)
+			returns = append(returns, typeName)
+		}
+		if len(returns) == 1 {
+			returnType = returns[0]
+		} else if len(returns) > 1 {
+			returnType = "(" + strings.Join(returns, ", ") + ")"
+		} else {
+			returnType = "void"
+		}
+	} else {
+		returnType = "void"
+	}
+
+	return params, returnType
+}
+
+func exprToString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprToString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprToString(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + exprToString(t.Elt)
+		}
+		return "[" + exprToString(t.Len) + "]" + exprToString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprToString(t.Key) + "]" + exprToString(t.Value)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.FuncType:
+		params, returnType := extractFuncType(t)
+		var paramStrings []string
+		for _, p := range params {
+			if p.Name != "" {
+				paramStrings = append(paramStrings, p.Name+" "+p.Type)
+			} else {
+				paramStrings = append(paramStrings, p.Type)
+			}
+		}
+		return "func(" + strings.Join(paramStrings, ", ") + ") " + returnType
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.StructType:
+		return "struct{...}"
+	case *ast.Ellipsis:
+		return "..." + exprToString(t.Elt)
+	case *ast.ChanType:
+		return "chan " + exprToString(t.Value)
+	case *ast.IndexExpr:
+		return exprToString(t.X) + "[" + exprToString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			indices[i] = exprToString(index)
+		}
+		return exprToString(t.X) + "[" + strings.Join(indices, ", ") + "]"
+	default:
+		return fmt.Sprintf("<%T>", expr)
+	}
+}
+
+// receiverTypeName returns the name of the type a method is declared on,
+// e.g. "List" for "func (l *List[T]) Len() int", or "" for a function.
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+	recv := funcDecl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// hasPointerReceiver reports whether funcDecl is a method declared on a
+// pointer type
+func hasPointerReceiver(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return false
+	}
+	_, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+	return ok
+}
+
+// fileImportMap returns the import paths of file by local name
+func fileImportMap(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		if importPath, err := strconv.Unquote(imp.Path.Value); err == nil {
+			if imp.Name != nil {
+				imports[imp.Name.Name] = importPath
+			} else {
+				imports[importName(importPath)] = importPath
+			}
+		}
+	}
+	return imports
+}
+
+// typeKey returns the typeMap key of the type name declared in the package
+// in dir. Type names alone clash between packages.
+func typeKey(dir, name string) string {
+	return dir + "." + name
+}
+
+// typeKeyOf returns the typeMap key of the type expr names in the file
+// being processed, following pointers: an identifier declared in the
+// file's package, or pkg.Name for an import of a package of the module.
+func typeKeyOf(expr ast.Expr) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return typeKeyOf(t.X)
+	case *ast.Ident:
+		if !declaredLocally(t) {
+			return typeKey(fileDir, t.Name), true
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && !declaredLocally(x) {
+			if dir, ok := importDir(fileImports[x.Name]); ok {
+				return typeKey(dir, t.Sel.Name), true
+			}
+		}
+	}
+	return "", false
+}
+
+// readModulePath returns the module path declared by the go.mod at the
+// root of src, or "" when there is none
+func readModulePath(src Source) string {
+	content, err := fs.ReadFile(src.FS, "go.mod")
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(content)
+}
+
+// importDir returns the directory of the package importPath when it is
+// part of the module being analyzed
+func importDir(importPath string) (string, bool) {
+	if modulePath == "" || importPath == "" {
+		return "", false
+	}
+	if importPath == modulePath {
+		return sourceRoot, true
+	}
+	rel, ok := strings.CutPrefix(importPath, modulePath+"/")
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(sourceRoot, filepath.FromSlash(rel)), true
+}
+
+// detectTypeUse records that fromID uses the type named by expr; the edge
+// of relation is added by resolveCalls once every type is known
+func detectTypeUse(fset *token.FileSet, expr ast.Expr, fromID, relation string) {
+	if key, ok := typeKeyOf(expr); ok {
+		pendingTypeUses = append(pendingTypeUses, pendingTypeUse{
+			fromID:   fromID,
+			key:      key,
+			relation: relation,
+			pos:      fset.Position(expr.Pos()),
+		})
+	}
+}
+
+// extractMethods adds the methods of the struct or named type typeName
+// declared in the package in dir. Methods need not sit in the file of
+// their receiver, so every file of the package is searched; each method
+// gets its node, the has_method edge and the calls and references of its
+// body.
+func extractMethods(dir, typeName, typeID, packageName string) []FunctionInfo {
+	var methods []FunctionInfo
+	savedDir, savedImports, savedScope := fileDir, fileImports, fileScope
+	defer func() { fileDir, fileImports, fileScope = savedDir, savedImports, savedScope }()
+
+	for _, file := range packageFiles {
+		fileDir, fileImports, fileScope = dir, file.imports, file.node.Scope
+		for _, decl := range file.node.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || receiverTypeName(funcDecl) != typeName || claimedMethods[funcDecl] {
+				continue
+			}
+			// A type declared again under other build tags keeps the
+			// methods found for the first declaration
+			claimedMethods[funcDecl] = true
+
+			// Generate a unique ID for this method
+			methodID := generateID("func_")
+			params, returnType := extractFuncType(funcDecl.Type)
+			doc := extractDoc(funcDecl.Doc, methodID, packageName, dir)
+			methods = append(methods, FunctionInfo{
+				Name:            funcDecl.Name.Name,
+				Parameters:      params,
+				ReturnType:      returnType,
+				Comment:         extractComment(funcDecl.Doc),
+				Doc:             doc,
+				ID:              methodID,
+				Package:         packageName,
+				FilePath:        file.path,
+				PointerReceiver: hasPointerReceiver(funcDecl),
+			})
+
+			pos := file.fset.Position(funcDecl.Name.Pos())
+			addNode(Node{
+				ID:         methodID,
+				Type:       "method",
+				Name:       funcDecl.Name.Name,
+				Package:    packageName,
+				File:       filepath.Base(file.path),
+				Receiver:   typeName,
+				Line:       pos.Line,
+				Column:     pos.Column,
+				Metrics:    functionMetrics(file.fset, funcDecl),
+				Deprecated: doc.IsDeprecated(),
+			})
+			if structMethodSigs[typeID] == nil {
+				structMethodSigs[typeID] = make(map[string]string)
+			}
+			structMethodSigs[typeID][funcDecl.Name.Name] = signatureKey(params, returnType)
+
+			// Store method ID
+			funcMap[typeName+"."+funcDecl.Name.Name] = methodID
+
+			addEdge(Edge{
+				From:     typeID,
+				To:       methodID,
+				Relation: "has_method",
+			})
+
+			// Also analyze the method body for function calls
+			detectReferences(file.fset, funcDecl.Type, methodID, packageName)
+			if funcDecl.Body != nil {
+				detectReferences(file.fset, funcDecl.Body, methodID, packageName)
+				ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+					if callExpr, ok := n.(*ast.CallExpr); ok {
+						detectFunctionCall(file.fset, callExpr, methodID, packageName)
+					}
+					return true
+				})
+			}
+		}
+	}
+
+	return methods
+}
+
+// processGoFile analyzes a single parsed Go file and extracts its
+// structure; the methods of its types are added by processPackage
+func processGoFile(file packageFile, projectName, packageName string) ModuleInfo {
+	fileSet, node, filePath := file.fset, file.node, file.path
+
+	moduleInfo := ModuleInfo{
+		Package:      packageName,
+		Structs:      []StructInfo{},
+		Functions:    []FunctionInfo{},
+		Interfaces:   []InterfaceInfo{},
+		Types:        []TypeInfo{},
+		Dependencies: []string{},
+		Constants:    []ConstantInfo{},
+		Variables:    []VariableInfo{},
+		Lines:        fileSet.File(node.Pos()).LineCount(),
+	}
+
+	dir := filepath.Dir(filePath)
+
+	// Extract imports
+	for _, imp := range node.Imports {
+		var name string
+		if imp.Name != nil {
+			name = imp.Name.Name + " "
+		}
+		moduleInfo.Dependencies = append(moduleInfo.Dependencies, "import "+name+imp.Path.Value)
+		moduleInfo.ImportLines = append(moduleInfo.ImportLines, fileSet.Position(imp.Pos()).Line)
+	}
+	fileDir, fileImports, fileScope = dir, fileImportMap(node), node.Scope
+
+	// Process declarations
+	for _, decl := range node.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			// Skip methods (they'll be handled with structs)
+			if d.Recv == nil {
+				// Regular function, not a method
+				funcID := generateID("func_")
+				params, returnType := extractFuncType(d.Type)
+				doc := extractDoc(d.Doc, funcID, packageName, dir)
+				funcInfo := FunctionInfo{
+					Name:       d.Name.Name,
+					Parameters: params,
+					ReturnType: returnType,
+					Comment:    extractComment(d.Doc),
+					Doc:        doc,
+					ID:         funcID,
+					Package:    packageName,
+					FilePath:   filePath,
+				}
+				moduleInfo.Functions = append(moduleInfo.Functions, funcInfo)
+
+				// Register the function ID
+				fullFuncName := packageName + "." + d.Name.Name
+				funcMap[fullFuncName] = funcID
+				funcMap[d.Name.Name] = funcID // Also register just the name for local references
+
+				// Add to nodes
+				pos := fileSet.Position(d.Name.Pos())
+				addNode(Node{
+					ID:         funcID,
+					Type:       "function",
+					Name:       d.Name.Name,
+					Package:    packageName,
+					File:       filepath.Base(filePath),
+					Line:       pos.Line,
+					Column:     pos.Column,
+					Metrics:    functionMetrics(fileSet, d),
+					Deprecated: doc.IsDeprecated(),
+				})
+
+				// Analyze function body for calls to other functions
+				detectReferences(fileSet, d.Type, funcID, packageName)
+				if d.Body != nil {
+					detectReferences(fileSet, d.Body, funcID, packageName)
+					ast.Inspect(d.Body, func(n ast.Node) bool {
+						if callExpr, ok := n.(*ast.CallExpr); ok {
+							detectFunctionCall(fileSet, callExpr, funcID, packageName)
+						}
+						// Look for type usage in declarations
+						if declStmt, ok := n.(*ast.DeclStmt); ok {
+							if genDecl, ok := declStmt.Decl.(*ast.GenDecl); ok {
+								processGenDeclForTypeUsage(fileSet, genDecl, funcID)
+							}
+						}
+						// Look for type usage in assignments
+						if assignStmt, ok := n.(*ast.AssignStmt); ok {
+							for _, rhs := range assignStmt.Rhs {
+								if compLit, ok := rhs.(*ast.CompositeLit); ok && compLit.Type != nil {
+									detectTypeUse(fileSet, compLit.Type, funcID, "uses")
+								}
+							}
+						}
+						return true
+					})
+				}
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					// Handle struct types
+					if structType, ok := s.Type.(*ast.StructType); ok {
+						structID := generateID("struct_")
+						doc := extractDoc(specDoc(d, s.Doc), structID, packageName, dir)
+						structInfo := StructInfo{
+							Name:       s.Name.Name,
+							Properties: []PropertyInfo{},
+							Comment:    extractComment(specDoc(d, s.Doc)),
+							Doc:        doc,
+							ID:         structID,
+						}
+
+						// Register struct ID
+						typeMap[typeKey(dir, s.Name.Name)] = structID
+
+						// Add to nodes
+						pos := fileSet.Position(s.Name.Pos())
+						addNode(Node{
+							ID:         structID,
+							Type:       "struct",
+							Name:       s.Name.Name,
+							Package:    packageName,
+							File:       filepath.Base(filePath),
+							Line:       pos.Line,
+							Column:     pos.Column,
+							Deprecated: doc.IsDeprecated(),
+						})
+						detectReferences(fileSet, structType, structID, packageName)
+
+						// Extract struct fields
+						if structType.Fields != nil {
+							for _, field := range structType.Fields.List {
+								if len(field.Names) > 0 {
+									for _, name := range field.Names {
+										typeName := exprToString(field.Type)
+										tag := fieldTag(field)
+										structInfo.Properties = append(structInfo.Properties, PropertyInfo{
+											Name:     name.Name,
+											Type:     typeName,
+											Tag:      tag,
+											Tags:     parseStructTag(tag),
+											Exported: name.IsExported(),
+											Comment:  extractComment(field.Doc),
+											Doc:      extractDoc(field.Doc, structID, packageName, dir),
+										})
+
+										// Check if field type references another struct/type
+										detectTypeUse(fileSet, field.Type, structID, "has_field_of_type")
+									}
+								} else {
+									// Embedded field
+									fieldType := exprToString(field.Type)
+									tag := fieldTag(field)
+									structInfo.Properties = append(structInfo.Properties, PropertyInfo{
+										Name:     fieldType, // The name is the type for embedded fields
+										Type:     fieldType,
+										Tag:      tag,
+										Tags:     parseStructTag(tag),
+										Exported: isExported(embeddedName(fieldType)),
+										Embedded: true,
+										Comment:  extractComment(field.Doc),
+										Doc:      extractDoc(field.Doc, structID, packageName, dir),
+									})
+
+									// Add relationship for embedded struct
+									detectTypeUse(fileSet, field.Type, structID, "embeds")
+								}
+							}
+						}
+
+						// Find methods for this struct (will be populated later)
+						moduleInfo.Structs = append(moduleInfo.Structs, structInfo)
+					}
+
+					// Handle interfaces
+					if interfaceType, ok := s.Type.(*ast.InterfaceType); ok {
+						interfaceID := generateID("interface_")
+						doc := extractDoc(specDoc(d, s.Doc), interfaceID, packageName, dir)
+						interfaceInfo := InterfaceInfo{
+							Name:      s.Name.Name,
+							Functions: []FunctionInfo{},
+							Comment:   extractComment(specDoc(d, s.Doc)),
+							Doc:       doc,
+							ID:        interfaceID,
+						}
+
+						// Register interface ID
+						typeMap[typeKey(dir, s.Name.Name)] = interfaceID
+
+						// Add to nodes
+						pos := fileSet.Position(s.Name.Pos())
+						addNode(Node{
+							ID:         interfaceID,
+							Type:       "interface",
+							Name:       s.Name.Name,
+							Package:    packageName,
+							File:       filepath.Base(filePath),
+							Line:       pos.Line,
+							Column:     pos.Column,
+							Deprecated: doc.IsDeprecated(),
+						})
+						detectReferences(fileSet, interfaceType, interfaceID, packageName)
+						methodSigs := make(map[string]string)
+
+						// Extract interface methods
+						if interfaceType.Methods != nil {
+							for _, method := range interfaceType.Methods.List {
+								if len(method.Names) == 0 {
+									// Embedded interface; its methods are not known here
+									methodSigs = nil
+								} else {
+									if methodType, ok := method.Type.(*ast.FuncType); ok {
+										params, returnType := extractFuncType(methodType)
+										methodID := generateID("method_")
+										doc := extractDoc(method.Doc, methodID, packageName, dir)
+										for _, name := range method.Names {
+											methodInfo := FunctionInfo{
+												Name:       name.Name,
+												Parameters: params,
+												ReturnType: returnType,
+												Comment:    extractComment(method.Doc),
+												Doc:        doc,
+												ID:         methodID,
+											}
+											interfaceInfo.Functions = append(interfaceInfo.Functions, methodInfo)
+											if methodSigs != nil {
+												methodSigs[name.Name] = signatureKey(params, returnType)
+											}
+
+											// Add method to nodes
+											pos := fileSet.Position(name.Pos())
+											addNode(Node{
+												ID:         methodID,
+												Type:       "interface_method",
+												Name:       name.Name,
+												Package:    packageName,
+												File:       filepath.Base(filePath),
+												Receiver:   s.Name.Name,
+												Line:       pos.Line,
+												Column:     pos.Column,
+												Deprecated: doc.IsDeprecated(),
+											})
+
+											// Add relationship between interface and method
+											addEdge(Edge{
+												From:     interfaceID,
+												To:       methodID,
+												Relation: "declares",
+											})
+										}
+									}
+								}
+							}
+						}
+
+						if len(methodSigs) > 0 {
+							interfaceMethodSigs[interfaceID] = methodSigs
+						}
+						moduleInfo.Interfaces = append(moduleInfo.Interfaces, interfaceInfo)
+					}
+
+					// Handle other named types and aliases
+					switch s.Type.(type) {
+					case *ast.StructType, *ast.InterfaceType:
+					default:
+						typeID := generateID("type_")
+						doc := extractDoc(specDoc(d, s.Doc), typeID, packageName, dir)
+						typeInfo := TypeInfo{
+							Name:    s.Name.Name,
+							Type:    exprToString(s.Type),
+							Alias:   s.Assign.IsValid(),
+							Comment: extractComment(specDoc(d, s.Doc)),
+							Doc:     doc,
+							ID:      typeID,
+						}
+
+						// Register type ID
+						typeMap[typeKey(dir, s.Name.Name)] = typeID
+
+						// Add to nodes
+						pos := fileSet.Position(s.Name.Pos())
+						addNode(Node{
+							ID:         typeID,
+							Type:       "type",
+							Name:       s.Name.Name,
+							Package:    packageName,
+							File:       filepath.Base(filePath),
+							Line:       pos.Line,
+							Column:     pos.Column,
+							Deprecated: doc.IsDeprecated(),
+						})
+						detectReferences(fileSet, s.Type, typeID, packageName)
+						moduleInfo.Types = append(moduleInfo.Types, typeInfo)
+					}
+
+				case *ast.ValueSpec:
+					// Handle constants and variables
+					if d.Tok == token.CONST {
+						for i, name := range s.Names {
+							constID := generateID("const_")
+							doc := extractDoc(specDoc(d, s.Doc), constID, packageName, dir)
+							constInfo := ConstantInfo{
+								Name:    name.Name,
+								Type:    "",
+								Comment: extractComment(specDoc(d, s.Doc)),
+								Doc:     doc,
+								ID:      constID,
+							}
+
+							// Add to nodes
+							pos := fileSet.Position(name.Pos())
+							addNode(Node{
+								ID:         constID,
+								Type:       "constant",
+								Name:       name.Name,
+								Package:    packageName,
+								File:       filepath.Base(filePath),
+								Line:       pos.Line,
+								Column:     pos.Column,
+								Deprecated: doc.IsDeprecated(),
+							})
+
+							if s.Type != nil {
+								constInfo.Type = exprToString(s.Type)
+								detectReferences(fileSet, s.Type, constID, packageName)
+
+								// Check if constant type references another type
+								detectTypeUse(fileSet, s.Type, constID, "has_type")
+							}
+
+							if i < len(s.Values) {
+								constInfo.Value = exprToString(s.Values[i])
+								detectReferences(fileSet, s.Values[i], constID, packageName)
+							}
+							valueMap[packageName+"."+name.Name] = constID
+
+							moduleInfo.Constants = append(moduleInfo.Constants, constInfo)
+						}
+					} else if d.Tok == token.VAR {
+						for i, name := range s.Names {
+							varID := generateID("var_")
+							doc := extractDoc(specDoc(d, s.Doc), varID, packageName, dir)
+							varInfo := VariableInfo{
+								Name:    name.Name,
+								Type:    "",
+								Comment: extractComment(specDoc(d, s.Doc)),
+								Doc:     doc,
+								ID:      varID,
+							}
+
+							// Add to nodes
+							pos := fileSet.Position(name.Pos())
+							addNode(Node{
+								ID:         varID,
+								Type:       "variable",
+								Name:       name.Name,
+								Package:    packageName,
+								File:       filepath.Base(filePath),
+								Line:       pos.Line,
+								Column:     pos.Column,
+								Deprecated: doc.IsDeprecated(),
+							})
+
+							if s.Type != nil {
+								varInfo.Type = exprToString(s.Type)
+								detectReferences(fileSet, s.Type, varID, packageName)
+
+								// Check if variable type references another type
+								detectTypeUse(fileSet, s.Type, varID, "has_type")
+							}
+
+							if i < len(s.Values) {
+								varInfo.Value = exprToString(s.Values[i])
+								detectReferences(fileSet, s.Values[i], varID, packageName)
+								ast.Inspect(s.Values[i], func(n ast.Node) bool {
+									if callExpr, ok := n.(*ast.CallExpr); ok {
+										detectFunctionCall(fileSet, callExpr, varID, packageName)
+									}
+									return true
+								})
+							} else if len(s.Values) == 1 {
+								// var a, b = f()
+								detectReferences(fileSet, s.Values[0], varID, packageName)
+							}
+							valueMap[packageName+"."+name.Name] = varID
+
+							moduleInfo.Variables = append(moduleInfo.Variables, varInfo)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return moduleInfo
+}
+
+// detectFunctionCall records a function call expression; the "calls" edge
+// is added by resolveCalls once every function in the project is known
+func detectFunctionCall(fset *token.FileSet, callExpr *ast.CallExpr, callerID string, packageName string) {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		if declaredLocally(fun) {
+			return // A function value held by a parameter or local variable
+		}
+		// Local function call, preferring the caller's own package
+		pendingCalls = append(pendingCalls, pendingCall{
+			callerID:   callerID,
+			candidates: []string{packageName + "." + fun.Name, fun.Name},
+			pos:        fset.Position(fun.Pos()),
+		})
+	case *ast.SelectorExpr:
+		// Could be a package.Function call or object.Method call
+		if x, ok := fun.X.(*ast.Ident); ok && !declaredLocally(x) {
+			// Try as package.Function
+			pendingCalls = append(pendingCalls, pendingCall{
+				callerID:   callerID,
+				candidates: []string{x.Name + "." + fun.Sel.Name},
+				external:   fileImports[x.Name],
+				pos:        fset.Position(fun.Sel.Pos()),
+			})
+
+			// Or it could be a method call on a struct instance
+			// This is more complex and would require type checking
+		}
+	}
+}
+
+// detectReferences records the constants, variables, functions and types
+// named in root other than as the callee of a call, which
+// detectFunctionCall covers; the "references" edges are added by
+// resolveCalls
+func detectReferences(fset *token.FileSet, root ast.Node, fromID string, packageName string) {
+	callees := make(map[ast.Expr]bool)
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			callees[n.Fun] = true
+		case *ast.SelectorExpr:
+			// x.Sel is either a member of package x or a field or method of
+			// the value x; the latter is not resolved, but x itself is
+			if x, ok := n.X.(*ast.Ident); ok && !callees[n] && !declaredLocally(x) {
+				key, _ := typeKeyOf(n)
+				pendingRefs = append(pendingRefs, pendingCall{
+					callerID:   fromID,
+					candidates: []string{x.Name + "." + n.Sel.Name},
+					typeKey:    key,
+					pos:        fset.Position(n.Sel.Pos()),
+				})
+			}
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.Ident:
+			if !callees[n] && n.Name != "_" && !declaredLocally(n) {
+				pendingRefs = append(pendingRefs, pendingCall{
+					callerID:   fromID,
+					candidates: []string{packageName + "." + n.Name},
+					typeKey:    typeKey(fileDir, n.Name),
+					pos:        fset.Position(n.Pos()),
+				})
+			}
+		}
+		return true
+	}
+	ast.Inspect(root, visit)
+}
+
+// declaredLocally reports whether ident names a parameter, result,
+// receiver, type parameter or local of the function it is used in, which
+// shadows any package-level declaration of the same name. It relies on the
+// parser's resolution within the file: names declared in other files of
+// the package are left unresolved.
+func declaredLocally(ident *ast.Ident) bool {
+	return ident.Obj != nil && fileScope != nil && fileScope.Lookup(ident.Name) != ident.Obj
+}
+
+// resolveCalls turns the calls recorded by detectFunctionCall, the
+// references recorded by detectReferences and the type uses recorded by
+// detectTypeUse into edges
+func resolveCalls() {
+	for _, call := range pendingCalls {
+		calleeID := ""
+		for _, name := range call.candidates {
+			if id, exists := funcMap[name]; exists {
+				calleeID = id
+				break
+			}
+		}
+		if calleeID == "" && call.external != "" {
+			_, name, _ := strings.Cut(call.candidates[0], ".")
+			calleeID = externalFunction(call.external, name)
+		}
+		if calleeID != "" {
+			addEdge(Edge{
+				From:     call.callerID,
+				To:       calleeID,
+				Relation: "calls",
+				Line:     call.pos.Line,
+				Column:   call.pos.Column,
+			})
+		}
+	}
+	pendingCalls = nil
+
+	for _, ref := range pendingRefs {
+		name := ref.candidates[0]
+		targetID, exists := valueMap[name]
+		if !exists {
+			targetID, exists = funcMap[name]
+		}
+		if !exists && ref.typeKey != "" {
+			targetID, exists = typeMap[ref.typeKey]
+		}
+		if exists {
+			addEdge(Edge{
+				From:     ref.callerID,
+				To:       targetID,
+				Relation: "references",
+				Line:     ref.pos.Line,
+				Column:   ref.pos.Column,
+			})
+		}
+	}
+	pendingRefs = nil
+
+	for _, use := range pendingTypeUses {
+		if typeID, exists := typeMap[use.key]; exists {
+			addEdge(Edge{
+				From:     use.fromID,
+				To:       typeID,
+				Relation: use.relation,
+				Line:     use.pos.Line,
+				Column:   use.pos.Column,
+			})
+		}
+	}
+	pendingTypeUses = nil
+}
+
+// externalFunction returns the ID of the node standing for function name of
+// the imported package importPath, adding the node on first use
+func externalFunction(importPath, name string) string {
+	key := importPath + "." + name
+	if id, exists := externalMap[key]; exists {
+		return id
+	}
+	id := generateID("ext_")
+	externalMap[key] = id
+	addNode(Node{
+		ID:      id,
+		Type:    "external_function",
+		Name:    name,
+		Package: importPath,
+	})
+	return id
+}
+
+// importName guesses the name a package is imported under from its path:
+// the last element, skipping a major version suffix such as /v2 or .v3
+func importName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.LastIndex(name, "."); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
+
+// processGenDeclForTypeUsage checks for type usage in declarations
+func processGenDeclForTypeUsage(fset *token.FileSet, genDecl *ast.GenDecl, funcID string) {
+	for _, spec := range genDecl.Specs {
+		if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+			if valueSpec.Type != nil {
+				detectTypeUse(fset, valueSpec.Type, funcID, "uses")
+			}
+		}
+	}
+}
+
+// signatureKey renders parameter and return types into a string that two
+// methods share exactly when their signatures match
+func signatureKey(params []ParameterInfo, returnType string) string {
+	types := make([]string, len(params))
+	for i, p := range params {
+		types[i] = p.Type
+	}
+	return "(" + strings.Join(types, ", ") + ") " + returnType
+}
+
+// linkImplementations adds an "implements" edge from every struct to every
+// interface whose methods it declares with matching signatures
+func linkImplementations() {
+	structIDs := make([]string, 0, len(structMethodSigs))
+	for id := range structMethodSigs {
+		structIDs = append(structIDs, id)
+	}
+	sort.Strings(structIDs)
+	interfaceIDs := make([]string, 0, len(interfaceMethodSigs))
+	for id := range interfaceMethodSigs {
+		interfaceIDs = append(interfaceIDs, id)
+	}
+	sort.Strings(interfaceIDs)
+
+	for _, structID := range structIDs {
+		methods := structMethodSigs[structID]
+		for _, interfaceID := range interfaceIDs {
+			implements := true
+			for name, sig := range interfaceMethodSigs[interfaceID] {
+				if methods[name] != sig {
+					implements = false
+					break
+				}
+			}
+			if implements {
+				addEdge(Edge{
+					From:     structID,
+					To:       interfaceID,
+					Relation: "implements",
+				})
+			}
+		}
+	}
+}
+
+func processGoProject(src Source, projectName string) (ProjectStructure, error) {
+	analyzeMu.Lock()
+	defer analyzeMu.Unlock()
+	resetAnalyzer()
+
+	result := ProjectStructure{
+		SchemaVersion: SchemaVersion,
+		Project: map[string]PackageInfo{
+			projectName: {
+				Module:  readModulePath(src),
+				Modules: make(map[string]ModuleInfo),
+			},
+		},
+		CodeGraph: CodeGraph{
+			Nodes: []Node{},
+			Edges: []Edge{},
+		},
+	}
+
+	err := walkGoProject(src, projectName, func(relPath string, moduleInfo ModuleInfo) {
+		result.Project[projectName].Modules[relPath] = moduleInfo
+	})
+
+	result.CodeGraph.Nodes = nodes
+	result.CodeGraph.Edges = edges
+
+	return result, err
+}
+
+// streamGoProject analyzes the project like processGoProject, but hands every
+// module, node and edge to s as soon as it is produced instead of building a
+// ProjectStructure in memory
+func streamGoProject(src Source, projectName string, s recordSink) error {
+	analyzeMu.Lock()
+	defer analyzeMu.Unlock()
+	resetAnalyzer()
+
+	sink = s
+	defer func() { sink = nil }()
+	return walkGoProject(src, projectName, s.module)
+}
+
+// walkGoProject parses every Go file in src and calls visit with the module
+// info of each file, keyed by its path relative to the project root.
+// Packages are processed one at a time, so that only the syntax trees of a
+// single package are held in memory.
+func walkGoProject(src Source, projectName string, visit func(relPath string, moduleInfo ModuleInfo)) error {
+	// isSource reports whether a file is analyzed, given its path under
+	// src.Root
+	isSource := func(path string, d fs.DirEntry) bool {
+		return !d.IsDir() && strings.HasSuffix(path, ".go") &&
+			!strings.Contains(path, "/vendor/") &&
+			!strings.HasSuffix(path, "_test.go")
+	}
+
+	sourceRoot, modulePath = filepath.Clean(src.Root), readModulePath(src)
+
+	// Group the files by package directory
+	packageNames := make(map[string][]string) // Names in src.FS of the files in each directory
+	err := fs.WalkDir(src.FS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(src.Root, filepath.FromSlash(name))
+		if isSource(path, d) {
+			dir := filepath.Dir(path)
+			packageNames[dir] = append(packageNames[dir], name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dirs := make([]string, 0, len(packageNames))
+	for dir := range packageNames {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if err := processPackage(src, dir, packageNames[dir], projectName, visit); err != nil {
+			return err
+		}
+	}
+
+	// Last pass: resolve calls now that every function is registered, and
+	// relate structs to the interfaces they satisfy
+	resolveCalls()
+	resolveDocLinks()
+	linkImplementations()
+	return nil
+}
+
+// processPackage analyzes the files of the package in dir, given by their
+// names in src.FS, and calls visit with the module info of each. Methods
+// may be declared in any file of the package, so the parsed files are kept
+// until the whole package is done.
+func processPackage(src Source, dir string, names []string, projectName string, visit func(relPath string, moduleInfo ModuleInfo)) error {
+	defer func() {
+		packageFiles = nil
+		claimedMethods = make(map[*ast.FuncDecl]bool)
+	}()
+
+	// Parse the files, learning the package name, package doc and
+	// declared names
+	var decl *packageDecl
+	symbols := make(map[string]bool)
+	packageSymbols[dir] = symbols
+	for _, name := range names {
+		path := filepath.Join(src.Root, filepath.FromSlash(name))
+		content, err := fs.ReadFile(src.FS, name)
+		if err != nil {
+			return err
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error processing %s: %v\n", path, err)
+			continue // Continue with other files
+		}
+		file := packageFile{name: name, path: path, fset: fset, node: f, imports: fileImportMap(f)}
+		packageFiles = append(packageFiles, file)
+		collectSymbols(f, symbols)
+		if decl == nil || decl.doc == nil && f.Doc != nil {
+			decl = &packageDecl{name: f.Name.Name, file: filepath.Base(path), pos: fset.Position(f.Name.Pos()), doc: f.Doc,
+				imports: file.imports}
+		}
+	}
+	if decl == nil {
+		return nil
+	}
+
+	// Package node, carrying the package doc comment
+	pkgID := generateID("pkg_")
+	if _, exists := packageMap[decl.name]; !exists {
+		packageMap[decl.name] = pkgID
+	}
+	fileDir, fileImports = dir, decl.imports
+	doc := extractDoc(decl.doc, pkgID, decl.name, dir)
+	addNode(Node{
+		ID:         pkgID,
+		Type:       "package",
+		Name:       decl.name,
+		Package:    decl.name,
+		File:       decl.file,
+		Line:       decl.pos.Line,
+		Column:     decl.pos.Column,
+		Deprecated: doc.IsDeprecated(),
+		Doc:        doc,
+	})
+
+	modules := make([]ModuleInfo, len(packageFiles))
+	for i, file := range packageFiles {
+		modules[i] = processGoFile(file, projectName, decl.name)
+	}
+
+	// Populate struct and named type methods now that every file is parsed
+	for _, m := range modules {
+		for i, structInfo := range m.Structs {
+			m.Structs[i].Functions = extractMethods(dir, structInfo.Name, structInfo.ID, decl.name)
+		}
+		for i, typeInfo := range m.Types {
+			m.Types[i].Functions = extractMethods(dir, typeInfo.Name, typeInfo.ID, decl.name)
+		}
+	}
+
+	for i, file := range packageFiles {
+		visit(filepath.FromSlash(file.name), modules[i])
+	}
+	return nil
+}
+
+func main() {
+	// Default values
+	projectPath := "."
+	projectName := "MyProject"
+	outputFile := "output.json"
+
+	// Parse command-line arguments
+	switch len(os.Args) {
+	case 4:
+		outputFile = os.Args[3]
+		fallthrough
+	case 3:
+		projectName = os.Args[2]
+		fallthrough
+	case 2:
+		projectPath = os.Args[1]
+	case 1:
+		// Use defaults
+	default:
+		fmt.Println("Usage: go run go-codegraph.go [project-path] [project-name] [output-file]")
+		os.Exit(1)
+	}
+
+	// Convert to absolute path
+	absProjectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		fmt.Printf("Error converting to absolute path: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := processGoProject(DirSource(absProjectPath), projectName)
+	if err != nil {
+		fmt.Printf("Error processing project: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Generate JSON
+	jsonOutput, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling to JSON: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Write to file
+	err = os.WriteFile(outputFile, jsonOutput, 0644)
+	if err != nil {
+		fmt.Printf("Error writing to output file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Analysis complete. Results written to %s\n", outputFile)
+}
@@ -0,0 +1,106 @@
+// graph/graph.go
+package graph
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Analyze runs the codegraph analysis on the Go project at projectPath.
+func Analyze(projectPath, projectName string) (ProjectStructure, error) {
+	return processGoProject(DirSource(projectPath), projectName)
+}
+
+// AnalyzeFS runs the codegraph analysis on the Go files in fsys, with the
+// project root at ".".
+func AnalyzeFS(fsys fs.FS, projectName string) (ProjectStructure, error) {
+	return processGoProject(Source{FS: fsys, Root: "."}, projectName)
+}
+
+// AnalyzeSource runs the codegraph analysis on the Go files in src.
+func AnalyzeSource(src Source, projectName string) (ProjectStructure, error) {
+	return processGoProject(src, projectName)
+}
+
+// ProcessProject runs the codegraph analysis and writes out the result in
+// each of the comma-separated formats (see Formats). With a single format
+// the result goes to outputFile, or stdout for "-"; with several, each is
+// written next to outputFile using the format's extension.
+func ProcessProject(projectPath, projectName, outputFile, format string) error {
+	return ProcessSource(DirSource(projectPath), projectName, outputFile, format)
+}
+
+// ProcessSource is like ProcessProject but analyzes the files in src.
+func ProcessSource(src Source, projectName, outputFile, format string) error {
+	selected, err := parseFormats(format)
+	if err != nil {
+		return err
+	}
+	opts := EncodeOptions{ProjectPath: src.Root, ProjectName: projectName, FS: src.FS}
+
+	if len(selected) == 1 {
+		if stream, ok := selected[0].Encoder.(StreamEncoder); ok {
+			// Streamed straight from the analyzer, never built in memory
+			return WriteOutput(outputFile, func(w io.Writer) error {
+				return stream.EncodeStream(w, opts)
+			})
+		}
+	} else if outputFile == "-" {
+		return fmt.Errorf("cannot write %d formats to stdout", len(selected))
+	}
+
+	result, err := processGoProject(src, projectName)
+	if err != nil {
+		return err
+	}
+	return writeFormats(result, outputFile, selected, opts)
+}
+
+// WriteFormats writes out an analyzed or loaded result in each of the
+// comma-separated formats, to outputFile or next to it as ProcessProject
+// does, so that a saved graph can be converted to other formats.
+func WriteFormats(result ProjectStructure, outputFile, format string, opts EncodeOptions) error {
+	selected, err := parseFormats(format)
+	if err != nil {
+		return err
+	}
+	if len(selected) > 1 && outputFile == "-" {
+		return fmt.Errorf("cannot write %d formats to stdout", len(selected))
+	}
+	return writeFormats(result, outputFile, selected, opts)
+}
+
+func writeFormats(result ProjectStructure, outputFile string, selected []Format, opts EncodeOptions) error {
+	for _, f := range selected {
+		target := outputFile
+		if len(selected) > 1 {
+			target = strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + f.Extension
+		}
+		if err := WriteOutput(target, func(w io.Writer) error {
+			return f.Encoder.Encode(w, result, opts)
+		}); err != nil {
+			return fmt.Errorf("writing %s output: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// WriteOutput opens outputFile, or stdout for "-", and hands it to write.
+func WriteOutput(outputFile string, write func(w io.Writer) error) error {
+	if outputFile == "-" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"io"
+)
+
+// The viewer is vendored into the binary so the generated page works offline.
+var (
+	//go:embed assets/viewer.html
+	viewerHTML string
+	//go:embed assets/viewer.js
+	viewerJS string
+	//go:embed assets/viewer.css
+	viewerCSS string
+
+	viewerTemplate = template.Must(template.New("viewer").Parse(viewerHTML))
+)
+
+func init() {
+	RegisterFormat(Format{Name: "html", Extension: ".html", Encoder: EncoderFunc(writeHTML)})
+}
+
+// GenerateHTML analyzes the project and writes a self-contained HTML page
+// with an interactive graph viewer to outputFile ("-" for stdout).
+func GenerateHTML(projectPath, projectName, outputFile string) error {
+	return ProcessProject(projectPath, projectName, outputFile, "html")
+}
+
+func writeHTML(w io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	// json.Marshal escapes <, > and &, so the data cannot close the script tag
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return viewerTemplate.Execute(w, struct {
+		Title  string
+		Style  template.CSS
+		Script template.JS
+		Data   template.JS
+	}{
+		Title:  opts.ProjectName,
+		Style:  template.CSS(viewerCSS),
+		Script: template.JS(viewerJS),
+		Data:   template.JS(data),
+	})
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// projectImport is an import of a file of the project.
+type projectImport struct {
+	File string // Importing file, relative to the project root
+	From string // Directory of the importing file
+	Path string // Import path
+	Line int    // Line of the import spec, 0 in graphs predating it
+	To   string // Directory of the imported package when it is part of the project
+}
+
+// Location returns "file:line" of the import, or the file alone when the
+// line is not known.
+func (imp projectImport) Location() string {
+	if imp.Line == 0 {
+		return imp.File
+	}
+	return imp.File + ":" + strconv.Itoa(imp.Line)
+}
+
+// projectImports lists the imports of every file, ordered by file. An
+// import path belongs to the project when it is the module path from
+// go.mod or lies below it. Graphs without a module path, of projects
+// without go.mod or saved before it was recorded, fall back to import
+// paths ending in one of the project's directories; the longest matching
+// directory wins, so that "a/b" is not taken for "b".
+func projectImports(result ProjectStructure) (imports []projectImport, dirs []string) {
+	seen := make(map[string]bool)
+	var files []string
+	modules := make(map[string]ModuleInfo)
+	module := ""
+	for _, pkg := range result.Project {
+		if pkg.Module != "" {
+			module = pkg.Module
+		}
+		for file, m := range pkg.Modules {
+			file = filepath.ToSlash(file)
+			if dir := path.Dir(file); !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+			files = append(files, file)
+			modules[file] = m
+		}
+	}
+	sort.Strings(files)
+	sort.Strings(dirs)
+
+	for _, file := range files {
+		for i, dep := range modules[file].Dependencies {
+			fields := strings.Fields(dep)
+			if len(fields) == 0 {
+				continue
+			}
+			importPath, err := strconv.Unquote(fields[len(fields)-1])
+			if err != nil {
+				continue
+			}
+			imp := projectImport{File: file, From: path.Dir(file), Path: importPath}
+			if i < len(modules[file].ImportLines) {
+				imp.Line = modules[file].ImportLines[i]
+			}
+			if module != "" {
+				dir, ok := ".", importPath == module
+				if !ok {
+					dir, ok = strings.CutPrefix(importPath, module+"/")
+				}
+				if ok && seen[dir] {
+					imp.To = dir
+				}
+			} else {
+				for _, dir := range dirs {
+					if dir != "." && (importPath == dir || strings.HasSuffix(importPath, "/"+dir)) && len(dir) > len(imp.To) {
+						imp.To = dir
+					}
+				}
+			}
+			imports = append(imports, imp)
+		}
+	}
+	return imports, dirs
+}
@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectImports(t *testing.T) {
+	modules := map[string]ModuleInfo{
+		"main.go":       {Dependencies: []string{`import "example.com/m/graph"`, `import "github.com/other/graph"`, `import "fmt"`}},
+		"graph/a.go":    {Dependencies: []string{`import "example.com/m"`, `import cli "example.com/m/cmd"`}},
+		"cmd/root.go":   {Dependencies: []string{`import "github.com/spf13/cobra"`}},
+		"missing.go  ":  {Dependencies: []string{`import "example.com/m/missing"`}},
+		"graph/b/b.go":  {Dependencies: []string{`import "example.com/m/graph"`}},
+		"other/main.go": {Dependencies: []string{`import "example.com/mod/graph"`}},
+	}
+	tests := []struct {
+		name   string
+		module string
+		want   map[string]string // Imported directory by file and import path
+	}{
+		{
+			name:   "module path",
+			module: "example.com/m",
+			want: map[string]string{
+				"main.go example.com/m/graph":         "graph",
+				"main.go github.com/other/graph":      "",
+				"main.go fmt":                         "",
+				"graph/a.go example.com/m":            ".",
+				"graph/a.go example.com/m/cmd":        "cmd",
+				"cmd/root.go github.com/spf13/cobra":  "",
+				"missing.go   example.com/m/missing":  "",
+				"graph/b/b.go example.com/m/graph":    "graph",
+				"other/main.go example.com/mod/graph": "",
+			},
+		},
+		{
+			name: "no module path",
+			want: map[string]string{
+				"main.go example.com/m/graph":         "graph",
+				"main.go github.com/other/graph":      "graph",
+				"main.go fmt":                         "",
+				"graph/a.go example.com/m":            "",
+				"graph/a.go example.com/m/cmd":        "cmd",
+				"cmd/root.go github.com/spf13/cobra":  "",
+				"missing.go   example.com/m/missing":  "",
+				"graph/b/b.go example.com/m/graph":    "graph",
+				"other/main.go example.com/mod/graph": "graph",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ProjectStructure{Project: map[string]PackageInfo{"test": {Module: tt.module, Modules: modules}}}
+			imports, dirs := projectImports(result)
+			got := make(map[string]string)
+			for _, imp := range imports {
+				got[imp.File+" "+imp.Path] = imp.To
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("imports = %v, want %v", got, tt.want)
+			}
+			if want := []string{".", "cmd", "graph", "graph/b", "other"}; !reflect.DeepEqual(dirs, want) {
+				t.Errorf("dirs = %v, want %v", dirs, want)
+			}
+		})
+	}
+}
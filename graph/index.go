@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Index provides fast lookups over a CodeGraph: nodes by ID and edges by
+// either endpoint.
+type Index struct {
+	Nodes map[string]Node
+	Out   map[string][]Edge // Edges keyed by From
+	In    map[string][]Edge // Edges keyed by To
+}
+
+// NewIndex builds an Index over g.
+func NewIndex(g CodeGraph) *Index {
+	idx := &Index{
+		Nodes: make(map[string]Node, len(g.Nodes)),
+		Out:   make(map[string][]Edge),
+		In:    make(map[string][]Edge),
+	}
+	for _, n := range g.Nodes {
+		idx.Nodes[n.ID] = n
+	}
+	for _, e := range g.Edges {
+		idx.Out[e.From] = append(idx.Out[e.From], e)
+		idx.In[e.To] = append(idx.In[e.To], e)
+	}
+	return idx
+}
+
+// Resolve returns the nodes a user-supplied symbol refers to, sorted by ID.
+// A symbol is a node ID or a dotted name: "Name", "pkg.Name", "Type.Method"
+// or "pkg.Type.Method". A package shares its name with the package clause of
+// every file, so package nodes only match a bare name no other node has.
+func (idx *Index) Resolve(symbol string) []Node {
+	if n, ok := idx.Nodes[symbol]; ok {
+		return []Node{n}
+	}
+	parts := strings.Split(symbol, ".")
+	var matches, packages []Node
+	for _, n := range idx.Nodes {
+		if n.Name != parts[len(parts)-1] {
+			continue
+		}
+		if n.Type == "package" {
+			if len(parts) == 1 {
+				packages = append(packages, n)
+			}
+			continue
+		}
+		var ok bool
+		switch len(parts) {
+		case 1:
+			ok = true
+		case 2:
+			ok = n.Package == parts[0] || n.Receiver == parts[0]
+		case 3:
+			ok = n.Package == parts[0] && n.Receiver == parts[1]
+		}
+		if ok {
+			matches = append(matches, n)
+		}
+	}
+	if len(matches) == 0 {
+		matches = packages
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
+
+// QualifiedName returns the node name qualified by package and receiver,
+// e.g. "graph.Index.Resolve"; that of a package node is the package name.
+func (n Node) QualifiedName() string {
+	if n.Type == "package" {
+		return n.Name
+	}
+	name := n.Name
+	if n.Receiver != "" {
+		name = n.Receiver + "." + name
+	}
+	if n.Package != "" {
+		name = n.Package + "." + name
+	}
+	return name
+}
+
+// Location returns "file:line" for the node, or just the file when the line
+// is unknown.
+func (n Node) Location() string {
+	if n.Line == 0 {
+		return n.File
+	}
+	return n.File + ":" + strconv.Itoa(n.Line)
+}
+
+// methodPath returns the path of the file declaring method fn of a type
+// declared in the file at path. Methods may sit in any file of the
+// package; graphs that do not record their file keep them with the type.
+func methodPath(path string, fn FunctionInfo) string {
+	if fn.FilePath == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(path), filepath.Base(fn.FilePath))
+}
+
+// NodePaths maps the ID of every declared node to the path of its file,
+// relative to the project root and slash-separated. A package node is
+// mapped to its file unless another package of the same name has a file
+// of the same name.
+func NodePaths(result ProjectStructure) map[string]string {
+	paths := make(map[string]string)
+	packageFiles := make(map[[2]string][]string) // Keyed by package name and file name
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			path = filepath.ToSlash(path)
+			key := [2]string{m.Package, filepath.Base(path)}
+			packageFiles[key] = append(packageFiles[key], path)
+			mark := func(id string) { paths[id] = path }
+			for _, fn := range m.Functions {
+				mark(fn.ID)
+			}
+			for _, st := range m.Structs {
+				mark(st.ID)
+				for _, fn := range st.Functions {
+					paths[fn.ID] = filepath.ToSlash(methodPath(path, fn))
+				}
+			}
+			for _, t := range m.Types {
+				mark(t.ID)
+				for _, fn := range t.Functions {
+					paths[fn.ID] = filepath.ToSlash(methodPath(path, fn))
+				}
+			}
+			for _, iface := range m.Interfaces {
+				mark(iface.ID)
+				for _, fn := range iface.Functions {
+					mark(fn.ID)
+				}
+			}
+			for _, c := range m.Constants {
+				mark(c.ID)
+			}
+			for _, v := range m.Variables {
+				mark(v.ID)
+			}
+		}
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		if files := packageFiles[[2]string{n.Package, n.File}]; n.Type == "package" && len(files) == 1 {
+			paths[n.ID] = files[0]
+		}
+	}
+	return paths
+}
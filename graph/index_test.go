@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndexResolve(t *testing.T) {
+	result := analyzeFiles(t, map[string]string{
+		"main.go":        "package main\n\nimport \"example.com/m/server\"\n\nfunc main() { run() }\n\nfunc run() { new(server.Server).Start() }\n",
+		"server/srv.go":  "package server\n\ntype Server struct{}\n\nfunc (s *Server) Start() {}\n",
+		"server/util.go": "package server\n\nfunc Start() {}\n",
+	})
+	tests := []struct {
+		symbol string
+		want   []string // Types and qualified names
+	}{
+		{"main", []string{"function main.main"}},
+		{"run", []string{"function main.run"}},
+		{"main.run", []string{"function main.run"}},
+		{"Start", []string{"function server.Start", "method server.Server.Start"}},
+		{"Server.Start", []string{"method server.Server.Start"}},
+		{"server.Server.Start", []string{"method server.Server.Start"}},
+		{"server", []string{"package server"}},
+		{"server.server", nil},
+		{"missing", nil},
+	}
+	idx := NewIndex(result.CodeGraph)
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			var got []string
+			for _, n := range idx.Resolve(tt.symbol) {
+				got = append(got, n.Type+" "+n.QualifiedName())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve(%q) = %v, want %v", tt.symbol, got, tt.want)
+			}
+		})
+	}
+
+	// A package node is still found by its ID
+	for _, n := range result.CodeGraph.Nodes {
+		if n.Type == "package" && n.Name == "main" {
+			if got := idx.Resolve(n.ID); len(got) != 1 || got[0].ID != n.ID {
+				t.Errorf("Resolve(%q) = %v, want the main package", n.ID, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func init() {
+	RegisterFormat(Format{Name: "json", Extension: ".json", Encoder: EncoderFunc(writeJSON), Decoder: DecoderFunc(readJSON)})
+}
+
+// writeJSON writes the pretty-printed ProjectStructure.
+func writeJSON(w io.Writer, result ProjectStructure, _ EncodeOptions) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readJSON reads a ProjectStructure written by writeJSON.
+func readJSON(r io.Reader) (ProjectStructure, error) {
+	var result ProjectStructure
+	err := json.NewDecoder(r).Decode(&result)
+	return result, err
+}
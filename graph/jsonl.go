@@ -0,0 +1,178 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+func init() {
+	RegisterFormat(Format{Name: "jsonl", Extension: ".jsonl", Encoder: jsonlEncoder{}, Decoder: DecoderFunc(readJSONL)})
+}
+
+// JSON Lines records. Every line is a single object whose "record" field
+// tells the reader which kind of record follows.
+type (
+	jsonlProject struct {
+		Record        string `json:"record"`
+		Name          string `json:"name"`
+		Module        string `json:"module,omitempty"`
+		SchemaVersion int    `json:"schemaVersion,omitempty"`
+	}
+	jsonlModule struct {
+		Record string `json:"record"`
+		Path   string `json:"path"`
+		ModuleInfo
+	}
+	jsonlNode struct {
+		Record string `json:"record"`
+		Node
+	}
+	jsonlEdge struct {
+		Record string `json:"record"`
+		Edge
+	}
+)
+
+// jsonlWriter is a recordSink that encodes each record as one JSON line.
+// The first write error is kept and later records are dropped.
+type jsonlWriter struct {
+	enc *json.Encoder
+	err error
+}
+
+func (w *jsonlWriter) write(v any) {
+	if w.err == nil {
+		w.err = w.enc.Encode(v)
+	}
+}
+
+func (w *jsonlWriter) node(n Node) {
+	w.write(jsonlNode{Record: "node", Node: n})
+}
+
+func (w *jsonlWriter) edge(e Edge) {
+	w.write(jsonlEdge{Record: "edge", Edge: e})
+}
+
+func (w *jsonlWriter) module(path string, m ModuleInfo) {
+	w.write(jsonlModule{Record: "module", Path: path, ModuleInfo: m})
+}
+
+// jsonlEncoder writes JSON Lines. As a StreamEncoder it can analyze the
+// project and write each record as it is produced.
+type jsonlEncoder struct{}
+
+// EncodeStream analyzes the project and streams it to out as JSON Lines
+// without holding the whole graph in memory.
+func (jsonlEncoder) EncodeStream(out io.Writer, opts EncodeOptions) error {
+	buf := bufio.NewWriter(out)
+	w := &jsonlWriter{enc: json.NewEncoder(buf)}
+
+	src := opts.source()
+	w.write(jsonlProject{Record: "project", Name: opts.ProjectName, Module: readModulePath(src), SchemaVersion: SchemaVersion})
+	if err := streamGoProject(src, opts.ProjectName, w); err != nil {
+		return err
+	}
+	if w.err != nil {
+		return w.err
+	}
+	return buf.Flush()
+}
+
+// Encode writes an already analyzed project in the same record order the
+// stream uses: modules first, then nodes and edges.
+func (jsonlEncoder) Encode(out io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	buf := bufio.NewWriter(out)
+	w := &jsonlWriter{enc: json.NewEncoder(buf)}
+
+	module := ""
+	for _, pkgInfo := range result.Project {
+		module = pkgInfo.Module
+	}
+	w.write(jsonlProject{Record: "project", Name: opts.ProjectName, Module: module, SchemaVersion: SchemaVersion})
+	for _, pkgInfo := range result.Project {
+		paths := make([]string, 0, len(pkgInfo.Modules))
+		for path := range pkgInfo.Modules {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			w.module(path, pkgInfo.Modules[path])
+		}
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		w.node(n)
+	}
+	for _, e := range result.CodeGraph.Edges {
+		w.edge(e)
+	}
+	if w.err != nil {
+		return w.err
+	}
+	return buf.Flush()
+}
+
+// readJSONL reads JSON Lines records back into a ProjectStructure. Module
+// records are filed under the project named by the preceding project
+// record.
+func readJSONL(r io.Reader) (ProjectStructure, error) {
+	result := ProjectStructure{
+		Project:   make(map[string]PackageInfo),
+		CodeGraph: CodeGraph{Nodes: []Node{}, Edges: []Edge{}},
+	}
+	project, module := "", ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64<<20) // Records embed function bodies
+	for line := 1; scanner.Scan(); line++ {
+		data := scanner.Bytes()
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var head struct {
+			Record string `json:"record"`
+		}
+		if err := json.Unmarshal(data, &head); err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		var err error
+		switch head.Record {
+		case "project":
+			var p jsonlProject
+			if err = json.Unmarshal(data, &p); err == nil {
+				project, module = p.Name, p.Module
+				result.SchemaVersion = p.SchemaVersion
+			}
+		case "module":
+			var m jsonlModule
+			if err = json.Unmarshal(data, &m); err == nil {
+				pkg, ok := result.Project[project]
+				if !ok {
+					pkg = PackageInfo{Module: module, Modules: make(map[string]ModuleInfo)}
+					result.Project[project] = pkg
+				}
+				pkg.Modules[m.Path] = m.ModuleInfo
+			}
+		case "node":
+			var n jsonlNode
+			if err = json.Unmarshal(data, &n); err == nil {
+				result.CodeGraph.Nodes = append(result.CodeGraph.Nodes, n.Node)
+			}
+		case "edge":
+			var e jsonlEdge
+			if err = json.Unmarshal(data, &e); err == nil {
+				result.CodeGraph.Edges = append(result.CodeGraph.Edges, e.Edge)
+			}
+		default:
+			err = fmt.Errorf("unknown record type %q", head.Record)
+		}
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return result, scanner.Err()
+}
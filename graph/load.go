@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SchemaVersion is the version of the serialized graph model. It is bumped
+// when a field is removed or changes meaning; adding fields keeps it as is.
+// Readers reject graphs written with a newer version.
+const SchemaVersion = 1
+
+// checkSchemaVersion rejects graphs written by a newer codegraph. Version 0
+// marks graphs written before the version was recorded.
+func checkSchemaVersion(version int) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("graph schema version %d is newer than supported version %d", version, SchemaVersion)
+	}
+	return nil
+}
+
+// Load reads a graph file previously written in any format that can be read
+// back (json, jsonl, sqlite or proto). The format is taken from the file
+// extension, or detected from the contents when the extension is unknown.
+func Load(path string) (ProjectStructure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ProjectStructure{}, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	format := formatForExtension(filepath.Ext(path))
+	if format == "" {
+		head, _ := br.Peek(512)
+		format = sniffFormat(head)
+	}
+	result, err := Decode(br, format)
+	if err != nil {
+		return result, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return result, nil
+}
+
+// Decode reads a graph in the named format from r and checks its schema
+// version.
+func Decode(r io.Reader, format string) (ProjectStructure, error) {
+	f, ok := LookupFormat(format)
+	if !ok {
+		return ProjectStructure{}, unknownFormat(format)
+	}
+	if f.Decoder == nil {
+		return ProjectStructure{}, fmt.Errorf("format %q cannot be read back", format)
+	}
+	result, err := f.Decoder.Decode(r)
+	if err != nil {
+		return result, err
+	}
+	if err := checkSchemaVersion(result.SchemaVersion); err != nil {
+		return ProjectStructure{}, err
+	}
+	return result, nil
+}
+
+// formatForExtension returns the format using ext, if any.
+func formatForExtension(ext string) string {
+	for _, name := range Formats() {
+		f, _ := LookupFormat(name)
+		if strings.EqualFold(f.Extension, ext) {
+			return name
+		}
+	}
+	return ""
+}
+
+// sniffFormat guesses the format of a graph file from its first bytes.
+func sniffFormat(head []byte) string {
+	switch trimmed := bytes.TrimSpace(head); {
+	case bytes.HasPrefix(head, []byte("SQLite format 3\x00")):
+		return "sqlite"
+	case bytes.HasPrefix(trimmed, []byte(`{"record"`)):
+		return "jsonl"
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return "json"
+	default:
+		return "proto"
+	}
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	result := analyzeFiles(t, sampleProject)
+	dir := t.TempDir()
+	tests := []struct {
+		format, filename string
+	}{
+		{"json", "graph.json"},
+		{"jsonl", "graph.jsonl"},
+		{"sqlite", "graph.db"},
+		{"proto", "graph.pb"},
+		// Detected from the contents
+		{"json", "graph.out"},
+		{"jsonl", "graph-jsonl"},
+		{"sqlite", "graph.sqlite3"},
+		{"proto", "graph.bin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, tt.format, result, EncodeOptions{ProjectName: "test"}); err != nil {
+				t.Fatal(err)
+			}
+			filename := filepath.Join(dir, tt.filename)
+			if err := os.WriteFile(filename, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Load(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, result) {
+				t.Errorf("loaded graph differs from the one written")
+			}
+		})
+	}
+}
+
+func TestDecodeSchemaVersion(t *testing.T) {
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{`{"schemaVersion": 1, "project": {}, "codeGraph": {"nodes": [], "edges": []}}`, ""},
+		{`{"project": {}, "codeGraph": {"nodes": [], "edges": []}}`, ""},
+		{`{"schemaVersion": 2, "project": {}, "codeGraph": {"nodes": [], "edges": []}}`, "newer than supported version 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.json), "json")
+			if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
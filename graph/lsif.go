@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+)
+
+func init() {
+	RegisterFormat(Format{Name: "lsif", Extension: ".lsif", Encoder: EncoderFunc(writeLSIF)})
+}
+
+// LSIF vertex and edge shapes, see
+// https://microsoft.github.io/language-server-protocol/specifications/lsif/0.4.0/specification/
+type (
+	lsifVertex struct {
+		ID               int           `json:"id"`
+		Type             string        `json:"type"`
+		Label            string        `json:"label"`
+		Version          string        `json:"version,omitempty"`
+		ProjectRoot      string        `json:"projectRoot,omitempty"`
+		PositionEncoding string        `json:"positionEncoding,omitempty"`
+		ToolInfo         *lsifToolInfo `json:"toolInfo,omitempty"`
+		Kind             string        `json:"kind,omitempty"`
+		URI              string        `json:"uri,omitempty"`
+		LanguageID       string        `json:"languageId,omitempty"`
+		Start            *lsifPosition `json:"start,omitempty"`
+		End              *lsifPosition `json:"end,omitempty"`
+		Result           *lsifHover    `json:"result,omitempty"`
+	}
+	lsifEdge struct {
+		ID       int    `json:"id"`
+		Type     string `json:"type"`
+		Label    string `json:"label"`
+		OutV     int    `json:"outV"`
+		InV      int    `json:"inV,omitempty"`
+		InVs     []int  `json:"inVs,omitempty"`
+		Document int    `json:"document,omitempty"`
+		Property string `json:"property,omitempty"`
+	}
+	lsifToolInfo struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	lsifPosition struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+	lsifHover struct {
+		Contents []lsifMarkedString `json:"contents"`
+	}
+	lsifMarkedString struct {
+		Language string `json:"language,omitempty"`
+		Value    string `json:"value"`
+	}
+)
+
+// lsifEmitter numbers and writes LSIF elements as JSON lines
+type lsifEmitter struct {
+	enc    *json.Encoder
+	nextID int
+	err    error
+}
+
+func (e *lsifEmitter) vertex(v lsifVertex) int {
+	e.nextID++
+	v.ID, v.Type = e.nextID, "vertex"
+	if e.err == nil {
+		e.err = e.enc.Encode(v)
+	}
+	return v.ID
+}
+
+func (e *lsifEmitter) edge(ed lsifEdge) {
+	e.nextID++
+	ed.ID, ed.Type = e.nextID, "edge"
+	if e.err == nil {
+		e.err = e.enc.Encode(ed)
+	}
+}
+
+// writeLSIF encodes the graph as an LSIF dump, the JSON predecessor of SCIP
+// still accepted by older code intelligence backends.
+func writeLSIF(w io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	idx := newSymbolIndex(result)
+
+	root, err := filepath.Abs(opts.ProjectPath)
+	if err != nil {
+		return err
+	}
+	rootURI := url.URL{Scheme: "file", Path: filepath.ToSlash(root)}
+
+	buf := bufio.NewWriter(w)
+	e := &lsifEmitter{enc: json.NewEncoder(buf)}
+
+	e.vertex(lsifVertex{
+		Label:            "metaData",
+		Version:          "0.4.3",
+		ProjectRoot:      rootURI.String(),
+		PositionEncoding: "utf-16",
+		ToolInfo:         &lsifToolInfo{Name: scipToolName, Version: scipToolVersion},
+	})
+	project := e.vertex(lsifVertex{Label: "project", Kind: "go"})
+
+	// One result set per defined symbol, carrying its hover text
+	resultSets := make(map[string]int)
+	var defined []string
+	for id := range idx.symbols {
+		defined = append(defined, id)
+	}
+	sort.Strings(defined)
+	for _, id := range defined {
+		resultSet := e.vertex(lsifVertex{Label: "resultSet"})
+		resultSets[id] = resultSet
+
+		hover := &lsifHover{}
+		if sig := idx.hovers[id]; sig != "" {
+			hover.Contents = append(hover.Contents, lsifMarkedString{Language: "go", Value: sig})
+		}
+		if comment := idx.comments[id]; comment != "" {
+			hover.Contents = append(hover.Contents, lsifMarkedString{Value: comment})
+		}
+		if len(hover.Contents) > 0 {
+			hoverResult := e.vertex(lsifVertex{Label: "hoverResult", Result: hover})
+			e.edge(lsifEdge{Label: "textDocument/hover", OutV: resultSet, InV: hoverResult})
+		}
+	}
+
+	// Documents and their ranges, remembering where each symbol occurs
+	type rangeRef struct{ document, rng int }
+	definitions := make(map[string][]rangeRef)
+	references := make(map[string][]rangeRef)
+	var documents []int
+	for _, modulePath := range idx.documents {
+		docURI := rootURI
+		docURI.Path += "/" + modulePath
+		doc := e.vertex(lsifVertex{Label: "document", URI: docURI.String(), LanguageID: "go"})
+		documents = append(documents, doc)
+
+		var ranges []int
+		for _, occ := range idx.occurrences[modulePath] {
+			line, column := occ.line-1, occ.column-1
+			rng := e.vertex(lsifVertex{
+				Label: "range",
+				Start: &lsifPosition{Line: line, Character: column},
+				End:   &lsifPosition{Line: line, Character: column + len(idx.nodes[occ.node].Name)},
+			})
+			e.edge(lsifEdge{Label: "next", OutV: rng, InV: resultSets[occ.node]})
+			ranges = append(ranges, rng)
+
+			if occ.definition {
+				definitions[occ.node] = append(definitions[occ.node], rangeRef{doc, rng})
+			} else {
+				references[occ.node] = append(references[occ.node], rangeRef{doc, rng})
+			}
+		}
+		if len(ranges) > 0 {
+			e.edge(lsifEdge{Label: "contains", OutV: doc, InVs: ranges})
+		}
+	}
+	if len(documents) > 0 {
+		e.edge(lsifEdge{Label: "contains", OutV: project, InVs: documents})
+	}
+
+	// Definition and reference results, grouped by document
+	items := func(result int, refs []rangeRef, property string) {
+		byDoc := make(map[int][]int)
+		var docs []int
+		for _, r := range refs {
+			if _, ok := byDoc[r.document]; !ok {
+				docs = append(docs, r.document)
+			}
+			byDoc[r.document] = append(byDoc[r.document], r.rng)
+		}
+		for _, doc := range docs {
+			e.edge(lsifEdge{Label: "item", OutV: result, InVs: byDoc[doc], Document: doc, Property: property})
+		}
+	}
+	for _, id := range defined {
+		if len(definitions[id]) == 0 {
+			continue
+		}
+		definitionResult := e.vertex(lsifVertex{Label: "definitionResult"})
+		e.edge(lsifEdge{Label: "textDocument/definition", OutV: resultSets[id], InV: definitionResult})
+		items(definitionResult, definitions[id], "")
+
+		referenceResult := e.vertex(lsifVertex{Label: "referenceResult"})
+		e.edge(lsifEdge{Label: "textDocument/references", OutV: resultSets[id], InV: referenceResult})
+		items(referenceResult, definitions[id], "definitions")
+		items(referenceResult, references[id], "references")
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Metrics are size and complexity measures of a function or method.
+type Metrics struct {
+	Cyclomatic int `json:"cyclomatic"` // McCabe: 1 + decision points
+	Cognitive  int `json:"cognitive"`  // SonarSource cognitive complexity
+	Lines      int `json:"lines"`      // Lines from the func keyword to the closing brace
+	Params     int `json:"params"`
+	Returns    int `json:"returns"`
+	Nesting    int `json:"nesting"` // Deepest nesting of control structures
+}
+
+// functionMetrics measures a function or method declaration.
+func functionMetrics(fset *token.FileSet, fn *ast.FuncDecl) *Metrics {
+	m := &Metrics{
+		Cyclomatic: 1,
+		Lines:      fset.Position(fn.End()).Line - fset.Position(fn.Pos()).Line + 1,
+		Params:     fieldCount(fn.Type.Params),
+		Returns:    fieldCount(fn.Type.Results),
+	}
+	if fn.Body != nil {
+		c := &complexityVisitor{m: m}
+		c.block(fn.Body.List, 0)
+	}
+	return m
+}
+
+// fieldCount counts the entries of a parameter or result list, where
+// "a, b int" counts as two.
+func fieldCount(fl *ast.FieldList) int {
+	if fl == nil {
+		return 0
+	}
+	n := 0
+	for _, f := range fl.List {
+		if len(f.Names) == 0 {
+			n++
+		} else {
+			n += len(f.Names)
+		}
+	}
+	return n
+}
+
+// complexityVisitor walks a function body keeping track of the nesting
+// level, which cognitive complexity charges extra for.
+type complexityVisitor struct {
+	m *Metrics
+}
+
+func (c *complexityVisitor) nest(level int) {
+	if level > c.m.Nesting {
+		c.m.Nesting = level
+	}
+}
+
+func (c *complexityVisitor) block(stmts []ast.Stmt, level int) {
+	for _, s := range stmts {
+		c.stmt(s, level)
+	}
+}
+
+func (c *complexityVisitor) stmt(s ast.Stmt, level int) {
+	switch s := s.(type) {
+	case *ast.IfStmt:
+		c.m.Cognitive += 1 + level
+		c.ifStmt(s, level)
+	case *ast.ForStmt:
+		c.m.Cyclomatic++
+		c.m.Cognitive += 1 + level
+		c.nest(level + 1)
+		if s.Init != nil {
+			c.stmt(s.Init, level)
+		}
+		c.expr(s.Cond, level)
+		if s.Post != nil {
+			c.stmt(s.Post, level)
+		}
+		c.block(s.Body.List, level+1)
+	case *ast.RangeStmt:
+		c.m.Cyclomatic++
+		c.m.Cognitive += 1 + level
+		c.nest(level + 1)
+		c.expr(s.X, level)
+		c.block(s.Body.List, level+1)
+	case *ast.SwitchStmt:
+		c.m.Cognitive += 1 + level
+		c.nest(level + 1)
+		if s.Init != nil {
+			c.stmt(s.Init, level)
+		}
+		c.expr(s.Tag, level)
+		c.clauses(s.Body, level+1)
+	case *ast.TypeSwitchStmt:
+		c.m.Cognitive += 1 + level
+		c.nest(level + 1)
+		if s.Init != nil {
+			c.stmt(s.Init, level)
+		}
+		c.clauses(s.Body, level+1)
+	case *ast.SelectStmt:
+		c.m.Cognitive += 1 + level
+		c.nest(level + 1)
+		c.clauses(s.Body, level+1)
+	case *ast.BranchStmt:
+		// goto and jumps to a label break the linear flow
+		if s.Tok == token.GOTO || s.Label != nil {
+			c.m.Cognitive++
+		}
+	case *ast.BlockStmt:
+		c.block(s.List, level)
+	case *ast.LabeledStmt:
+		c.stmt(s.Stmt, level)
+	case *ast.ExprStmt:
+		c.expr(s.X, level)
+	case *ast.AssignStmt:
+		for _, e := range s.Rhs {
+			c.expr(e, level)
+		}
+	case *ast.ReturnStmt:
+		for _, e := range s.Results {
+			c.expr(e, level)
+		}
+	case *ast.DeclStmt:
+		ast.Inspect(s, func(n ast.Node) bool {
+			if e, ok := n.(ast.Expr); ok {
+				c.expr(e, level)
+				return false
+			}
+			return true
+		})
+	case *ast.GoStmt:
+		c.expr(s.Call, level)
+	case *ast.DeferStmt:
+		c.expr(s.Call, level)
+	case *ast.SendStmt:
+		c.expr(s.Value, level)
+	}
+}
+
+// ifStmt handles an if and its else-if chain; only the first if pays for
+// nesting, each else if and else adds one.
+func (c *complexityVisitor) ifStmt(s *ast.IfStmt, level int) {
+	c.m.Cyclomatic++
+	c.nest(level + 1)
+	if s.Init != nil {
+		c.stmt(s.Init, level)
+	}
+	c.expr(s.Cond, level)
+	c.block(s.Body.List, level+1)
+	switch e := s.Else.(type) {
+	case *ast.IfStmt:
+		c.m.Cognitive++
+		c.ifStmt(e, level)
+	case *ast.BlockStmt:
+		c.m.Cognitive++
+		c.block(e.List, level+1)
+	}
+}
+
+// clauses visits the cases of a switch or select; every case but the
+// default is a decision point.
+func (c *complexityVisitor) clauses(body *ast.BlockStmt, level int) {
+	for _, s := range body.List {
+		switch cl := s.(type) {
+		case *ast.CaseClause:
+			if cl.List != nil {
+				c.m.Cyclomatic++
+			}
+			for _, e := range cl.List {
+				c.expr(e, level)
+			}
+			c.block(cl.Body, level)
+		case *ast.CommClause:
+			if cl.Comm != nil {
+				c.m.Cyclomatic++
+				c.stmt(cl.Comm, level)
+			}
+			c.block(cl.Body, level)
+		}
+	}
+}
+
+// expr charges for boolean operators and walks into function literals,
+// which nest one level deeper.
+func (c *complexityVisitor) expr(e ast.Expr, level int) {
+	if e == nil {
+		return
+	}
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			c.nest(level + 1)
+			c.block(n.Body.List, level+1)
+			return false
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				c.logical(n, level)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// logical handles a tree of && and || operators: each is a decision point,
+// and each run of the same operator costs one cognitive point.
+func (c *complexityVisitor) logical(e *ast.BinaryExpr, level int) {
+	var operands []ast.Expr
+	var ops []token.Token
+	var flatten func(x ast.Expr)
+	flatten = func(x ast.Expr) {
+		if p, ok := x.(*ast.ParenExpr); ok {
+			x = p.X
+		}
+		if b, ok := x.(*ast.BinaryExpr); ok && (b.Op == token.LAND || b.Op == token.LOR) {
+			flatten(b.X)
+			ops = append(ops, b.Op)
+			flatten(b.Y)
+			return
+		}
+		operands = append(operands, x)
+	}
+	flatten(e)
+
+	for i, op := range ops {
+		c.m.Cyclomatic++
+		if i == 0 || ops[i-1] != op {
+			c.m.Cognitive++
+		}
+	}
+	for _, x := range operands {
+		c.expr(x, level)
+	}
+}
@@ -0,0 +1,301 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricsLevels are the levels a MetricsReport can be viewed at.
+var MetricsLevels = []string{"function", "struct", "file", "package"}
+
+// MetricsReport collects code metrics of a project at function, struct,
+// file and package level.
+type MetricsReport struct {
+	Functions []FunctionMetrics `json:"functions"`
+	Structs   []StructMetrics   `json:"structs"`
+	Files     []FileMetrics     `json:"files"`
+	Packages  []PackageMetrics  `json:"packages"`
+}
+
+// FunctionMetrics are the metrics of a function or method, plus its fan-in
+// and fan-out: the number of distinct callers and callees.
+type FunctionMetrics struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Metrics
+	FanIn  int `json:"fanIn"`
+	FanOut int `json:"fanOut"`
+}
+
+// StructMetrics counts the fields and methods of a struct.
+type StructMetrics struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Fields   int    `json:"fields"`
+	Methods  int    `json:"methods"`
+}
+
+// FileMetrics summarizes a source file.
+type FileMetrics struct {
+	Path          string `json:"path"`
+	Package       string `json:"package"`
+	Lines         int    `json:"lines"`
+	Functions     int    `json:"functions"` // Functions and methods
+	Cyclomatic    int    `json:"cyclomatic"`
+	MaxCyclomatic int    `json:"maxCyclomatic"`
+}
+
+// PackageMetrics are Robert Martin's package metrics. Afferent coupling
+// (Ca) counts the packages of the project importing this one, efferent
+// coupling (Ce) those it imports; instability is Ce/(Ca+Ce), abstractness the
+// share of interfaces among its types and distance |A+I-1| how far the
+// package is from the main sequence.
+type PackageMetrics struct {
+	Path         string  `json:"path"`
+	Package      string  `json:"package"`
+	Files        int     `json:"files"`
+	Lines        int     `json:"lines"`
+	Afferent     int     `json:"afferent"`
+	Efferent     int     `json:"efferent"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+}
+
+// NewMetricsReport computes the metrics report of an analyzed project.
+// Packages are told apart by directory, so that two packages named main
+// are not lumped together.
+func NewMetricsReport(result ProjectStructure) *MetricsReport {
+	idx := NewIndex(result.CodeGraph)
+	r := &MetricsReport{
+		Functions: []FunctionMetrics{},
+		Structs:   []StructMetrics{},
+		Files:     []FileMetrics{},
+		Packages:  []PackageMetrics{},
+	}
+
+	packages := make(map[string]*PackageMetrics)
+	types := make(map[string][2]int) // Concrete types and interfaces per directory
+	fields := make(map[string]int)
+	files := make(map[string]*FileMetrics)
+	var methods [][2]string // File and ID, counted once every file is known
+	count := func(file *FileMetrics, id string) {
+		if n, ok := idx.Nodes[id]; ok && n.Metrics != nil {
+			file.Functions++
+			file.Cyclomatic += n.Metrics.Cyclomatic
+			file.MaxCyclomatic = max(file.MaxCyclomatic, n.Metrics.Cyclomatic)
+		}
+	}
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			path = filepath.ToSlash(path)
+			dir := filepath.ToSlash(filepath.Dir(path))
+			p := packages[dir]
+			if p == nil {
+				p = &PackageMetrics{Path: dir, Package: m.Package}
+				packages[dir] = p
+			}
+			p.Files++
+			p.Lines += m.Lines
+
+			file := &FileMetrics{Path: path, Package: m.Package, Lines: m.Lines}
+			files[path] = file
+			for _, fn := range m.Functions {
+				count(file, fn.ID)
+			}
+			for _, st := range m.Structs {
+				fields[st.ID] = len(st.Properties)
+				for _, fn := range st.Functions {
+					methods = append(methods, [2]string{filepath.ToSlash(methodPath(path, fn)), fn.ID})
+				}
+			}
+			for _, t := range m.Types {
+				for _, fn := range t.Functions {
+					methods = append(methods, [2]string{filepath.ToSlash(methodPath(path, fn)), fn.ID})
+				}
+			}
+			t := types[dir]
+			types[dir] = [2]int{t[0] + len(m.Structs) + len(m.Types), t[1] + len(m.Interfaces)}
+		}
+	}
+	for _, method := range methods {
+		if file := files[method[0]]; file != nil {
+			count(file, method[1])
+		}
+	}
+	for _, file := range files {
+		r.Files = append(r.Files, *file)
+	}
+
+	for _, n := range result.CodeGraph.Nodes {
+		switch {
+		case n.Metrics != nil:
+			callers, callees := make(map[string]bool), make(map[string]bool)
+			for _, e := range idx.In[n.ID] {
+				if e.Relation == "calls" {
+					callers[e.From] = true
+				}
+			}
+			for _, e := range idx.Out[n.ID] {
+				if e.Relation == "calls" {
+					callees[e.To] = true
+				}
+			}
+			r.Functions = append(r.Functions, FunctionMetrics{
+				ID:       n.ID,
+				Name:     n.QualifiedName(),
+				Location: n.Location(),
+				Metrics:  *n.Metrics,
+				FanIn:    len(callers),
+				FanOut:   len(callees),
+			})
+		case n.Type == "struct":
+			s := StructMetrics{ID: n.ID, Name: n.QualifiedName(), Location: n.Location()}
+			for _, e := range idx.Out[n.ID] {
+				if e.Relation == "has_method" {
+					s.Methods++
+				}
+			}
+			r.Structs = append(r.Structs, s)
+		}
+	}
+	for i := range r.Structs {
+		r.Structs[i].Fields = fields[r.Structs[i].ID]
+	}
+
+	// Coupling counts distinct packages of the project on the other end of
+	// the imports
+	efferent := make(map[string]map[string]bool)
+	afferent := make(map[string]map[string]bool)
+	imports, _ := projectImports(result)
+	for _, imp := range imports {
+		from, to := imp.From, imp.To
+		if to == "" || from == to {
+			continue
+		}
+		if efferent[from] == nil {
+			efferent[from] = make(map[string]bool)
+		}
+		if afferent[to] == nil {
+			afferent[to] = make(map[string]bool)
+		}
+		efferent[from][to] = true
+		afferent[to][from] = true
+	}
+	for dir, p := range packages {
+		p.Afferent, p.Efferent = len(afferent[dir]), len(efferent[dir])
+		if p.Afferent+p.Efferent > 0 {
+			p.Instability = round2(float64(p.Efferent) / float64(p.Afferent+p.Efferent))
+		}
+		if t := types[dir]; t[0]+t[1] > 0 {
+			p.Abstractness = round2(float64(t[1]) / float64(t[0]+t[1]))
+		}
+		p.Distance = round2(math.Abs(p.Abstractness + p.Instability - 1))
+		r.Packages = append(r.Packages, *p)
+	}
+
+	sort.Slice(r.Functions, func(i, j int) bool {
+		a, b := r.Functions[i], r.Functions[j]
+		return a.Name < b.Name || a.Name == b.Name && a.Location < b.Location
+	})
+	sort.Slice(r.Structs, func(i, j int) bool {
+		a, b := r.Structs[i], r.Structs[j]
+		return a.Name < b.Name || a.Name == b.Name && a.Location < b.Location
+	})
+	sort.Slice(r.Files, func(i, j int) bool { return r.Files[i].Path < r.Files[j].Path })
+	sort.Slice(r.Packages, func(i, j int) bool { return r.Packages[i].Path < r.Packages[j].Path })
+	return r
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// Table returns the report at the given level as rows. The first two
+// columns identify the row; the others are numeric.
+func (r *MetricsReport) Table(level string) (*QueryResult, error) {
+	var t QueryResult
+	switch level {
+	case "function":
+		t.Columns = []string{"name", "location", "cyclomatic", "cognitive", "lines", "params", "returns", "nesting", "fan_in", "fan_out"}
+		for _, f := range r.Functions {
+			t.Rows = append(t.Rows, []any{f.Name, f.Location, f.Cyclomatic, f.Cognitive, f.Lines, f.Params, f.Returns, f.Nesting, f.FanIn, f.FanOut})
+		}
+	case "struct":
+		t.Columns = []string{"name", "location", "fields", "methods"}
+		for _, s := range r.Structs {
+			t.Rows = append(t.Rows, []any{s.Name, s.Location, s.Fields, s.Methods})
+		}
+	case "file":
+		t.Columns = []string{"path", "package", "lines", "functions", "cyclomatic", "max_cyclomatic"}
+		for _, f := range r.Files {
+			t.Rows = append(t.Rows, []any{f.Path, f.Package, f.Lines, f.Functions, f.Cyclomatic, f.MaxCyclomatic})
+		}
+	case "package":
+		t.Columns = []string{"path", "package", "files", "lines", "afferent", "efferent", "instability", "abstractness", "distance"}
+		for _, p := range r.Packages {
+			t.Rows = append(t.Rows, []any{p.Path, p.Package, p.Files, p.Lines, p.Afferent, p.Efferent, p.Instability, p.Abstractness, p.Distance})
+		}
+	default:
+		return nil, fmt.Errorf("unknown metrics level %q (want %s)", level, strings.Join(MetricsLevels, ", "))
+	}
+	return &t, nil
+}
+
+// MetricsViolation is a row exceeding a threshold.
+type MetricsViolation struct {
+	Name   string
+	Metric string
+	Value  float64
+	Limit  float64
+}
+
+func (v MetricsViolation) String() string {
+	return fmt.Sprintf("%s: %s %s exceeds %s", v.Name, v.Metric,
+		strconv.FormatFloat(v.Value, 'f', -1, 64), strconv.FormatFloat(v.Limit, 'f', -1, 64))
+}
+
+// CheckThresholds returns the rows of t whose value in a column exceeds
+// the maximum given for that column in limits.
+func CheckThresholds(t *QueryResult, limits map[string]float64) ([]MetricsViolation, error) {
+	columns := make(map[string]int)
+	for i, c := range t.Columns[2:] {
+		columns[c] = i + 2
+	}
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown metric %q (want one of %s)", name, strings.Join(t.Columns[2:], ", "))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []MetricsViolation
+	for _, row := range t.Rows {
+		for _, name := range names {
+			var value float64
+			switch v := row[columns[name]].(type) {
+			case int:
+				value = float64(v)
+			case float64:
+				value = v
+			}
+			if value > limits[name] {
+				violations = append(violations, MetricsViolation{
+					Name:   valueString(row[0]),
+					Metric: name,
+					Value:  value,
+					Limit:  limits[name],
+				})
+			}
+		}
+	}
+	return violations, nil
+}
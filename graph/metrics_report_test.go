@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMetricsReportPackages(t *testing.T) {
+	result := analyzeFiles(t, map[string]string{
+		// a uses nothing of c, which it imports for its side effects
+		"a/a.go": "package a\n\nimport (\n\t\"example.com/m/b\"\n\t_ \"example.com/m/c\"\n)\n\ntype T struct{ b.Base }\n",
+		"b/b.go": "package b\n\nimport (\n\tother \"github.com/other/c\"\n\n\t\"example.com/m/c\"\n)\n\ntype Base struct{ r c.Reader }\n\nvar _ = other.X\n",
+		"c/c.go": "package c\n\ntype Reader interface{ Read() }\n\ntype File struct{}\n",
+	})
+	tests := []struct {
+		path                                string
+		afferent, efferent                  int
+		instability, abstractness, distance float64
+	}{
+		{"a", 0, 2, 1, 0, 0},
+		{"b", 1, 1, 0.5, 0, 0.5},
+		{"c", 2, 0, 0, 0.5, 0.5},
+	}
+	packages := NewMetricsReport(result).Packages
+	if len(packages) != len(tests) {
+		t.Fatalf("packages = %v, want %d", packages, len(tests))
+	}
+	for i, tt := range tests {
+		p := packages[i]
+		got := []any{p.Path, p.Afferent, p.Efferent, p.Instability, p.Abstractness, p.Distance}
+		want := []any{tt.path, tt.afferent, tt.efferent, tt.instability, tt.abstractness, tt.distance}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("package %s: (path, Ca, Ce, I, A, D) = %v, want %v", tt.path, got, want)
+		}
+	}
+}
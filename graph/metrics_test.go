@@ -0,0 +1,77 @@
+package graph
+
+import "testing"
+
+func TestFunctionMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want Metrics
+	}{
+		{
+			name: "straight line",
+			src:  "func f(a, b int) (int, error) { return a + b, nil }",
+			want: Metrics{Cyclomatic: 1, Cognitive: 0, Lines: 1, Params: 2, Returns: 2, Nesting: 0},
+		},
+		{
+			name: "else if chain",
+			src: `func f(x int) int {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	} else {
+		return 0
+	}
+}`,
+			want: Metrics{Cyclomatic: 3, Cognitive: 3, Lines: 9, Params: 1, Returns: 1, Nesting: 1},
+		},
+		{
+			name: "nested loops and boolean operators",
+			src: `func f(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		for i := 0; i < x; i++ {
+			if x > 0 && i > 0 || x < -1 {
+				n++
+			}
+		}
+	}
+	return n
+}`,
+			want: Metrics{Cyclomatic: 6, Cognitive: 8, Lines: 11, Params: 1, Returns: 1, Nesting: 3},
+		},
+		{
+			name: "switch and function literal",
+			src: `func f(s string) func() int {
+	switch s {
+	case "a", "b":
+		return nil
+	case "c":
+	default:
+	}
+	return func() int {
+		if s == "" {
+			return 0
+		}
+		return 1
+	}
+}`,
+			want: Metrics{Cyclomatic: 4, Cognitive: 3, Lines: 14, Params: 1, Returns: 1, Nesting: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzeFiles(t, map[string]string{"f.go": "package f\n\n" + tt.src + "\n"})
+			for _, n := range result.CodeGraph.Nodes {
+				if n.Name == "f" && n.Type == "function" {
+					if *n.Metrics != tt.want {
+						t.Errorf("metrics = %+v, want %+v", *n.Metrics, tt.want)
+					}
+					return
+				}
+			}
+			t.Fatal("function f not found")
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ShortestPath returns the edges of a shortest path, following edges
+// forward, from any node in from to any node in to. It returns nil when no
+// path exists and an empty path when from and to share a node.
+func (idx *Index) ShortestPath(from, to []string, opts TraverseOptions) []Edge {
+	targets := make(map[string]bool, len(to))
+	for _, id := range to {
+		targets[id] = true
+	}
+
+	// Breadth-first search remembering the edge each node was reached by
+	via := make(map[string]*Edge)
+	queue := make([]string, 0, len(from))
+	depth := make(map[string]int)
+	for _, id := range from {
+		if _, seen := via[id]; !seen {
+			via[id] = nil
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if targets[id] {
+			var path []Edge
+			for e := via[id]; e != nil; e = via[e.From] {
+				path = append(path, *e)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return append([]Edge{}, path...)
+		}
+		if opts.Depth > 0 && depth[id] >= opts.Depth {
+			continue
+		}
+		edges, next := idx.neighbors(id, Forward, opts)
+		for i, n := range next {
+			if _, seen := via[n]; seen {
+				continue
+			}
+			e := edges[i]
+			via[n] = &e
+			depth[n] = depth[id] + 1
+			queue = append(queue, n)
+		}
+	}
+	return nil
+}
+
+// AllPaths returns the simple paths from any node in from to any node in to
+// with at most maxLen edges, shortest first. At most limit paths are
+// collected, which are then the limit shortest ones; limit <= 0 means no
+// limit.
+func (idx *Index) AllPaths(from, to []string, maxLen, limit int, opts TraverseOptions) [][]Edge {
+	targets := make(map[string]bool, len(to))
+	for _, id := range to {
+		targets[id] = true
+	}
+
+	// Iterative deepening: each round lists the paths of exactly length
+	// edges, so paths are found in order of length
+	var paths [][]Edge
+	onPath := make(map[string]bool)
+	var path []Edge
+	var cut bool // Whether a round stopped some walk at its length
+	var walk func(id string, length int)
+	walk = func(id string, length int) {
+		if limit > 0 && len(paths) >= limit {
+			return
+		}
+		if targets[id] && len(path) > 0 {
+			if len(path) == length {
+				paths = append(paths, append([]Edge{}, path...))
+			}
+			return
+		}
+		if len(path) == length {
+			cut = true
+			return
+		}
+		onPath[id] = true
+		edges, next := idx.neighbors(id, Forward, opts)
+		for i, n := range next {
+			if onPath[n] {
+				continue
+			}
+			path = append(path, edges[i])
+			walk(n, length)
+			path = path[:len(path)-1]
+		}
+		onPath[id] = false
+	}
+	for length := 1; length <= maxLen; length++ {
+		cut = false
+		for _, id := range from {
+			walk(id, length)
+		}
+		if !cut || limit > 0 && len(paths) >= limit {
+			break
+		}
+	}
+	return paths
+}
+
+// WritePath prints a path one hop per line: the starting node, then for
+// every edge the relation, the position of the reference and the node
+// reached, each with its definition's file:line.
+func (idx *Index) WritePath(w io.Writer, path []Edge) error {
+	if len(path) == 0 {
+		return nil
+	}
+	start := idx.Nodes[path[0].From]
+	if _, err := fmt.Fprintf(w, "%s  %s\n", start.QualifiedName(), start.Location()); err != nil {
+		return err
+	}
+	for _, e := range path {
+		hop := e.Relation
+		if e.Line > 0 {
+			hop += " at " + idx.Nodes[e.From].File + ":" + strconv.Itoa(e.Line)
+		}
+		n := idx.Nodes[e.To]
+		if _, err := fmt.Fprintf(w, "  -[%s]-> %s  %s\n", hop, n.QualifiedName(), n.Location()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pathString names the nodes along path, e.g. "main.run main.load store.Put".
+func pathString(idx *Index, path []Edge) string {
+	if path == nil {
+		return "<none>"
+	}
+	if len(path) == 0 {
+		return "<empty>"
+	}
+	names := []string{idx.Nodes[path[0].From].QualifiedName()}
+	for _, e := range path {
+		names = append(names, idx.Nodes[e.To].QualifiedName())
+	}
+	return strings.Join(names, " ")
+}
+
+func TestShortestPath(t *testing.T) {
+	idx := NewIndex(analyzeFiles(t, callChain).CodeGraph)
+	calls := TraverseOptions{Relations: []string{"calls"}}
+	tests := []struct {
+		from, to string
+		opts     TraverseOptions
+		want     string
+	}{
+		{"main.main", "store.Put", calls, "main.main main.run main.load store.Put"},
+		{"main.save", "store.Put", calls, "main.save main.load store.Put"},
+		{"main.main", "store.Put", TraverseOptions{Depth: 2, Relations: []string{"calls"}}, "<none>"},
+		{"store.Put", "main.main", calls, "<none>"},
+		{"main.run", "main.run", calls, "<empty>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" "+tt.to, func(t *testing.T) {
+			path := idx.ShortestPath([]string{resolveOne(t, idx, tt.from)}, []string{resolveOne(t, idx, tt.to)}, tt.opts)
+			if got := pathString(idx, path); got != tt.want {
+				t.Errorf("path = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	idx := NewIndex(analyzeFiles(t, callChain).CodeGraph)
+	calls := TraverseOptions{Relations: []string{"calls"}}
+	tests := []struct {
+		name          string
+		maxLen, limit int
+		want          []string
+	}{
+		{"all", 5, 0, []string{"main.run main.load store.Put", "main.run main.save main.load store.Put"}},
+		{"limit", 5, 1, []string{"main.run main.load store.Put"}},
+		{"max length", 2, 0, []string{"main.run main.load store.Put"}},
+		{"too short", 1, 0, nil},
+	}
+	from, to := resolveOne(t, idx, "main.run"), resolveOne(t, idx, "store.Put")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, path := range idx.AllPaths([]string{from}, []string{to}, tt.maxLen, tt.limit, calls) {
+				got = append(got, pathString(idx, path))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package graph
+
+//go:generate protoc --proto_path=../proto --go_out=.. --go_opt=module=github.com/srinidhi-metadome/go-codegraph-cli codegraph/v1/codegraph.proto
+
+import (
+	"io"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/codegraphpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:      "proto",
+		Extension: ".pb",
+		Encoder: EncoderFunc(func(w io.Writer, result ProjectStructure, _ EncodeOptions) error {
+			return WriteProto(w, result)
+		}),
+		Decoder: DecoderFunc(ReadProto),
+	})
+}
+
+// WriteProto encodes result as a codegraph.v1.ProjectStructure message.
+// Map fields are written in key order, so the same graph always encodes to
+// the same bytes.
+func WriteProto(w io.Writer, result ProjectStructure) error {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(projectToProto(result))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadProto decodes a graph written by WriteProto.
+func ReadProto(r io.Reader) (ProjectStructure, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ProjectStructure{}, err
+	}
+	var msg codegraphpb.ProjectStructure
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return ProjectStructure{}, err
+	}
+	if err := checkSchemaVersion(int(msg.GetSchemaVersion())); err != nil {
+		return ProjectStructure{}, err
+	}
+	return projectFromProto(&msg), nil
+}
+
+func projectToProto(result ProjectStructure) *codegraphpb.ProjectStructure {
+	msg := &codegraphpb.ProjectStructure{
+		SchemaVersion: SchemaVersion,
+		Project:       make(map[string]*codegraphpb.PackageInfo, len(result.Project)),
+		CodeGraph:     &codegraphpb.CodeGraph{},
+	}
+	for name, pkg := range result.Project {
+		modules := make(map[string]*codegraphpb.ModuleInfo, len(pkg.Modules))
+		for path, m := range pkg.Modules {
+			modules[path] = moduleToProto(m)
+		}
+		msg.Project[name] = &codegraphpb.PackageInfo{Module: pkg.Module, Modules: modules}
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		msg.CodeGraph.Nodes = append(msg.CodeGraph.Nodes, NodeToProto(n))
+	}
+	for _, e := range result.CodeGraph.Edges {
+		msg.CodeGraph.Edges = append(msg.CodeGraph.Edges, EdgeToProto(e))
+	}
+	return msg
+}
+
+func moduleToProto(m ModuleInfo) *codegraphpb.ModuleInfo {
+	msg := &codegraphpb.ModuleInfo{
+		Package:      m.Package,
+		Functions:    functionsToProto(m.Functions),
+		Dependencies: m.Dependencies,
+		Lines:        int32(m.Lines),
+	}
+	for _, line := range m.ImportLines {
+		msg.ImportLines = append(msg.ImportLines, int32(line))
+	}
+	for _, s := range m.Structs {
+		st := &codegraphpb.StructInfo{
+			Name:      s.Name,
+			Functions: functionsToProto(s.Functions),
+			Comment:   s.Comment,
+			Id:        s.ID,
+			Doc:       docToProto(s.Doc),
+		}
+		for _, p := range s.Properties {
+			st.Properties = append(st.Properties, &codegraphpb.PropertyInfo{
+				Name:     p.Name,
+				Type:     p.Type,
+				Comment:  p.Comment,
+				Doc:      docToProto(p.Doc),
+				Tag:      p.Tag,
+				Tags:     p.Tags,
+				Exported: p.Exported,
+				Embedded: p.Embedded,
+			})
+		}
+		msg.Structs = append(msg.Structs, st)
+	}
+	for _, i := range m.Interfaces {
+		msg.Interfaces = append(msg.Interfaces, &codegraphpb.InterfaceInfo{
+			Name:      i.Name,
+			Functions: functionsToProto(i.Functions),
+			Comment:   i.Comment,
+			Id:        i.ID,
+			Doc:       docToProto(i.Doc),
+		})
+	}
+	for _, t := range m.Types {
+		msg.Types = append(msg.Types, &codegraphpb.TypeInfo{
+			Name:      t.Name,
+			Type:      t.Type,
+			Alias:     t.Alias,
+			Functions: functionsToProto(t.Functions),
+			Comment:   t.Comment,
+			Id:        t.ID,
+			Doc:       docToProto(t.Doc),
+		})
+	}
+	for _, c := range m.Constants {
+		msg.Constants = append(msg.Constants, &codegraphpb.ConstantInfo{Name: c.Name, Type: c.Type, Value: c.Value, Id: c.ID,
+			Comment: c.Comment, Doc: docToProto(c.Doc)})
+	}
+	for _, v := range m.Variables {
+		msg.Variables = append(msg.Variables, &codegraphpb.VariableInfo{Name: v.Name, Type: v.Type, Value: v.Value, Id: v.ID,
+			Comment: v.Comment, Doc: docToProto(v.Doc)})
+	}
+	return msg
+}
+
+func functionsToProto(fns []FunctionInfo) []*codegraphpb.FunctionInfo {
+	var out []*codegraphpb.FunctionInfo
+	for _, f := range fns {
+		fn := &codegraphpb.FunctionInfo{
+			Name:            f.Name,
+			ReturnType:      f.ReturnType,
+			Content:         f.Content,
+			Comment:         f.Comment,
+			Id:              f.ID,
+			Package:         f.Package,
+			FilePath:        f.FilePath,
+			Doc:             docToProto(f.Doc),
+			PointerReceiver: f.PointerReceiver,
+		}
+		for _, p := range f.Parameters {
+			fn.Parameters = append(fn.Parameters, &codegraphpb.ParameterInfo{Name: p.Name, Type: p.Type})
+		}
+		out = append(out, fn)
+	}
+	return out
+}
+
+// NodeToProto converts a node to its protocol buffer message.
+func NodeToProto(n Node) *codegraphpb.Node {
+	return &codegraphpb.Node{
+		Id:         n.ID,
+		Type:       n.Type,
+		Name:       n.Name,
+		Package:    n.Package,
+		File:       n.File,
+		Receiver:   n.Receiver,
+		Line:       int32(n.Line),
+		Column:     int32(n.Column),
+		Metrics:    metricsToProto(n.Metrics),
+		Deprecated: n.Deprecated,
+		Doc:        docToProto(n.Doc),
+	}
+}
+
+func docToProto(d *Doc) *codegraphpb.Doc {
+	if d == nil {
+		return nil
+	}
+	msg := &codegraphpb.Doc{Deprecated: d.Deprecated, Links: d.Links}
+	for _, b := range d.Blocks {
+		msg.Blocks = append(msg.Blocks, &codegraphpb.DocBlock{Kind: b.Kind, Text: b.Text, Items: b.Items})
+	}
+	return msg
+}
+
+func metricsToProto(m *Metrics) *codegraphpb.Metrics {
+	if m == nil {
+		return nil
+	}
+	return &codegraphpb.Metrics{
+		Cyclomatic: int32(m.Cyclomatic),
+		Cognitive:  int32(m.Cognitive),
+		Lines:      int32(m.Lines),
+		Params:     int32(m.Params),
+		Returns:    int32(m.Returns),
+		Nesting:    int32(m.Nesting),
+	}
+}
+
+// EdgeToProto converts an edge to its protocol buffer message.
+func EdgeToProto(e Edge) *codegraphpb.Edge {
+	return &codegraphpb.Edge{
+		From:     e.From,
+		To:       e.To,
+		Relation: e.Relation,
+		Line:     int32(e.Line),
+		Column:   int32(e.Column),
+	}
+}
+
+func projectFromProto(msg *codegraphpb.ProjectStructure) ProjectStructure {
+	result := ProjectStructure{
+		SchemaVersion: int(msg.GetSchemaVersion()),
+		Project:       make(map[string]PackageInfo, len(msg.GetProject())),
+		CodeGraph: CodeGraph{
+			Nodes: []Node{},
+			Edges: []Edge{},
+		},
+	}
+	for name, pkg := range msg.GetProject() {
+		modules := make(map[string]ModuleInfo, len(pkg.GetModules()))
+		for path, m := range pkg.GetModules() {
+			modules[path] = moduleFromProto(m)
+		}
+		result.Project[name] = PackageInfo{Module: pkg.GetModule(), Modules: modules}
+	}
+	for _, n := range msg.GetCodeGraph().GetNodes() {
+		result.CodeGraph.Nodes = append(result.CodeGraph.Nodes, nodeFromProto(n))
+	}
+	for _, e := range msg.GetCodeGraph().GetEdges() {
+		result.CodeGraph.Edges = append(result.CodeGraph.Edges, edgeFromProto(e))
+	}
+	return result
+}
+
+// moduleFromProto converts back to ModuleInfo, using empty rather than nil
+// slices as processGoFile does so JSON output stays identical.
+func moduleFromProto(msg *codegraphpb.ModuleInfo) ModuleInfo {
+	m := ModuleInfo{
+		Package:      msg.GetPackage(),
+		Structs:      []StructInfo{},
+		Functions:    functionsFromProto(msg.GetFunctions()),
+		Interfaces:   []InterfaceInfo{},
+		Types:        []TypeInfo{},
+		Dependencies: append([]string{}, msg.GetDependencies()...),
+		Constants:    []ConstantInfo{},
+		Variables:    []VariableInfo{},
+		Lines:        int(msg.GetLines()),
+	}
+	for _, line := range msg.GetImportLines() {
+		m.ImportLines = append(m.ImportLines, int(line))
+	}
+	if m.Functions == nil {
+		m.Functions = []FunctionInfo{}
+	}
+	for _, s := range msg.GetStructs() {
+		st := StructInfo{
+			Name:       s.GetName(),
+			Functions:  functionsFromProto(s.GetFunctions()),
+			Properties: []PropertyInfo{},
+			Comment:    s.GetComment(),
+			Doc:        docFromProto(s.GetDoc()),
+			ID:         s.GetId(),
+		}
+		for _, p := range s.GetProperties() {
+			st.Properties = append(st.Properties, PropertyInfo{
+				Name:     p.GetName(),
+				Type:     p.GetType(),
+				Tag:      p.GetTag(),
+				Tags:     p.GetTags(),
+				Exported: p.GetExported(),
+				Embedded: p.GetEmbedded(),
+				Comment:  p.GetComment(),
+				Doc:      docFromProto(p.GetDoc()),
+			})
+		}
+		m.Structs = append(m.Structs, st)
+	}
+	for _, i := range msg.GetInterfaces() {
+		iface := InterfaceInfo{
+			Name:      i.GetName(),
+			Functions: functionsFromProto(i.GetFunctions()),
+			Comment:   i.GetComment(),
+			Doc:       docFromProto(i.GetDoc()),
+			ID:        i.GetId(),
+		}
+		if iface.Functions == nil {
+			iface.Functions = []FunctionInfo{}
+		}
+		m.Interfaces = append(m.Interfaces, iface)
+	}
+	for _, t := range msg.GetTypes() {
+		m.Types = append(m.Types, TypeInfo{
+			Name:      t.GetName(),
+			Type:      t.GetType(),
+			Alias:     t.GetAlias(),
+			Functions: functionsFromProto(t.GetFunctions()),
+			Comment:   t.GetComment(),
+			Doc:       docFromProto(t.GetDoc()),
+			ID:        t.GetId(),
+		})
+	}
+	for _, c := range msg.GetConstants() {
+		m.Constants = append(m.Constants, ConstantInfo{Name: c.GetName(), Type: c.GetType(), Value: c.GetValue(),
+			Comment: c.GetComment(), Doc: docFromProto(c.GetDoc()), ID: c.GetId()})
+	}
+	for _, v := range msg.GetVariables() {
+		m.Variables = append(m.Variables, VariableInfo{Name: v.GetName(), Type: v.GetType(), Value: v.GetValue(),
+			Comment: v.GetComment(), Doc: docFromProto(v.GetDoc()), ID: v.GetId()})
+	}
+	return m
+}
+
+func functionsFromProto(msgs []*codegraphpb.FunctionInfo) []FunctionInfo {
+	var out []FunctionInfo
+	for _, f := range msgs {
+		fn := FunctionInfo{
+			Name:            f.GetName(),
+			ReturnType:      f.GetReturnType(),
+			Content:         f.GetContent(),
+			Comment:         f.GetComment(),
+			Doc:             docFromProto(f.GetDoc()),
+			ID:              f.GetId(),
+			Package:         f.GetPackage(),
+			FilePath:        f.GetFilePath(),
+			PointerReceiver: f.GetPointerReceiver(),
+		}
+		for _, p := range f.GetParameters() {
+			fn.Parameters = append(fn.Parameters, ParameterInfo{Name: p.GetName(), Type: p.GetType()})
+		}
+		out = append(out, fn)
+	}
+	return out
+}
+
+func nodeFromProto(msg *codegraphpb.Node) Node {
+	return Node{
+		ID:         msg.GetId(),
+		Type:       msg.GetType(),
+		Name:       msg.GetName(),
+		Package:    msg.GetPackage(),
+		File:       msg.GetFile(),
+		Receiver:   msg.GetReceiver(),
+		Line:       int(msg.GetLine()),
+		Column:     int(msg.GetColumn()),
+		Metrics:    metricsFromProto(msg.GetMetrics()),
+		Deprecated: msg.GetDeprecated(),
+		Doc:        docFromProto(msg.GetDoc()),
+	}
+}
+
+func docFromProto(msg *codegraphpb.Doc) *Doc {
+	if msg == nil {
+		return nil
+	}
+	d := &Doc{Blocks: []DocBlock{}, Deprecated: msg.GetDeprecated(), Links: msg.GetLinks()}
+	for _, b := range msg.GetBlocks() {
+		d.Blocks = append(d.Blocks, DocBlock{Kind: b.GetKind(), Text: b.GetText(), Items: b.GetItems()})
+	}
+	return d
+}
+
+func metricsFromProto(msg *codegraphpb.Metrics) *Metrics {
+	if msg == nil {
+		return nil
+	}
+	return &Metrics{
+		Cyclomatic: int(msg.GetCyclomatic()),
+		Cognitive:  int(msg.GetCognitive()),
+		Lines:      int(msg.GetLines()),
+		Params:     int(msg.GetParams()),
+		Returns:    int(msg.GetReturns()),
+		Nesting:    int(msg.GetNesting()),
+	}
+}
+
+func edgeFromProto(msg *codegraphpb.Edge) Edge {
+	return Edge{
+		From:     msg.GetFrom(),
+		To:       msg.GetTo(),
+		Relation: msg.GetRelation(),
+		Line:     int(msg.GetLine()),
+		Column:   int(msg.GetColumn()),
+	}
+}
@@ -0,0 +1,641 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The code graph query language is a small subset of Cypher:
+//
+//	MATCH (h:function {package: "handlers"})-[:calls*1..4]->(q:function)
+//	WHERE q.package = "sql" AND h.name =~ "Handle.*"
+//	RETURN DISTINCT h, q.name AS target
+//	ORDER BY target LIMIT 20
+//
+// Node patterns filter on node type (":struct", ":function|method") and on
+// property equality ({name: "Run"}). Relationship patterns select edge
+// relations (":calls|uses"), a direction (-[]->, <-[]-, -[]-) and an optional
+// hop range (*, *2, *1..3, *..3). Variable-length relationships match each
+// reachable end node once. WHERE supports =, <>, <, <=, >, >=, =~ (full
+// regular expression match), CONTAINS, STARTS WITH, ENDS WITH, AND, OR and
+// NOT. RETURN takes variables, properties, count(*) and count([DISTINCT] x).
+//
+// Node properties are id, type, name, package, file, receiver, line, column,
+// deprecated and qname (the qualified name); edges have from, to, relation,
+// line and column.
+
+// Query is a parsed graph query, ready to run against an Index.
+type Query struct {
+	patterns  []qlPattern
+	where     qlExpr
+	distinct  bool
+	returnAll bool
+	returns   []qlProjection
+	orderBy   []qlOrder
+	limit     int
+}
+
+type qlPattern struct {
+	nodes []qlNodePattern
+	rels  []qlRelPattern // rels[i] connects nodes[i] and nodes[i+1]
+}
+
+type qlNodePattern struct {
+	variable string
+	types    []string
+	props    map[string]any
+}
+
+// Relationship directions within a pattern
+const (
+	qlOutgoing = iota // (a)-[]->(b)
+	qlIncoming        // (a)<-[]-(b)
+	qlEither          // (a)-[]-(b)
+)
+
+type qlRelPattern struct {
+	variable  string
+	relations []string
+	direction int
+	varLength bool
+	min, max  int // Hop range for variable-length relationships; max 0 is unbounded
+}
+
+type qlProjection struct {
+	expr     qlExpr
+	count    bool // count(expr) or count(*) when expr is nil
+	distinct bool // count(DISTINCT expr)
+	name     string
+}
+
+type qlOrder struct {
+	expr qlExpr
+	desc bool
+}
+
+// Expressions
+type (
+	qlExpr interface{}
+
+	qlLiteral struct{ value any }
+	qlVar     struct{ name string }
+	qlProp    struct{ variable, prop string }
+	qlNot     struct{ x qlExpr }
+	qlBinary  struct {
+		op          string
+		left, right qlExpr
+	}
+)
+
+// ---- Lexer ----------------------------------------------------------------
+
+type qlTokenKind int
+
+const (
+	qlEOF qlTokenKind = iota
+	qlIdent
+	qlString
+	qlNumber
+	qlPunct
+)
+
+type qlToken struct {
+	kind  qlTokenKind
+	text  string // Identifier, punctuation or unquoted string value
+	start int    // Byte offsets into the source
+	end   int
+}
+
+// qlPunctuation lists multi-character operators before their prefixes
+var qlPunctuation = []string{"->", "<-", "<>", "!=", "=~", "<=", ">=", "..",
+	"(", ")", "[", "]", "{", "}", ":", ",", ".", "*", "-", "=", "<", ">", "|"}
+
+func lexQuery(src string) ([]qlToken, error) {
+	var tokens []qlToken
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && rune(src[j]) != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				b.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, qlToken{kind: qlString, text: b.String(), start: i, end: j + 1})
+			i = j + 1
+		case c == '_' || unicode.IsLetter(c):
+			j := i
+			for j < len(src) && (src[j] == '_' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, qlToken{kind: qlIdent, text: src[i:j], start: i, end: j})
+			i = j
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(src) && unicode.IsDigit(rune(src[j])) {
+				j++
+			}
+			tokens = append(tokens, qlToken{kind: qlNumber, text: src[i:j], start: i, end: j})
+			i = j
+		default:
+			matched := false
+			for _, p := range qlPunctuation {
+				if strings.HasPrefix(src[i:], p) {
+					tokens = append(tokens, qlToken{kind: qlPunct, text: p, start: i, end: i + len(p)})
+					i += len(p)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+			}
+		}
+	}
+	return append(tokens, qlToken{kind: qlEOF, start: len(src), end: len(src)}), nil
+}
+
+// ---- Parser ---------------------------------------------------------------
+
+type qlParser struct {
+	src    string
+	tokens []qlToken
+	pos    int
+}
+
+// ParseQuery parses a query written in the code graph query language.
+func ParseQuery(src string) (*Query, error) {
+	tokens, err := lexQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &qlParser{src: src, tokens: tokens}
+	q, err := p.query()
+	if err != nil {
+		return nil, fmt.Errorf("parsing query: %w", err)
+	}
+	return q, nil
+}
+
+func (p *qlParser) peek() qlToken { return p.tokens[p.pos] }
+
+func (p *qlParser) next() qlToken {
+	t := p.tokens[p.pos]
+	if t.kind != qlEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword reports whether the current token is the given keyword.
+func (p *qlParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == qlIdent && strings.EqualFold(t.text, kw)
+}
+
+func (p *qlParser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *qlParser) accept(punct string) bool {
+	t := p.peek()
+	if t.kind == qlPunct && t.text == punct {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *qlParser) expect(punct string) error {
+	if !p.accept(punct) {
+		return p.errorf("expected %q", punct)
+	}
+	return nil
+}
+
+func (p *qlParser) errorf(format string, args ...any) error {
+	t := p.peek()
+	found := "end of query"
+	if t.kind != qlEOF {
+		found = strconv.Quote(p.src[t.start:t.end])
+	}
+	return fmt.Errorf("%s at offset %d, found %s", fmt.Sprintf(format, args...), t.start, found)
+}
+
+func (p *qlParser) ident() (string, error) {
+	t := p.peek()
+	if t.kind != qlIdent {
+		return "", p.errorf("expected a name")
+	}
+	p.pos++
+	return t.text, nil
+}
+
+func (p *qlParser) query() (*Query, error) {
+	q := &Query{}
+	if !p.acceptKeyword("MATCH") {
+		return nil, p.errorf("expected MATCH")
+	}
+	for {
+		pat, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+		q.patterns = append(q.patterns, pat)
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if p.acceptKeyword("WHERE") {
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		q.where = expr
+	}
+
+	if !p.acceptKeyword("RETURN") {
+		return nil, p.errorf("expected RETURN")
+	}
+	q.distinct = p.acceptKeyword("DISTINCT")
+	if p.accept("*") {
+		q.returnAll = true
+	} else {
+		for {
+			proj, err := p.projection()
+			if err != nil {
+				return nil, err
+			}
+			q.returns = append(q.returns, proj)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeyword("ORDER") {
+		if !p.acceptKeyword("BY") {
+			return nil, p.errorf("expected BY")
+		}
+		for {
+			expr, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			order := qlOrder{expr: expr}
+			if p.acceptKeyword("DESC") {
+				order.desc = true
+			} else {
+				p.acceptKeyword("ASC")
+			}
+			q.orderBy = append(q.orderBy, order)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeyword("LIMIT") {
+		t := p.next()
+		if t.kind != qlNumber {
+			return nil, p.errorf("expected a number after LIMIT")
+		}
+		q.limit, _ = strconv.Atoi(t.text)
+	}
+
+	if p.peek().kind != qlEOF {
+		return nil, p.errorf("unexpected input")
+	}
+	return q, nil
+}
+
+func (p *qlParser) pattern() (qlPattern, error) {
+	var pat qlPattern
+	node, err := p.nodePattern()
+	if err != nil {
+		return pat, err
+	}
+	pat.nodes = append(pat.nodes, node)
+	for {
+		t := p.peek()
+		if t.kind != qlPunct || (t.text != "-" && t.text != "<-") {
+			return pat, nil
+		}
+		rel, err := p.relPattern()
+		if err != nil {
+			return pat, err
+		}
+		node, err := p.nodePattern()
+		if err != nil {
+			return pat, err
+		}
+		pat.rels = append(pat.rels, rel)
+		pat.nodes = append(pat.nodes, node)
+	}
+}
+
+func (p *qlParser) nodePattern() (qlNodePattern, error) {
+	var np qlNodePattern
+	if err := p.expect("("); err != nil {
+		return np, err
+	}
+	if p.peek().kind == qlIdent {
+		np.variable = p.next().text
+	}
+	if p.accept(":") {
+		types, err := p.alternatives()
+		if err != nil {
+			return np, err
+		}
+		np.types = types
+	}
+	if p.peek().kind == qlPunct && p.peek().text == "{" {
+		props, err := p.properties()
+		if err != nil {
+			return np, err
+		}
+		np.props = props
+	}
+	return np, p.expect(")")
+}
+
+// alternatives parses "a|b|c" after a colon.
+func (p *qlParser) alternatives() ([]string, error) {
+	var names []string
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.accept("|") {
+			return names, nil
+		}
+		p.accept(":") // Cypher also allows ":a|:b"
+	}
+}
+
+func (p *qlParser) properties() (map[string]any, error) {
+	props := make(map[string]any)
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.accept("}") {
+		key, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+		props[key] = value
+		if !p.accept(",") {
+			if err := p.expect("}"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return props, nil
+}
+
+func (p *qlParser) literal() (any, error) {
+	t := p.next()
+	switch t.kind {
+	case qlString:
+		return t.text, nil
+	case qlNumber:
+		n, err := strconv.Atoi(t.text)
+		return n, err
+	case qlIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	p.pos--
+	return nil, p.errorf("expected a literal value")
+}
+
+// relPattern parses -[...]->, <-[...]-, -[...]- and the bracketless forms
+// -->, <-- and --.
+func (p *qlParser) relPattern() (qlRelPattern, error) {
+	rel := qlRelPattern{direction: qlEither, min: 1, max: 1}
+	incoming := p.accept("<-")
+	if !incoming {
+		if err := p.expect("-"); err != nil {
+			return rel, err
+		}
+	}
+
+	if p.accept("[") {
+		if p.peek().kind == qlIdent {
+			rel.variable = p.next().text
+		}
+		if p.accept(":") {
+			relations, err := p.alternatives()
+			if err != nil {
+				return rel, err
+			}
+			rel.relations = relations
+		}
+		if p.accept("*") {
+			rel.varLength = true
+			rel.min, rel.max = 1, 0
+			if p.peek().kind == qlNumber {
+				rel.min, _ = strconv.Atoi(p.next().text)
+				rel.max = rel.min
+			}
+			if p.accept("..") {
+				rel.max = 0
+				if p.peek().kind == qlNumber {
+					rel.max, _ = strconv.Atoi(p.next().text)
+				}
+			}
+			if rel.max != 0 && rel.max < rel.min {
+				return rel, p.errorf("empty hop range *%d..%d", rel.min, rel.max)
+			}
+			if rel.variable != "" {
+				return rel, p.errorf("variable-length relationships cannot be bound to a variable")
+			}
+		}
+		if err := p.expect("]"); err != nil {
+			return rel, err
+		}
+	}
+
+	switch {
+	case incoming:
+		rel.direction = qlIncoming
+		return rel, p.expect("-")
+	case p.accept("->"):
+		rel.direction = qlOutgoing
+		return rel, nil
+	default:
+		return rel, p.expect("-")
+	}
+}
+
+func (p *qlParser) projection() (qlProjection, error) {
+	start := p.peek().start
+	var proj qlProjection
+	if p.isKeyword("count") && p.tokens[p.pos+1].kind == qlPunct && p.tokens[p.pos+1].text == "(" {
+		p.pos += 2
+		proj.count = true
+		if !p.accept("*") {
+			proj.distinct = p.acceptKeyword("DISTINCT")
+			expr, err := p.expr()
+			if err != nil {
+				return proj, err
+			}
+			proj.expr = expr
+		}
+		if err := p.expect(")"); err != nil {
+			return proj, err
+		}
+	} else {
+		expr, err := p.expr()
+		if err != nil {
+			return proj, err
+		}
+		proj.expr = expr
+	}
+	proj.name = strings.TrimSpace(p.src[start:p.tokens[p.pos-1].end])
+	if p.acceptKeyword("AS") {
+		alias, err := p.ident()
+		if err != nil {
+			return proj, err
+		}
+		proj.name = alias
+	}
+	return proj, nil
+}
+
+func (p *qlParser) expr() (qlExpr, error) {
+	left, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.andExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = qlBinary{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *qlParser) andExpr() (qlExpr, error) {
+	left, err := p.notExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.notExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = qlBinary{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *qlParser) notExpr() (qlExpr, error) {
+	if p.acceptKeyword("NOT") {
+		x, err := p.notExpr()
+		if err != nil {
+			return nil, err
+		}
+		return qlNot{x: x}, nil
+	}
+	return p.comparison()
+}
+
+func (p *qlParser) comparison() (qlExpr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	var op string
+	t := p.peek()
+	switch {
+	case t.kind == qlPunct && (t.text == "=" || t.text == "<>" || t.text == "!=" || t.text == "=~" ||
+		t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+		p.pos++
+		op = t.text
+		if op == "!=" {
+			op = "<>"
+		}
+	case p.acceptKeyword("CONTAINS"):
+		op = "CONTAINS"
+	case p.isKeyword("STARTS") || p.isKeyword("ENDS"):
+		op = strings.ToUpper(p.next().text) + " WITH"
+		if !p.acceptKeyword("WITH") {
+			return nil, p.errorf("expected WITH")
+		}
+	default:
+		return left, nil
+	}
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return qlBinary{op: op, left: left, right: right}, nil
+}
+
+func (p *qlParser) operand() (qlExpr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == qlPunct && t.text == "(":
+		p.pos++
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case t.kind == qlString || t.kind == qlNumber:
+		v, err := p.literal()
+		return qlLiteral{value: v}, err
+	case t.kind == qlIdent:
+		switch strings.ToLower(t.text) {
+		case "true", "false", "null":
+			v, err := p.literal()
+			return qlLiteral{value: v}, err
+		}
+		p.pos++
+		if p.accept(".") {
+			prop, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			return qlProp{variable: t.text, prop: prop}, nil
+		}
+		return qlVar{name: t.text}, nil
+	}
+	return nil, p.errorf("expected an expression")
+}
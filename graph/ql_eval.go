@@ -0,0 +1,732 @@
+package graph
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// QueryResult holds the rows a query returned. Values are Node, Edge,
+// string, int, bool or nil.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// RunQuery parses src and runs it against g.
+func RunQuery(g CodeGraph, src string) (*QueryResult, error) {
+	q, err := ParseQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	return q.Run(NewIndex(g))
+}
+
+// binding maps pattern variables to the Node or Edge they matched
+type binding map[string]any
+
+func (b binding) with(name string, value any) binding {
+	out := make(binding, len(b)+1)
+	for k, v := range b {
+		out[k] = v
+	}
+	if name != "" {
+		out[name] = value
+	}
+	return out
+}
+
+type qlEvaluator struct {
+	idx     *Index
+	ids     []string // Node IDs in order, for deterministic results
+	regexps map[string]*regexp.Regexp
+}
+
+// Run evaluates the query against idx.
+func (q *Query) Run(idx *Index) (*QueryResult, error) {
+	ev := &qlEvaluator{idx: idx, regexps: make(map[string]*regexp.Regexp)}
+	for id := range idx.Nodes {
+		ev.ids = append(ev.ids, id)
+	}
+	sort.Strings(ev.ids)
+
+	bindings := []binding{{}}
+	for _, pat := range q.patterns {
+		var next []binding
+		for _, b := range bindings {
+			matched, err := ev.matchPattern(pat, b)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, matched...)
+		}
+		bindings = next
+	}
+
+	if q.where != nil {
+		var kept []binding
+		for _, b := range bindings {
+			v, err := ev.eval(q.where, b)
+			if err != nil {
+				return nil, err
+			}
+			if v == true {
+				kept = append(kept, b)
+			}
+		}
+		bindings = kept
+	}
+
+	projections := q.returns
+	if q.returnAll {
+		projections = allVariables(q.patterns)
+	}
+	res, err := ev.project(projections, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if q.distinct {
+		res.Rows = distinctRows(res.Rows)
+	}
+	if err := ev.order(q, projections, res); err != nil {
+		return nil, err
+	}
+	if q.limit > 0 && len(res.Rows) > q.limit {
+		res.Rows = res.Rows[:q.limit]
+	}
+	return res, nil
+}
+
+// allVariables returns a projection of every named variable, in order of
+// first appearance, for RETURN *.
+func allVariables(patterns []qlPattern) []qlProjection {
+	var out []qlProjection
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			out = append(out, qlProjection{expr: qlVar{name: name}, name: name})
+		}
+	}
+	for _, pat := range patterns {
+		for i, n := range pat.nodes {
+			add(n.variable)
+			if i < len(pat.rels) {
+				add(pat.rels[i].variable)
+			}
+		}
+	}
+	return out
+}
+
+// ---- Pattern matching -----------------------------------------------------
+
+func (ev *qlEvaluator) matchPattern(pat qlPattern, b binding) ([]binding, error) {
+	var out []binding
+	var extend func(i int, cur string, b binding)
+	extend = func(i int, cur string, b binding) {
+		if i == len(pat.rels) {
+			out = append(out, b)
+			return
+		}
+		rel, np := pat.rels[i], pat.nodes[i+1]
+		if rel.varLength {
+			for _, id := range ev.reachable(cur, rel) {
+				if nb, ok := ev.bindNode(np, id, b); ok {
+					extend(i+1, id, nb)
+				}
+			}
+			return
+		}
+		for _, e := range ev.relEdges(cur, rel) {
+			if rel.variable != "" {
+				if bound, ok := b[rel.variable]; ok && bound != e {
+					continue
+				}
+			}
+			other := e.To
+			if other == cur && rel.direction != qlOutgoing {
+				other = e.From
+			}
+			if nb, ok := ev.bindNode(np, other, b.with(rel.variable, e)); ok {
+				extend(i+1, other, nb)
+			}
+		}
+	}
+
+	first := pat.nodes[0]
+	candidates := ev.ids
+	if n, ok := b[first.variable].(Node); ok {
+		candidates = []string{n.ID}
+	} else if _, ok := b[first.variable]; ok {
+		return nil, fmt.Errorf("variable %s is bound to a relationship, not a node", first.variable)
+	}
+	for _, id := range candidates {
+		if nb, ok := ev.bindNode(first, id, b); ok {
+			extend(0, id, nb)
+		}
+	}
+	return out, nil
+}
+
+// bindNode checks node id against np and binds np's variable, rejecting a
+// node that conflicts with an earlier binding of the same variable.
+func (ev *qlEvaluator) bindNode(np qlNodePattern, id string, b binding) (binding, bool) {
+	n, ok := ev.idx.Nodes[id]
+	if !ok {
+		return nil, false
+	}
+	if bound, ok := b[np.variable]; ok {
+		if bn, isNode := bound.(Node); !isNode || bn.ID != id {
+			return nil, false
+		}
+	}
+	if len(np.types) > 0 && !containsString(np.types, n.Type) {
+		return nil, false
+	}
+	for key, want := range np.props {
+		got, err := nodeProperty(n, key)
+		if err != nil || !valuesEqual(got, want) {
+			return nil, false
+		}
+	}
+	if _, ok := b[np.variable]; ok || np.variable == "" {
+		return b, true
+	}
+	return b.with(np.variable, n), true
+}
+
+// relEdges returns the edges at id matching a single-hop relationship.
+func (ev *qlEvaluator) relEdges(id string, rel qlRelPattern) []Edge {
+	var candidates []Edge
+	if rel.direction != qlIncoming {
+		candidates = append(candidates, ev.idx.Out[id]...)
+	}
+	if rel.direction != qlOutgoing {
+		candidates = append(candidates, ev.idx.In[id]...)
+	}
+	var out []Edge
+	for _, e := range candidates {
+		if len(rel.relations) == 0 || containsString(rel.relations, e.Relation) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// reachable returns the nodes reachable from id by a walk whose length is
+// within rel's hop range, each once, in breadth-first order.
+func (ev *qlEvaluator) reachable(id string, rel qlRelPattern) []string {
+	// Walks are explored as (node, hops) states. Without an upper bound any
+	// walk of at least min hops qualifies, so hops are capped at min to keep
+	// the state space finite.
+	type state struct {
+		id   string
+		hops int
+	}
+	var out []string
+	emitted := make(map[string]bool)
+	seen := map[state]bool{{id, 0}: true}
+	queue := []state{{id, 0}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.hops >= rel.min && !emitted[cur.id] {
+			emitted[cur.id] = true
+			out = append(out, cur.id)
+		}
+		if rel.max > 0 && cur.hops >= rel.max {
+			continue
+		}
+		for _, e := range ev.relEdges(cur.id, rel) {
+			other := e.To
+			if other == cur.id && rel.direction != qlOutgoing {
+				other = e.From
+			}
+			next := state{other, cur.hops + 1}
+			if rel.max == 0 && next.hops > rel.min {
+				next.hops = rel.min
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return out
+}
+
+// ---- Expressions ----------------------------------------------------------
+
+func (ev *qlEvaluator) eval(x qlExpr, b binding) (any, error) {
+	switch x := x.(type) {
+	case qlLiteral:
+		return x.value, nil
+	case qlVar:
+		v, ok := b[x.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %s", x.name)
+		}
+		return v, nil
+	case qlProp:
+		v, ok := b[x.variable]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %s", x.variable)
+		}
+		switch v := v.(type) {
+		case Node:
+			return nodeProperty(v, x.prop)
+		case Edge:
+			return edgeProperty(v, x.prop)
+		}
+		return nil, nil
+	case qlNot:
+		v, err := ev.eval(x.x, b)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		return v != true, nil
+	case qlBinary:
+		return ev.evalBinary(x, b)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", x)
+}
+
+func (ev *qlEvaluator) evalBinary(x qlBinary, b binding) (any, error) {
+	left, err := ev.eval(x.left, b)
+	if err != nil {
+		return nil, err
+	}
+	// Short-circuit the logical operators
+	switch x.op {
+	case "AND":
+		if left == false {
+			return false, nil
+		}
+	case "OR":
+		if left == true {
+			return true, nil
+		}
+	}
+	right, err := ev.eval(x.right, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x.op {
+	case "AND":
+		return left == true && right == true, nil
+	case "OR":
+		return left == true || right == true, nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	switch x.op {
+	case "=":
+		return valuesEqual(left, right), nil
+	case "<>":
+		return !valuesEqual(left, right), nil
+	case "<":
+		return compareValues(left, right) < 0, nil
+	case "<=":
+		return compareValues(left, right) <= 0, nil
+	case ">":
+		return compareValues(left, right) > 0, nil
+	case ">=":
+		return compareValues(left, right) >= 0, nil
+	}
+
+	l, r := valueString(left), valueString(right)
+	switch x.op {
+	case "=~":
+		re, err := ev.regexp(r)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(l), nil
+	case "CONTAINS":
+		return strings.Contains(l, r), nil
+	case "STARTS WITH":
+		return strings.HasPrefix(l, r), nil
+	case "ENDS WITH":
+		return strings.HasSuffix(l, r), nil
+	}
+	return nil, fmt.Errorf("unsupported operator %s", x.op)
+}
+
+// regexp compiles pattern anchored at both ends, as =~ matches the whole
+// string.
+func (ev *qlEvaluator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := ev.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	ev.regexps[pattern] = re
+	return re, nil
+}
+
+func nodeProperty(n Node, prop string) (any, error) {
+	switch prop {
+	case "id":
+		return n.ID, nil
+	case "type":
+		return n.Type, nil
+	case "name":
+		return n.Name, nil
+	case "package":
+		return n.Package, nil
+	case "file":
+		return n.File, nil
+	case "receiver":
+		return n.Receiver, nil
+	case "line":
+		return n.Line, nil
+	case "column":
+		return n.Column, nil
+	case "qname":
+		return n.QualifiedName(), nil
+	case "deprecated":
+		return n.Deprecated, nil
+	}
+	return nil, fmt.Errorf("unknown node property %q", prop)
+}
+
+func edgeProperty(e Edge, prop string) (any, error) {
+	switch prop {
+	case "from":
+		return e.From, nil
+	case "to":
+		return e.To, nil
+	case "relation", "type":
+		return e.Relation, nil
+	case "line":
+		return e.Line, nil
+	case "column":
+		return e.Column, nil
+	}
+	return nil, fmt.Errorf("unknown relationship property %q", prop)
+}
+
+func valuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case Node:
+		bn, ok := b.(Node)
+		return ok && a.ID == bn.ID
+	case Edge:
+		return a == b
+	}
+	if _, ok := b.(Node); ok {
+		return false
+	}
+	return compareValues(a, b) == 0
+}
+
+// compareValues orders ints numerically and everything else by its string
+// form.
+func compareValues(a, b any) int {
+	ai, aok := a.(int)
+	bi, bok := b.(int)
+	if aok && bok {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(valueString(a), valueString(b))
+}
+
+// valueString renders a value for comparison and table output. Nodes print
+// as their qualified name and edges as "from -[relation]-> to".
+func valueString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case Node:
+		return v.QualifiedName()
+	case Edge:
+		return v.From + " -[" + v.Relation + "]-> " + v.To
+	}
+	return fmt.Sprint(v)
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ---- Projection -----------------------------------------------------------
+
+func (ev *qlEvaluator) project(projections []qlProjection, bindings []binding) (*QueryResult, error) {
+	res := &QueryResult{Columns: make([]string, len(projections)), Rows: [][]any{}}
+	aggregate := false
+	for i, p := range projections {
+		res.Columns[i] = p.name
+		aggregate = aggregate || p.count
+	}
+
+	if !aggregate {
+		for _, b := range bindings {
+			row := make([]any, len(projections))
+			for i, p := range projections {
+				v, err := ev.eval(p.expr, b)
+				if err != nil {
+					return nil, err
+				}
+				row[i] = v
+			}
+			res.Rows = append(res.Rows, row)
+		}
+		return res, nil
+	}
+
+	// Group by the non-aggregate columns, keeping groups in first-seen order
+	type group struct {
+		row      []any
+		distinct []map[string]bool
+	}
+	groups := make(map[string]*group)
+	var order []string
+	for _, b := range bindings {
+		key := make([]any, len(projections))
+		for i, p := range projections {
+			if p.count {
+				continue
+			}
+			v, err := ev.eval(p.expr, b)
+			if err != nil {
+				return nil, err
+			}
+			key[i] = v
+		}
+		k := rowKey(key)
+		g, ok := groups[k]
+		if !ok {
+			g = &group{row: key, distinct: make([]map[string]bool, len(projections))}
+			for i, p := range projections {
+				if p.count {
+					g.row[i] = 0
+					g.distinct[i] = make(map[string]bool)
+				}
+			}
+			groups[k] = g
+			order = append(order, k)
+		}
+		for i, p := range projections {
+			if !p.count {
+				continue
+			}
+			if p.expr == nil {
+				g.row[i] = g.row[i].(int) + 1
+				continue
+			}
+			v, err := ev.eval(p.expr, b)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				continue
+			}
+			if p.distinct {
+				vk := rowKey([]any{v})
+				if g.distinct[i][vk] {
+					continue
+				}
+				g.distinct[i][vk] = true
+			}
+			g.row[i] = g.row[i].(int) + 1
+		}
+	}
+	// Counting everything over no matches still yields a single zero row
+	if len(order) == 0 && len(projections) > 0 && allCounts(projections) {
+		row := make([]any, len(projections))
+		for i := range row {
+			row[i] = 0
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	for _, k := range order {
+		res.Rows = append(res.Rows, groups[k].row)
+	}
+	return res, nil
+}
+
+func allCounts(projections []qlProjection) bool {
+	for _, p := range projections {
+		if !p.count {
+			return false
+		}
+	}
+	return true
+}
+
+// rowKey identifies a row for grouping and DISTINCT.
+func rowKey(row []any) string {
+	var b strings.Builder
+	for _, v := range row {
+		switch v := v.(type) {
+		case Node:
+			b.WriteString("n:" + v.ID)
+		case Edge:
+			fmt.Fprintf(&b, "e:%s:%s:%s:%d:%d", v.From, v.To, v.Relation, v.Line, v.Column)
+		default:
+			fmt.Fprintf(&b, "%T:%v", v, v)
+		}
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func distinctRows(rows [][]any) [][]any {
+	seen := make(map[string]bool)
+	out := rows[:0]
+	for _, row := range rows {
+		k := rowKey(row)
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// order sorts the rows by the ORDER BY items, each of which must name a
+// returned column by alias or by its expression.
+func (ev *qlEvaluator) order(q *Query, projections []qlProjection, res *QueryResult) error {
+	if len(q.orderBy) == 0 {
+		return nil
+	}
+	cols := make([]int, len(q.orderBy))
+	for i, o := range q.orderBy {
+		cols[i] = -1
+		for j, p := range projections {
+			if v, ok := o.expr.(qlVar); ok && v.name == p.name {
+				cols[i] = j
+				break
+			}
+			if !p.count && fmt.Sprint(o.expr) == fmt.Sprint(p.expr) {
+				cols[i] = j
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return fmt.Errorf("ORDER BY must refer to a returned column")
+		}
+	}
+	sort.SliceStable(res.Rows, func(a, b int) bool {
+		for i, o := range q.orderBy {
+			c := compareValues(res.Rows[a][cols[i]], res.Rows[b][cols[i]])
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != o.desc
+		}
+		return false
+	})
+	return nil
+}
+
+// ---- Output ---------------------------------------------------------------
+
+// WriteTable prints the result as aligned columns under a header.
+func (r *QueryResult) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.Columns, "\t"))
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = valueString(v)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteList prints one tab-separated row per line without a header.
+func (r *QueryResult) WriteList(w io.Writer) error {
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = valueString(v)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON prints the result as an array of objects keyed by column name.
+func (r *QueryResult) WriteJSON(w io.Writer) error {
+	objects := make([]map[string]any, len(r.Rows))
+	for i, row := range r.Rows {
+		obj := make(map[string]any, len(row))
+		for j, v := range row {
+			obj[r.Columns[j]] = v
+		}
+		objects[i] = obj
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(objects)
+}
+
+// WriteCSV prints the result as CSV with a header row.
+func (r *QueryResult) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.Columns); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = valueString(v)
+		}
+		if err := cw.Write(cells); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// NodeIDs returns the IDs of every node appearing in the result, either
+// directly or as an endpoint of a returned edge.
+func (r *QueryResult) NodeIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, row := range r.Rows {
+		for _, v := range row {
+			switch v := v.(type) {
+			case Node:
+				ids[v.ID] = true
+			case Edge:
+				ids[v.From] = true
+				ids[v.To] = true
+			}
+		}
+	}
+	return ids
+}
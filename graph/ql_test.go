@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunQuery(t *testing.T) {
+	g := analyzeFiles(t, callChain).CodeGraph
+	tests := []struct {
+		query string
+		want  string // As printed by WriteList
+	}{
+		{
+			query: `MATCH (f:function {name: "run"}) RETURN f.qname, f.line`,
+			want:  "main.run\t7\n",
+		},
+		{
+			query: `MATCH (f)-[:calls]->(g) WHERE f.package = "main" RETURN f.name, g.qname ORDER BY f.name, g.qname`,
+			want:  "load\tstore.Put\nmain\tmain.run\nrun\tmain.load\nrun\tmain.save\nsave\tmain.load\n",
+		},
+		{
+			query: `MATCH (f:function)-[:calls*2..3]->(p {name: "Put"}) RETURN DISTINCT f.name ORDER BY f.name`,
+			want:  "main\nrun\nsave\n",
+		},
+		{
+			query: `MATCH (f)<-[:calls]-(caller) RETURN f.name AS callee, count(*) AS n ORDER BY n DESC, callee LIMIT 2`,
+			want:  "load\t2\nPut\t1\n",
+		},
+		{
+			query: `MATCH (f:function) WHERE f.name =~ "[ls].*" AND NOT f.name ENDS WITH "d" RETURN f.name`,
+			want:  "save\n",
+		},
+		{
+			query: `MATCH (f:function|method) WHERE f.name STARTS WITH "ma" OR f.name CONTAINS "u" RETURN f.qname ORDER BY f.qname`,
+			want:  "main.main\nmain.run\nstore.Put\n",
+		},
+		{
+			query: `MATCH (f:function {name: "missing"}) RETURN count(*)`,
+			want:  "0\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			res, err := RunQuery(g, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b strings.Builder
+			if err := res.WriteList(&b); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("rows:\n%s\nwant:\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`RETURN f`, "MATCH"},
+		{`MATCH (f:function)`, "RETURN"},
+		{`MATCH (f)-[:calls*3..1]->(g) RETURN f`, "empty hop range"},
+		{`MATCH (f {name: "x") RETURN f`, "}"},
+		{`MATCH (f) WHERE f.name = RETURN f`, "expected RETURN"},
+		{`MATCH (f) RETURN f LIMIT x`, "LIMIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %v, want one mentioning %q", err, tt.err)
+			}
+		})
+	}
+}
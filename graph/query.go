@@ -0,0 +1,248 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Direction selects which way a traversal follows edges.
+type Direction int
+
+const (
+	Forward  Direction = iota // From → To, e.g. callees
+	Backward                  // To → From, e.g. callers
+)
+
+// TraverseOptions limits a traversal.
+type TraverseOptions struct {
+	Depth     int      // Maximum number of hops; 0 means unlimited
+	Relations []string // Edge relations to follow; empty follows all
+}
+
+func (o TraverseOptions) follows(relation string) bool {
+	if len(o.Relations) == 0 {
+		return true
+	}
+	for _, r := range o.Relations {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
+
+// Step is a node reached during a traversal.
+type Step struct {
+	Node  Node
+	Depth int  // Hops from the nearest root
+	Edge  Edge // Edge the node was reached through; zero for roots
+}
+
+// neighbors returns the edges leaving id in the given direction together
+// with the node at their other end, keeping only the first edge to each
+// neighbor.
+func (idx *Index) neighbors(id string, dir Direction, opts TraverseOptions) ([]Edge, []string) {
+	edges := idx.Out[id]
+	if dir == Backward {
+		edges = idx.In[id]
+	}
+	seen := make(map[string]bool)
+	var kept []Edge
+	var next []string
+	for _, e := range edges {
+		if !opts.follows(e.Relation) {
+			continue
+		}
+		other := e.To
+		if dir == Backward {
+			other = e.From
+		}
+		if _, ok := idx.Nodes[other]; !ok || seen[other] {
+			continue
+		}
+		seen[other] = true
+		kept = append(kept, e)
+		next = append(next, other)
+	}
+	return kept, next
+}
+
+// Traverse walks breadth-first from roots and returns every reachable node
+// once, in visiting order, starting with the roots at depth 0.
+func (idx *Index) Traverse(roots []string, dir Direction, opts TraverseOptions) []Step {
+	seen := make(map[string]bool)
+	var steps []Step
+	for _, id := range roots {
+		if n, ok := idx.Nodes[id]; ok && !seen[id] {
+			seen[id] = true
+			steps = append(steps, Step{Node: n})
+		}
+	}
+	for i := 0; i < len(steps); i++ {
+		cur := steps[i]
+		if opts.Depth > 0 && cur.Depth >= opts.Depth {
+			continue
+		}
+		edges, next := idx.neighbors(cur.Node.ID, dir, opts)
+		for j, id := range next {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			steps = append(steps, Step{Node: idx.Nodes[id], Depth: cur.Depth + 1, Edge: edges[j]})
+		}
+	}
+	return steps
+}
+
+// WriteTree prints the traversal from root as an indented tree. Nodes that
+// were already expanded are printed once more but not descended into.
+func (idx *Index) WriteTree(w io.Writer, root string, dir Direction, opts TraverseOptions) error {
+	expanded := make(map[string]bool)
+	var walk func(id string, depth int, relation string) error
+	walk = func(id string, depth int, relation string) error {
+		n := idx.Nodes[id]
+		line := strings.Repeat("  ", depth) + n.QualifiedName()
+		if relation != "" && len(opts.Relations) != 1 {
+			line += " [" + relation + "]"
+		}
+		if loc := n.Location(); loc != "" {
+			line += "  " + loc
+		}
+		if expanded[id] && depth > 0 {
+			_, err := fmt.Fprintln(w, line+"  (see above)")
+			return err
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		expanded[id] = true
+		if opts.Depth > 0 && depth >= opts.Depth {
+			return nil
+		}
+		edges, next := idx.neighbors(id, dir, opts)
+		for i, child := range next {
+			if err := walk(child, depth+1, edges[i].Relation); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(root, 0, "")
+}
+
+// WriteList prints one traversal step per line: depth, qualified name, node
+// type and location.
+func WriteList(w io.Writer, steps []Step) error {
+	for _, s := range steps {
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Depth, s.Node.QualifiedName(), s.Node.Type, s.Node.Location()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subgraph returns the part of result induced by the given node IDs: those
+// nodes, the edges between them, and the module entries declaring them.
+func Subgraph(result ProjectStructure, keep map[string]bool) ProjectStructure {
+	sub := ProjectStructure{
+		SchemaVersion: result.SchemaVersion,
+		Project:       make(map[string]PackageInfo, len(result.Project)),
+		CodeGraph: CodeGraph{
+			Nodes: []Node{},
+			Edges: []Edge{},
+		},
+	}
+	for _, n := range result.CodeGraph.Nodes {
+		if keep[n.ID] {
+			sub.CodeGraph.Nodes = append(sub.CodeGraph.Nodes, n)
+		}
+	}
+	for _, e := range result.CodeGraph.Edges {
+		if keep[e.From] && keep[e.To] {
+			sub.CodeGraph.Edges = append(sub.CodeGraph.Edges, e)
+		}
+	}
+
+	for name, pkg := range result.Project {
+		modules := make(map[string]ModuleInfo)
+		for path, m := range pkg.Modules {
+			if filtered, ok := filterModule(m, keep); ok {
+				modules[path] = filtered
+			}
+		}
+		sub.Project[name] = PackageInfo{Module: pkg.Module, Modules: modules}
+	}
+	return sub
+}
+
+// filterModule drops everything from m not in keep, reporting whether
+// anything is left.
+func filterModule(m ModuleInfo, keep map[string]bool) (ModuleInfo, bool) {
+	out := ModuleInfo{
+		Package:      m.Package,
+		Structs:      []StructInfo{},
+		Functions:    filterFunctions(m.Functions, keep),
+		Interfaces:   []InterfaceInfo{},
+		Types:        []TypeInfo{},
+		Dependencies: m.Dependencies,
+		ImportLines:  m.ImportLines,
+		Constants:    []ConstantInfo{},
+		Variables:    []VariableInfo{},
+	}
+	found := len(out.Functions) > 0
+	if out.Functions == nil {
+		out.Functions = []FunctionInfo{}
+	}
+	for _, s := range m.Structs {
+		methods := filterFunctions(s.Functions, keep)
+		if keep[s.ID] || len(methods) > 0 {
+			s.Functions = methods
+			out.Structs = append(out.Structs, s)
+			found = true
+		}
+	}
+	for _, i := range m.Interfaces {
+		methods := filterFunctions(i.Functions, keep)
+		if keep[i.ID] || len(methods) > 0 {
+			if methods == nil {
+				methods = []FunctionInfo{}
+			}
+			i.Functions = methods
+			out.Interfaces = append(out.Interfaces, i)
+			found = true
+		}
+	}
+	for _, t := range m.Types {
+		methods := filterFunctions(t.Functions, keep)
+		if keep[t.ID] || len(methods) > 0 {
+			t.Functions = methods
+			out.Types = append(out.Types, t)
+			found = true
+		}
+	}
+	for _, c := range m.Constants {
+		if keep[c.ID] {
+			out.Constants = append(out.Constants, c)
+			found = true
+		}
+	}
+	for _, v := range m.Variables {
+		if keep[v.ID] {
+			out.Variables = append(out.Variables, v)
+			found = true
+		}
+	}
+	return out, found
+}
+
+func filterFunctions(fns []FunctionInfo, keep map[string]bool) []FunctionInfo {
+	var out []FunctionInfo
+	for _, f := range fns {
+		if keep[f.ID] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// callChain is main → run → {load, save} → store.Put, with save also
+// calling load.
+var callChain = map[string]string{
+	"main.go": "package main\n\nimport \"example.com/m/store\"\n\nfunc main() { run() }\n\nfunc run() { load(); save() }\n\n" +
+		"func load() { store.Put() }\n\nfunc save() { load() }\n",
+	"store/store.go": "package store\n\nfunc Put() {}\n",
+}
+
+func resolveOne(t *testing.T, idx *Index, symbol string) string {
+	t.Helper()
+	nodes := idx.Resolve(symbol)
+	if len(nodes) != 1 {
+		t.Fatalf("Resolve(%q) = %v, want one node", symbol, nodes)
+	}
+	return nodes[0].ID
+}
+
+func TestTraverse(t *testing.T) {
+	idx := NewIndex(analyzeFiles(t, callChain).CodeGraph)
+	tests := []struct {
+		name string
+		root string
+		dir  Direction
+		opts TraverseOptions
+		want []string // Depth and qualified name
+	}{
+		{
+			name: "callees",
+			root: "main.run",
+			dir:  Forward,
+			opts: TraverseOptions{Relations: []string{"calls"}},
+			want: []string{"0 main.run", "1 main.load", "1 main.save", "2 store.Put"},
+		},
+		{
+			name: "callees to depth 1",
+			root: "main.run",
+			dir:  Forward,
+			opts: TraverseOptions{Depth: 1, Relations: []string{"calls"}},
+			want: []string{"0 main.run", "1 main.load", "1 main.save"},
+		},
+		{
+			name: "callers",
+			root: "store.Put",
+			dir:  Backward,
+			opts: TraverseOptions{Relations: []string{"calls"}},
+			want: []string{"0 store.Put", "1 main.load", "2 main.run", "2 main.save", "3 main.main"},
+		},
+		{
+			name: "other relations",
+			root: "store.Put",
+			dir:  Backward,
+			opts: TraverseOptions{Relations: []string{"references"}},
+			want: []string{"0 store.Put"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range idx.Traverse([]string{resolveOne(t, idx, tt.root)}, tt.dir, tt.opts) {
+				got = append(got, fmt.Sprintf("%d %s", s.Depth, s.Node.QualifiedName()))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("steps = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	idx := NewIndex(analyzeFiles(t, callChain).CodeGraph)
+	var b strings.Builder
+	if err := idx.WriteTree(&b, resolveOne(t, idx, "main.run"), Forward, TraverseOptions{Relations: []string{"calls"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "main.run  main.go:7\n" +
+		"  main.load  main.go:9\n" +
+		"    store.Put  store.go:3\n" +
+		"  main.save  main.go:11\n" +
+		"    main.load  main.go:9  (see above)\n"
+	if b.String() != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
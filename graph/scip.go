@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"io"
+	"net/url"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers and enum values from the SCIP schema
+// (https://github.com/sourcegraph/scip/blob/main/scip.proto).
+const (
+	scipIndexMetadata  protowire.Number = 1
+	scipIndexDocuments protowire.Number = 2
+
+	scipMetadataToolInfo             protowire.Number = 2
+	scipMetadataProjectRoot          protowire.Number = 3
+	scipMetadataTextDocumentEncoding protowire.Number = 4
+
+	scipToolInfoName    protowire.Number = 1
+	scipToolInfoVersion protowire.Number = 2
+
+	scipDocumentRelativePath     protowire.Number = 1
+	scipDocumentOccurrences      protowire.Number = 2
+	scipDocumentSymbols          protowire.Number = 3
+	scipDocumentLanguage         protowire.Number = 4
+	scipDocumentPositionEncoding protowire.Number = 6
+
+	scipOccurrenceRange       protowire.Number = 1
+	scipOccurrenceSymbol      protowire.Number = 2
+	scipOccurrenceSymbolRoles protowire.Number = 3
+
+	scipSymbolInformationSymbol        protowire.Number = 1
+	scipSymbolInformationDocumentation protowire.Number = 3
+	scipSymbolInformationRelationships protowire.Number = 4
+	scipSymbolInformationDisplayName   protowire.Number = 6
+
+	scipRelationshipSymbol           protowire.Number = 1
+	scipRelationshipIsImplementation protowire.Number = 3
+
+	scipTextEncodingUTF8                  = 1
+	scipPositionEncodingUTF8FromLineStart = 1
+	scipSymbolRoleDefinition              = 1
+	scipToolName                          = "codegraph"
+	scipToolVersion                       = "0.1.0"
+)
+
+func init() {
+	RegisterFormat(Format{Name: "scip", Extension: ".scip", Encoder: EncoderFunc(writeSCIP)})
+}
+
+// writeSCIP encodes the graph as a SCIP index. Definitions and references
+// come from node and edge positions, implementations from "implements" edges.
+func writeSCIP(w io.Writer, result ProjectStructure, opts EncodeOptions) error {
+	idx := newSymbolIndex(result)
+
+	root, err := filepath.Abs(opts.ProjectPath)
+	if err != nil {
+		return err
+	}
+	projectRoot := url.URL{Scheme: "file", Path: filepath.ToSlash(root)}
+
+	var toolInfo []byte
+	toolInfo = appendProtoString(toolInfo, scipToolInfoName, scipToolName)
+	toolInfo = appendProtoString(toolInfo, scipToolInfoVersion, scipToolVersion)
+
+	var metadata []byte
+	metadata = appendProtoMessage(metadata, scipMetadataToolInfo, toolInfo)
+	metadata = appendProtoString(metadata, scipMetadataProjectRoot, projectRoot.String())
+	metadata = appendProtoVarint(metadata, scipMetadataTextDocumentEncoding, scipTextEncodingUTF8)
+
+	var index []byte
+	index = appendProtoMessage(index, scipIndexMetadata, metadata)
+	for _, doc := range idx.documents {
+		index = appendProtoMessage(index, scipIndexDocuments, idx.scipDocument(doc))
+	}
+
+	_, err = w.Write(index)
+	return err
+}
+
+func (idx *symbolIndex) scipDocument(modulePath string) []byte {
+	var doc []byte
+	doc = appendProtoString(doc, scipDocumentRelativePath, modulePath)
+
+	for _, occ := range idx.occurrences[modulePath] {
+		name := idx.nodes[occ.node].Name
+		line, column := int32(occ.line-1), int32(occ.column-1)
+
+		var o []byte
+		o = appendProtoPacked(o, scipOccurrenceRange, []int32{line, column, column + int32(len(name))})
+		o = appendProtoString(o, scipOccurrenceSymbol, idx.symbols[occ.node])
+		if occ.definition {
+			o = appendProtoVarint(o, scipOccurrenceSymbolRoles, scipSymbolRoleDefinition)
+		}
+		doc = appendProtoMessage(doc, scipDocumentOccurrences, o)
+	}
+
+	for _, occ := range idx.occurrences[modulePath] {
+		if occ.definition {
+			doc = appendProtoMessage(doc, scipDocumentSymbols, idx.scipSymbolInformation(occ.node))
+		}
+	}
+
+	doc = appendProtoString(doc, scipDocumentLanguage, "go")
+	doc = appendProtoVarint(doc, scipDocumentPositionEncoding, scipPositionEncodingUTF8FromLineStart)
+	return doc
+}
+
+func (idx *symbolIndex) scipSymbolInformation(id string) []byte {
+	var info []byte
+	info = appendProtoString(info, scipSymbolInformationSymbol, idx.symbols[id])
+	if hover := idx.hovers[id]; hover != "" {
+		info = appendProtoString(info, scipSymbolInformationDocumentation, "```go\n"+hover+"\n```")
+	}
+	if comment := idx.comments[id]; comment != "" {
+		info = appendProtoString(info, scipSymbolInformationDocumentation, comment)
+	}
+	for _, target := range idx.implements[id] {
+		symbol, ok := idx.symbols[target]
+		if !ok {
+			continue
+		}
+		var rel []byte
+		rel = appendProtoString(rel, scipRelationshipSymbol, symbol)
+		rel = appendProtoVarint(rel, scipRelationshipIsImplementation, 1)
+		info = appendProtoMessage(info, scipSymbolInformationRelationships, rel)
+	}
+	info = appendProtoString(info, scipSymbolInformationDisplayName, idx.nodes[id].Name)
+	return info
+}
+
+// Helpers for hand-encoding protobuf messages; zero values are omitted as
+// proto3 requires.
+
+func appendProtoString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendProtoMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendProtoVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendProtoPacked(b []byte, num protowire.Number, values []int32) []byte {
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	return appendProtoMessage(b, num, packed)
+}
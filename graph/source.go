@@ -0,0 +1,321 @@
+package graph
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Source supplies the Go files to analyze.
+type Source struct {
+	FS   fs.FS  // Files, with the project root at "."
+	Root string // Path files are reported under, e.g. the project directory
+}
+
+// DirSource reads the project in directory dir.
+func DirSource(dir string) Source {
+	return Source{FS: os.DirFS(dir), Root: dir}
+}
+
+// MemorySource analyzes files held in memory, keyed by slash-separated path
+// relative to the project root, e.g. fixtures in tests.
+func MemorySource(files map[string][]byte) Source {
+	m := make(memFS, len(files))
+	for name, data := range files {
+		m[path.Clean(strings.TrimPrefix(name, "/"))] = data
+	}
+	return Source{FS: m, Root: "."}
+}
+
+// OpenSource returns the source for a project path given on the command
+// line: a directory, a .zip archive such as a module zip from the proxy
+// cache, or a .tar.gz or .tgz archive.
+func OpenSource(projectPath string) (Source, error) {
+	lower := strings.ToLower(projectPath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return ZipSource(projectPath)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return TarGzSource(projectPath)
+	}
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		return Source{}, err
+	}
+	if !info.IsDir() {
+		return Source{}, fmt.Errorf("%s is neither a directory nor a .zip or .tar.gz archive", projectPath)
+	}
+	return DirSource(projectPath), nil
+}
+
+// Fingerprint summarizes the Go files of s by path, size and modification
+// time. It changes when a file is edited, added or removed, so polling it
+// detects changes without reading the files.
+func (s Source) Fingerprint() (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(s.FS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "vendor" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", name, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return hex.EncodeToString(h.Sum(nil)), err
+}
+
+// ZipSource reads the Go files and go.mod files of a zip archive into
+// memory. In a module zip the module@version directory
+// ("example.com/mod@v1.0.0/...") is the project root; otherwise, when
+// every file sits under one top-level directory, as in release archives,
+// that directory is.
+func ZipSource(archive string) (Source, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return Source{}, err
+	}
+	defer zr.Close()
+
+	files := make(memFS)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isArchivedGoFile(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return Source{}, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return Source{}, fmt.Errorf("reading %s from %s: %w", f.Name, archive, err)
+		}
+		files[path.Clean(f.Name)] = data
+	}
+	return Source{FS: files.trimTopDir(), Root: archive}, nil
+}
+
+// TarGzSource reads the Go files and go.mod files of a gzip-compressed tar
+// archive into memory, finding the project root as ZipSource does.
+func TarGzSource(archive string) (Source, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return Source{}, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return Source{}, fmt.Errorf("reading %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	files := make(memFS)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Source{}, fmt.Errorf("reading %s: %w", archive, err)
+		}
+		name := strings.TrimPrefix(hdr.Name, "./")
+		if hdr.Typeflag != tar.TypeReg || !isArchivedGoFile(name) {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return Source{}, fmt.Errorf("reading %s from %s: %w", name, archive, err)
+		}
+		files[path.Clean(name)] = data
+	}
+	return Source{FS: files.trimTopDir(), Root: archive}, nil
+}
+
+// isArchivedGoFile reports whether an archive entry is a Go file or a
+// go.mod with a path that stays inside the archive.
+func isArchivedGoFile(name string) bool {
+	return (strings.HasSuffix(name, ".go") || path.Base(name) == "go.mod") && fs.ValidPath(path.Clean(name))
+}
+
+// memFS is a read-only file system held in memory. Keys are slash-separated
+// file paths; directories are implied by them.
+type memFS map[string][]byte
+
+// trimTopDir returns the file system rooted at the project directory of an
+// archive: the module@version directory when the directories holding every
+// file have one in common, as in module zips whose module path has several
+// elements, or else the single top-level directory holding every file. It
+// returns m itself when there is neither.
+func (m memFS) trimTopDir() memFS {
+	var common []string // Directory elements shared by every file
+	first := true
+	for name := range m {
+		dir := path.Dir(name)
+		if dir == "." {
+			return m
+		}
+		elems := strings.Split(dir, "/")
+		if first {
+			common, first = elems, false
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(elems) && common[n] == elems[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return m
+	}
+
+	root := common[:1]
+	for i, elem := range common {
+		if strings.Contains(elem, "@") {
+			root = common[:i+1]
+			break
+		}
+	}
+	prefix := strings.Join(root, "/") + "/"
+	trimmed := make(memFS, len(m))
+	for name, data := range m {
+		trimmed[strings.TrimPrefix(name, prefix)] = data
+	}
+	return trimmed
+}
+
+// Open implements fs.FS.
+func (m memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m[name]; ok {
+		return &memFile{info: memInfo{name: path.Base(name), size: int64(len(data))}, Reader: bytes.NewReader(data)}, nil
+	}
+	entries, err := m.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return &memDir{info: memInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (m memFS) ReadFile(name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte{}, data...), nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (m memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+	children := make(map[string]memInfo)
+	for p, data := range m {
+		rest, ok := strings.CutPrefix(p, prefix)
+		if !ok {
+			continue
+		}
+		if child, _, isDir := strings.Cut(rest, "/"); isDir {
+			children[child] = memInfo{name: child, dir: true}
+		} else {
+			children[rest] = memInfo{name: rest, size: int64(len(data))}
+		}
+	}
+	if len(children) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, info := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// memInfo describes a memFS file or directory.
+type memInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) ModTime() time.Time { return time.Time{} }
+func (i memInfo) IsDir() bool        { return i.dir }
+func (i memInfo) Sys() any           { return nil }
+
+func (i memInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+type memFile struct {
+	info memInfo
+	*bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memDir struct {
+	info    memInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
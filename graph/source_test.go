@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTrimTopDir(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "module zip",
+			files: []string{"github.com/foo/bar@v1.0.0/go.mod", "github.com/foo/bar@v1.0.0/bar.go", "github.com/foo/bar@v1.0.0/sub/sub.go"},
+			want:  []string{"bar.go", "go.mod", "sub/sub.go"},
+		},
+		{
+			name:  "module zip with a single package below the root",
+			files: []string{"github.com/foo/bar@v1.0.0/internal/x/x.go", "github.com/foo/bar@v1.0.0/internal/x/y.go"},
+			want:  []string{"internal/x/x.go", "internal/x/y.go"},
+		},
+		{
+			name:  "release archive",
+			files: []string{"bar-1.0/main.go", "bar-1.0/cmd/run.go"},
+			want:  []string{"cmd/run.go", "main.go"},
+		},
+		{
+			name:  "several top-level directories",
+			files: []string{"a/a.go", "b/b.go"},
+			want:  []string{"a/a.go", "b/b.go"},
+		},
+		{
+			name:  "files at the top level",
+			files: []string{"main.go", "sub/sub.go"},
+			want:  []string{"main.go", "sub/sub.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := make(memFS)
+			for _, name := range tt.files {
+				m[name] = nil
+			}
+			var got []string
+			for name := range m.trimTopDir() {
+				got = append(got, name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trimTopDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipSourceModuleZip(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "v1.0.0.zip")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"github.com/foo/bar@v1.0.0/go.mod":     "module github.com/foo/bar\n",
+		"github.com/foo/bar@v1.0.0/bar.go":     "package bar\n\nimport \"github.com/foo/bar/sub\"\n\ntype T struct{ sub.Base }\n",
+		"github.com/foo/bar@v1.0.0/sub/sub.go": "package sub\n\ntype Base struct{}\n",
+		"github.com/foo/bar@v1.0.0/README.md":  "bar\n",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := ZipSource(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := AnalyzeSource(src, "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	for file, m := range result.Project["bar"].Modules {
+		files = append(files, filepath.ToSlash(file)+" "+m.Package)
+	}
+	sort.Strings(files)
+	if want := []string{"bar.go bar", "sub/sub.go sub"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("modules = %v, want %v", files, want)
+	}
+
+	// go.mod is kept, so imports of the module resolve
+	idx := NewIndex(result.CodeGraph)
+	embeds := false
+	for _, e := range result.CodeGraph.Edges {
+		embeds = embeds || e.Relation == "embeds" && idx.Nodes[e.To].QualifiedName() == "sub.Base"
+	}
+	if !embeds {
+		t.Error("bar.T does not embed sub.Base")
+	}
+}
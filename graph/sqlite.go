@@ -0,0 +1,844 @@
+package graph
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+func init() {
+	RegisterFormat(Format{Name: "sqlite", Extension: ".db", Encoder: EncoderFunc(encodeSQLite), Decoder: DecoderFunc(decodeSQLite)})
+}
+
+// sqliteSchema is the normalized layout written by writeSQLite. Node and
+// function IDs are the same strings used in the JSON output, so rows can be
+// joined back to edges. Packages are keyed by directory, relative to the
+// project root, as several may share a name; dir is NULL for packages
+// outside the project and for package nodes whose file is ambiguous.
+// Parsed doc comments are stored as JSON in the doc columns, and struct
+// tags are split into field_tags, one row per key.
+const sqliteSchema = `
+CREATE TABLE meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE packages (
+	id   INTEGER PRIMARY KEY,
+	dir  TEXT,
+	name TEXT NOT NULL,
+	UNIQUE (dir, name)
+);
+CREATE TABLE files (
+	id         INTEGER PRIMARY KEY,
+	path       TEXT NOT NULL UNIQUE,
+	package_id INTEGER REFERENCES packages(id),
+	lines      INTEGER
+);
+CREATE TABLE dependencies (
+	file_id  INTEGER NOT NULL REFERENCES files(id),
+	position INTEGER NOT NULL,
+	spec     TEXT NOT NULL,
+	line     INTEGER
+);
+CREATE TABLE nodes (
+	id         TEXT PRIMARY KEY,
+	type       TEXT NOT NULL,
+	name       TEXT NOT NULL,
+	package_id INTEGER REFERENCES packages(id),
+	file_id    INTEGER REFERENCES files(id),
+	receiver   TEXT,
+	line       INTEGER,
+	column     INTEGER,
+	comment    TEXT,
+	doc        TEXT,
+	deprecated INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE metrics (
+	node_id    TEXT PRIMARY KEY REFERENCES nodes(id),
+	cyclomatic INTEGER NOT NULL,
+	cognitive  INTEGER NOT NULL,
+	lines      INTEGER NOT NULL,
+	params     INTEGER NOT NULL,
+	returns    INTEGER NOT NULL,
+	nesting    INTEGER NOT NULL
+);
+CREATE TABLE edges (
+	from_id  TEXT NOT NULL,
+	to_id    TEXT NOT NULL,
+	relation TEXT NOT NULL,
+	line     INTEGER,
+	column   INTEGER
+);
+CREATE TABLE functions (
+	id               TEXT PRIMARY KEY,
+	name             TEXT NOT NULL,
+	owner_id         TEXT,
+	file_id          INTEGER REFERENCES files(id),
+	return_type      TEXT,
+	comment          TEXT,
+	content          TEXT,
+	package          TEXT,
+	file_path        TEXT,
+	doc              TEXT,
+	pointer_receiver INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE parameters (
+	function_id TEXT NOT NULL REFERENCES functions(id),
+	position    INTEGER NOT NULL,
+	name        TEXT,
+	type        TEXT NOT NULL
+);
+CREATE TABLE fields (
+	struct_id TEXT NOT NULL REFERENCES nodes(id),
+	position  INTEGER NOT NULL,
+	name      TEXT NOT NULL,
+	type      TEXT NOT NULL,
+	tag       TEXT,
+	exported  INTEGER NOT NULL DEFAULT 0,
+	embedded  INTEGER NOT NULL DEFAULT 0,
+	comment   TEXT,
+	doc       TEXT
+);
+CREATE TABLE field_tags (
+	struct_id TEXT NOT NULL REFERENCES nodes(id),
+	position  INTEGER NOT NULL,
+	key       TEXT NOT NULL,
+	value     TEXT NOT NULL,
+	name      TEXT NOT NULL -- Value up to the first comma, e.g. the JSON key
+);
+CREATE TABLE types (
+	id         TEXT PRIMARY KEY REFERENCES nodes(id),
+	underlying TEXT NOT NULL, -- The aliased type for aliases
+	alias      INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE constants (
+	id      TEXT PRIMARY KEY,
+	name    TEXT NOT NULL,
+	type    TEXT,
+	value   TEXT,
+	file_id INTEGER REFERENCES files(id),
+	comment TEXT,
+	doc     TEXT
+);
+CREATE TABLE variables (
+	id      TEXT PRIMARY KEY,
+	name    TEXT NOT NULL,
+	type    TEXT,
+	value   TEXT,
+	file_id INTEGER REFERENCES files(id),
+	comment TEXT,
+	doc     TEXT
+);
+
+CREATE INDEX dependencies_file ON dependencies(file_id);
+CREATE INDEX nodes_name ON nodes(name);
+CREATE INDEX nodes_package ON nodes(package_id);
+CREATE INDEX nodes_type ON nodes(type);
+CREATE INDEX edges_relation ON edges(relation);
+CREATE INDEX edges_from ON edges(from_id, relation);
+CREATE INDEX edges_to ON edges(to_id, relation);
+CREATE INDEX functions_name ON functions(name);
+CREATE INDEX functions_owner ON functions(owner_id);
+CREATE INDEX parameters_function ON parameters(function_id);
+CREATE INDEX fields_struct ON fields(struct_id);
+CREATE INDEX fields_name ON fields(name);
+CREATE INDEX field_tags_key ON field_tags(key, name);
+CREATE INDEX constants_name ON constants(name);
+CREATE INDEX variables_name ON variables(name);
+`
+
+// encodeSQLite builds the database in a temporary file, since SQLite needs
+// random access, and copies it to w.
+func encodeSQLite(w io.Writer, result ProjectStructure, _ EncodeOptions) error {
+	tmp, err := os.CreateTemp("", "codegraph-*.db")
+	if err != nil {
+		return err
+	}
+	path := tmp.Name()
+	tmp.Close()
+	defer os.Remove(path)
+
+	if err := writeSQLite(result, path); err != nil {
+		return err
+	}
+	db, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	_, err = io.Copy(w, db)
+	return err
+}
+
+// writeSQLite writes the analysis result into a fresh SQLite database at
+// outputFile, replacing any existing file.
+func writeSQLite(result ProjectStructure, outputFile string) error {
+	if err := os.Remove(outputFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", outputFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := insertProject(tx, result); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// sqliteWriter tracks row IDs assigned while filling the database.
+type sqliteWriter struct {
+	tx           *sql.Tx
+	packages     map[[2]string]int64 // Keyed by directory and name
+	filePackages map[int64]int64     // Maps file row ID to its package
+	nodeFiles    map[string]int64    // Maps node ID to the file that declares it
+	nodeComments map[string]string   // Doc comments of structs and interfaces
+	nodeDocs     map[string]*Doc
+
+	// Files by path, and by package and file name locating package nodes
+	files        map[string]int64
+	packageFiles map[[2]string][]int64
+}
+
+func insertProject(tx *sql.Tx, result ProjectStructure) error {
+	w := &sqliteWriter{
+		tx:           tx,
+		packages:     make(map[[2]string]int64),
+		filePackages: make(map[int64]int64),
+		nodeFiles:    make(map[string]int64),
+		nodeComments: make(map[string]string),
+		nodeDocs:     make(map[string]*Doc),
+		files:        make(map[string]int64),
+		packageFiles: make(map[[2]string][]int64),
+	}
+
+	if _, err := tx.Exec(`INSERT INTO meta (key, value) VALUES ('schema_version', ?)`, SchemaVersion); err != nil {
+		return err
+	}
+	for name, pkgInfo := range result.Project {
+		if _, err := tx.Exec(`INSERT INTO meta (key, value) VALUES ('project', ?)`, name); err != nil {
+			return err
+		}
+		if pkgInfo.Module != "" {
+			if _, err := tx.Exec(`INSERT INTO meta (key, value) VALUES ('module', ?)`, pkgInfo.Module); err != nil {
+				return err
+			}
+		}
+		break // The analyzer produces a single project
+	}
+
+	for _, pkgInfo := range result.Project {
+		paths := make([]string, 0, len(pkgInfo.Modules))
+		for path := range pkgInfo.Modules {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		// Files come first, so that methods can refer to any file of
+		// their package
+		for _, path := range paths {
+			if err := w.insertFile(path, pkgInfo.Modules[path]); err != nil {
+				return err
+			}
+		}
+		for _, path := range paths {
+			if err := w.insertModule(path, pkgInfo.Modules[path]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, node := range result.CodeGraph.Nodes {
+		var pkgID, fileID any
+		doc := w.nodeDocs[node.ID]
+		if id, ok := w.nodeFiles[node.ID]; ok {
+			fileID = id
+		} else if files := w.packageFiles[[2]string{node.Package, node.File}]; node.Type == "package" && len(files) == 1 {
+			fileID = files[0]
+		}
+		// Nodes outside the project, and package nodes that cannot be
+		// told apart by file, are left without a directory
+		if id, ok := fileID.(int64); ok && w.filePackages[id] != 0 {
+			pkgID = w.filePackages[id]
+		} else if node.Package != "" {
+			id, err := w.packageID("", node.Package)
+			if err != nil {
+				return err
+			}
+			pkgID = id
+		}
+		if node.Type == "package" {
+			doc = node.Doc
+		}
+		docJSON, err := marshalDoc(doc)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO nodes (id, type, name, package_id, file_id, receiver, line, column, comment, doc, deprecated)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			node.ID, node.Type, node.Name, pkgID, fileID, node.Receiver, node.Line, node.Column, w.nodeComments[node.ID],
+			docJSON, node.Deprecated); err != nil {
+			return err
+		}
+		if m := node.Metrics; m != nil {
+			if _, err := tx.Exec(`INSERT INTO metrics (node_id, cyclomatic, cognitive, lines, params, returns, nesting)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				node.ID, m.Cyclomatic, m.Cognitive, m.Lines, m.Params, m.Returns, m.Nesting); err != nil {
+				return err
+			}
+		}
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO edges (from_id, to_id, relation, line, column) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, edge := range result.CodeGraph.Edges {
+		if _, err := stmt.Exec(edge.From, edge.To, edge.Relation, edge.Line, edge.Column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packageID returns the row ID for the package in directory dir, or for a
+// package outside the project when dir is empty, inserting it if needed.
+func (w *sqliteWriter) packageID(dir, name string) (int64, error) {
+	key, dirValue := [2]string{dir, name}, any(dir)
+	if dir == "" {
+		dirValue = nil
+	}
+	if id, ok := w.packages[key]; ok {
+		return id, nil
+	}
+	res, err := w.tx.Exec(`INSERT INTO packages (dir, name) VALUES (?, ?)`, dirValue, name)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	w.packages[key] = id
+	return id, nil
+}
+
+// insertFile stores the file row of a module.
+func (w *sqliteWriter) insertFile(path string, module ModuleInfo) error {
+	var pkgID any
+	if module.Package != "" {
+		id, err := w.packageID(filepath.ToSlash(filepath.Dir(path)), module.Package)
+		if err != nil {
+			return err
+		}
+		pkgID = id
+	}
+	res, err := w.tx.Exec(`INSERT INTO files (path, package_id, lines) VALUES (?, ?, ?)`, path, pkgID, module.Lines)
+	if err != nil {
+		return err
+	}
+	fileID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	w.files[path] = fileID
+	if id, ok := pkgID.(int64); ok {
+		w.filePackages[fileID] = id
+	}
+	key := [2]string{module.Package, filepath.Base(path)}
+	w.packageFiles[key] = append(w.packageFiles[key], fileID)
+	return nil
+}
+
+// insertModule stores everything declared in the module stored by
+// insertFile.
+func (w *sqliteWriter) insertModule(path string, module ModuleInfo) error {
+	fileID := w.files[path]
+	for i, dep := range module.Dependencies {
+		var line any
+		if i < len(module.ImportLines) {
+			line = module.ImportLines[i]
+		}
+		if _, err := w.tx.Exec(`INSERT INTO dependencies (file_id, position, spec, line) VALUES (?, ?, ?, ?)`,
+			fileID, i, dep, line); err != nil {
+			return err
+		}
+	}
+
+	for _, fn := range module.Functions {
+		if err := w.insertFunction(fn, "", fileID); err != nil {
+			return err
+		}
+	}
+
+	for _, st := range module.Structs {
+		w.nodeFiles[st.ID] = fileID
+		w.nodeComments[st.ID] = st.Comment
+		w.nodeDocs[st.ID] = st.Doc
+		for _, fn := range st.Functions {
+			methodFile, ok := w.files[methodPath(path, fn)]
+			if !ok {
+				methodFile = fileID
+			}
+			if err := w.insertFunction(fn, st.ID, methodFile); err != nil {
+				return err
+			}
+		}
+		for i, prop := range st.Properties {
+			doc, err := marshalDoc(prop.Doc)
+			if err != nil {
+				return err
+			}
+			if _, err := w.tx.Exec(`INSERT INTO fields (struct_id, position, name, type, tag, exported, embedded, comment, doc) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				st.ID, i, prop.Name, prop.Type, prop.Tag, prop.Exported, prop.Embedded, prop.Comment, doc); err != nil {
+				return err
+			}
+			keys := make([]string, 0, len(prop.Tags))
+			for key := range prop.Tags {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				value := prop.Tags[key]
+				name, _, _ := strings.Cut(value, ",")
+				if _, err := w.tx.Exec(`INSERT INTO field_tags (struct_id, position, key, value, name) VALUES (?, ?, ?, ?, ?)`,
+					st.ID, i, key, value, name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, iface := range module.Interfaces {
+		w.nodeFiles[iface.ID] = fileID
+		w.nodeComments[iface.ID] = iface.Comment
+		w.nodeDocs[iface.ID] = iface.Doc
+		for _, fn := range iface.Functions {
+			if err := w.insertFunction(fn, iface.ID, fileID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, t := range module.Types {
+		w.nodeFiles[t.ID] = fileID
+		w.nodeComments[t.ID] = t.Comment
+		w.nodeDocs[t.ID] = t.Doc
+		for _, fn := range t.Functions {
+			methodFile, ok := w.files[methodPath(path, fn)]
+			if !ok {
+				methodFile = fileID
+			}
+			if err := w.insertFunction(fn, t.ID, methodFile); err != nil {
+				return err
+			}
+		}
+		if _, err := w.tx.Exec(`INSERT INTO types (id, underlying, alias) VALUES (?, ?, ?)`, t.ID, t.Type, t.Alias); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range module.Constants {
+		w.nodeFiles[c.ID] = fileID
+		doc, err := marshalDoc(c.Doc)
+		if err != nil {
+			return err
+		}
+		if _, err := w.tx.Exec(`INSERT INTO constants (id, name, type, value, file_id, comment, doc) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			c.ID, c.Name, c.Type, c.Value, fileID, c.Comment, doc); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range module.Variables {
+		w.nodeFiles[v.ID] = fileID
+		doc, err := marshalDoc(v.Doc)
+		if err != nil {
+			return err
+		}
+		if _, err := w.tx.Exec(`INSERT INTO variables (id, name, type, value, file_id, comment, doc) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			v.ID, v.Name, v.Type, v.Value, fileID, v.Comment, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertFunction stores a function or method together with its parameters.
+// ownerID is the declaring struct or interface, empty for plain functions.
+func (w *sqliteWriter) insertFunction(fn FunctionInfo, ownerID string, fileID int64) error {
+	w.nodeFiles[fn.ID] = fileID
+	var owner any
+	if ownerID != "" {
+		owner = ownerID
+	}
+	doc, err := marshalDoc(fn.Doc)
+	if err != nil {
+		return err
+	}
+	if _, err := w.tx.Exec(`INSERT INTO functions (id, name, owner_id, file_id, return_type, comment, content, package, file_path, doc, pointer_receiver)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		fn.ID, fn.Name, owner, fileID, fn.ReturnType, fn.Comment, fn.Content, fn.Package, fn.FilePath, doc, fn.PointerReceiver); err != nil {
+		return err
+	}
+	for i, p := range fn.Parameters {
+		if _, err := w.tx.Exec(`INSERT INTO parameters (function_id, position, name, type) VALUES (?, ?, ?, ?)`,
+			fn.ID, i, p.Name, p.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeSQLite copies r to a temporary file, since SQLite needs a file to
+// open, and reads the graph back from it.
+func decodeSQLite(r io.Reader) (ProjectStructure, error) {
+	tmp, err := os.CreateTemp("", "codegraph-*.db")
+	if err != nil {
+		return ProjectStructure{}, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return ProjectStructure{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return ProjectStructure{}, err
+	}
+	return readSQLite(tmp.Name())
+}
+
+// readSQLite rebuilds a ProjectStructure from a database written by
+// writeSQLite. Structs and interfaces come back in declaration order.
+func readSQLite(path string) (ProjectStructure, error) {
+	result := ProjectStructure{
+		Project:   make(map[string]PackageInfo),
+		CodeGraph: CodeGraph{Nodes: []Node{}, Edges: []Edge{}},
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return result, err
+	}
+	defer db.Close()
+
+	r := &sqliteReader{db: db}
+	project, module := "", ""
+	r.query(`SELECT key, value FROM meta`, func(rows *sql.Rows) error {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		switch key {
+		case "schema_version":
+			version, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("bad schema version %q", value)
+			}
+			result.SchemaVersion = version
+		case "project":
+			project = value
+		case "module":
+			module = value
+		}
+		return nil
+	})
+
+	// Files become modules, keyed by row ID until they are filed by path
+	modules := make(map[int64]*ModuleInfo)
+	paths := make(map[int64]string)
+	r.query(`SELECT f.id, f.path, COALESCE(p.name, ''), COALESCE(f.lines, 0) FROM files f LEFT JOIN packages p ON p.id = f.package_id`,
+		func(rows *sql.Rows) error {
+			var id int64
+			m := &ModuleInfo{
+				Structs:      []StructInfo{},
+				Functions:    []FunctionInfo{},
+				Interfaces:   []InterfaceInfo{},
+				Types:        []TypeInfo{},
+				Dependencies: []string{},
+				Constants:    []ConstantInfo{},
+				Variables:    []VariableInfo{},
+			}
+			var path string
+			if err := rows.Scan(&id, &path, &m.Package, &m.Lines); err != nil {
+				return err
+			}
+			modules[id], paths[id] = m, path
+			return nil
+		})
+	r.query(`SELECT file_id, spec, line FROM dependencies ORDER BY file_id, position`, func(rows *sql.Rows) error {
+		var fileID int64
+		var spec string
+		var line sql.NullInt64
+		if err := rows.Scan(&fileID, &spec, &line); err != nil {
+			return err
+		}
+		if m := modules[fileID]; m != nil {
+			m.Dependencies = append(m.Dependencies, spec)
+			if line.Valid {
+				m.ImportLines = append(m.ImportLines, int(line.Int64))
+			}
+		}
+		return nil
+	})
+
+	params := make(map[string][]ParameterInfo)
+	r.query(`SELECT function_id, COALESCE(name, ''), type FROM parameters ORDER BY function_id, position`,
+		func(rows *sql.Rows) error {
+			var fnID string
+			var p ParameterInfo
+			if err := rows.Scan(&fnID, &p.Name, &p.Type); err != nil {
+				return err
+			}
+			params[fnID] = append(params[fnID], p)
+			return nil
+		})
+
+	// Plain functions go straight into their module; methods wait for
+	// their struct or interface
+	methods := make(map[string][]FunctionInfo)
+	r.query(`SELECT id, name, COALESCE(owner_id, ''), COALESCE(file_id, 0), COALESCE(return_type, ''),
+		COALESCE(comment, ''), COALESCE(content, ''), COALESCE(package, ''), COALESCE(file_path, ''), COALESCE(doc, ''), pointer_receiver
+		FROM functions ORDER BY rowid`, func(rows *sql.Rows) error {
+		var fn FunctionInfo
+		var owner, doc string
+		var fileID int64
+		if err := rows.Scan(&fn.ID, &fn.Name, &owner, &fileID, &fn.ReturnType,
+			&fn.Comment, &fn.Content, &fn.Package, &fn.FilePath, &doc, &fn.PointerReceiver); err != nil {
+			return err
+		}
+		var err error
+		if fn.Doc, err = unmarshalDoc(doc); err != nil {
+			return err
+		}
+		fn.Parameters = params[fn.ID]
+		if owner != "" {
+			methods[owner] = append(methods[owner], fn)
+		} else if m := modules[fileID]; m != nil {
+			m.Functions = append(m.Functions, fn)
+		}
+		return nil
+	})
+
+	types := make(map[string]TypeInfo)
+	r.query(`SELECT id, underlying, alias FROM types`, func(rows *sql.Rows) error {
+		var t TypeInfo
+		if err := rows.Scan(&t.ID, &t.Type, &t.Alias); err != nil {
+			return err
+		}
+		types[t.ID] = t
+		return nil
+	})
+
+	fields := make(map[string][]PropertyInfo)
+	r.query(`SELECT struct_id, name, type, COALESCE(tag, ''), exported, embedded, COALESCE(comment, ''), COALESCE(doc, '') FROM fields ORDER BY struct_id, position`,
+		func(rows *sql.Rows) error {
+			var structID, doc string
+			var p PropertyInfo
+			if err := rows.Scan(&structID, &p.Name, &p.Type, &p.Tag, &p.Exported, &p.Embedded, &p.Comment, &doc); err != nil {
+				return err
+			}
+			p.Tags = parseStructTag(p.Tag)
+			var err error
+			if p.Doc, err = unmarshalDoc(doc); err != nil {
+				return err
+			}
+			fields[structID] = append(fields[structID], p)
+			return nil
+		})
+
+	metrics := make(map[string]*Metrics)
+	r.query(`SELECT node_id, cyclomatic, cognitive, lines, params, returns, nesting FROM metrics`,
+		func(rows *sql.Rows) error {
+			var id string
+			m := &Metrics{}
+			if err := rows.Scan(&id, &m.Cyclomatic, &m.Cognitive, &m.Lines, &m.Params, &m.Returns, &m.Nesting); err != nil {
+				return err
+			}
+			metrics[id] = m
+			return nil
+		})
+
+	r.query(`SELECT n.id, n.type, n.name, COALESCE(p.name, ''), COALESCE(n.file_id, 0), COALESCE(n.receiver, ''),
+		COALESCE(n.line, 0), COALESCE(n.column, 0), COALESCE(n.doc, ''), COALESCE(n.deprecated, 0)
+		FROM nodes n LEFT JOIN packages p ON p.id = n.package_id ORDER BY n.rowid`, func(rows *sql.Rows) error {
+		var n Node
+		var fileID int64
+		var doc string
+		if err := rows.Scan(&n.ID, &n.Type, &n.Name, &n.Package, &fileID, &n.Receiver,
+			&n.Line, &n.Column, &doc, &n.Deprecated); err != nil {
+			return err
+		}
+		if n.Type == "package" {
+			var err error
+			if n.Doc, err = unmarshalDoc(doc); err != nil {
+				return err
+			}
+		}
+		if path, ok := paths[fileID]; ok {
+			n.File = filepath.Base(path)
+		}
+		n.Metrics = metrics[n.ID]
+		result.CodeGraph.Nodes = append(result.CodeGraph.Nodes, n)
+		return nil
+	})
+	// Structs, interfaces and named types, in source order within each file
+	r.query(`SELECT id, type, name, file_id, COALESCE(comment, ''), COALESCE(doc, '') FROM nodes
+		WHERE type IN ('struct', 'interface', 'type') AND file_id IS NOT NULL ORDER BY file_id, line, column`,
+		func(rows *sql.Rows) error {
+			var id, typ, name, comment, docJSON string
+			var fileID int64
+			if err := rows.Scan(&id, &typ, &name, &fileID, &comment, &docJSON); err != nil {
+				return err
+			}
+			m := modules[fileID]
+			if m == nil {
+				return nil
+			}
+			doc, err := unmarshalDoc(docJSON)
+			if err != nil {
+				return err
+			}
+			switch typ {
+			case "struct":
+				props := fields[id]
+				if props == nil {
+					props = []PropertyInfo{}
+				}
+				m.Structs = append(m.Structs, StructInfo{Name: name, Functions: methods[id], Properties: props, Comment: comment, Doc: doc, ID: id})
+			case "type":
+				t := types[id]
+				m.Types = append(m.Types, TypeInfo{Name: name, Type: t.Type, Alias: t.Alias, Functions: methods[id], Comment: comment, Doc: doc, ID: id})
+			default:
+				fns := methods[id]
+				if fns == nil {
+					fns = []FunctionInfo{}
+				}
+				m.Interfaces = append(m.Interfaces, InterfaceInfo{Name: name, Functions: fns, Comment: comment, Doc: doc, ID: id})
+			}
+			return nil
+		})
+
+	r.query(`SELECT id, name, COALESCE(type, ''), COALESCE(value, ''), COALESCE(file_id, 0), COALESCE(comment, ''), COALESCE(doc, '')
+		FROM constants ORDER BY rowid`, func(rows *sql.Rows) error {
+		var c ConstantInfo
+		var fileID int64
+		var doc string
+		if err := rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &fileID, &c.Comment, &doc); err != nil {
+			return err
+		}
+		var err error
+		if c.Doc, err = unmarshalDoc(doc); err != nil {
+			return err
+		}
+		if m := modules[fileID]; m != nil {
+			m.Constants = append(m.Constants, c)
+		}
+		return nil
+	})
+	r.query(`SELECT id, name, COALESCE(type, ''), COALESCE(value, ''), COALESCE(file_id, 0), COALESCE(comment, ''), COALESCE(doc, '')
+		FROM variables ORDER BY rowid`, func(rows *sql.Rows) error {
+		var v VariableInfo
+		var fileID int64
+		var doc string
+		if err := rows.Scan(&v.ID, &v.Name, &v.Type, &v.Value, &fileID, &v.Comment, &doc); err != nil {
+			return err
+		}
+		var err error
+		if v.Doc, err = unmarshalDoc(doc); err != nil {
+			return err
+		}
+		if m := modules[fileID]; m != nil {
+			m.Variables = append(m.Variables, v)
+		}
+		return nil
+	})
+
+	r.query(`SELECT from_id, to_id, relation, COALESCE(line, 0), COALESCE(column, 0) FROM edges ORDER BY rowid`,
+		func(rows *sql.Rows) error {
+			var e Edge
+			if err := rows.Scan(&e.From, &e.To, &e.Relation, &e.Line, &e.Column); err != nil {
+				return err
+			}
+			result.CodeGraph.Edges = append(result.CodeGraph.Edges, e)
+			return nil
+		})
+	if r.err != nil {
+		return result, r.err
+	}
+
+	pkg := PackageInfo{Module: module, Modules: make(map[string]ModuleInfo, len(modules))}
+	for id, m := range modules {
+		pkg.Modules[paths[id]] = *m
+	}
+	result.Project[project] = pkg
+	return result, nil
+}
+
+// marshalDoc encodes a doc comment for a doc column, NULL when absent.
+func marshalDoc(d *Doc) (any, error) {
+	if d == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func unmarshalDoc(data string) (*Doc, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var d Doc
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return nil, fmt.Errorf("bad doc %q: %w", data, err)
+	}
+	return &d, nil
+}
+
+// sqliteReader runs queries until the first error, which it keeps.
+type sqliteReader struct {
+	db  *sql.DB
+	err error
+}
+
+func (r *sqliteReader) query(query string, scan func(rows *sql.Rows) error) {
+	if r.err != nil {
+		return
+	}
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.err = err
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			r.err = err
+			return
+		}
+	}
+	r.err = rows.Err()
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSQLitePackages(t *testing.T) {
+	result := analyzeFiles(t, map[string]string{
+		"cmd/a/main.go": "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println() }\n",
+		"cmd/b/main.go": "package main\n\nfunc main() {}\n",
+		"lib/lib.go":    "package lib\n\ntype Reader interface{ Read() }\n",
+	})
+	filename := filepath.Join(t.TempDir(), "graph.db")
+	if err := writeSQLite(result, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	query := func(q string) []string {
+		t.Helper()
+		rows, err := db.Query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var a, b string
+			if err := rows.Scan(&a, &b); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, a+" "+b)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	// The main package nodes share a name and file name, so they are not
+	// placed in a directory
+	got := query(`SELECT COALESCE(dir, '-'), name FROM packages ORDER BY dir, name`)
+	if want := []string{"- fmt", "- main", "cmd/a main", "cmd/b main", "lib lib"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("packages = %q, want %q", got, want)
+	}
+	got = query(`SELECT n.type || ' ' || n.name, COALESCE(p.dir, '-') FROM nodes n JOIN packages p ON p.id = n.package_id
+		WHERE n.type != 'package' ORDER BY p.dir, n.name`)
+	if want := []string{"external_function Println -", "function main cmd/a", "function main cmd/b", "interface_method Read lib", "interface Reader lib"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("nodes = %q, want %q", got, want)
+	}
+
+	back, err := readSQLite(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back.Project, result.Project) {
+		t.Errorf("project read back differs:\n%v\nwant\n%v", back.Project, result.Project)
+	}
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// fieldTag returns the unquoted struct tag of field, or "" if it has none
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+// parseStructTag splits a struct tag written by the key:"value" convention
+// of reflect.StructTag into its values by key. Like StructTag.Get, it
+// keeps the first of repeated keys and stops at the first malformed pair.
+func parseStructTag(tag string) map[string]string {
+	var tags map[string]string
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		if _, ok := tags[key]; !ok {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
+// embeddedName returns the field name of an embedded field, which the
+// analyzer records under its type, e.g. "Reader" for "*io.Reader".
+func embeddedName(name string) string {
+	name = strings.TrimPrefix(name, "*")
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// TagName returns the name given to the field in its tag for key, the part
+// of the value before the first comma, e.g. "id" for `json:"id,omitempty"`.
+// It reports false when the field has no such tag, the name is empty or the
+// tag is "-", which tells encoders to skip the field.
+func (p PropertyInfo) TagName(key string) (string, bool) {
+	value, ok := p.Tags[key]
+	if !ok || value == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(value, ",")
+	return name, name != ""
+}
+
+// TaggedField is a struct field found by FindTaggedFields.
+type TaggedField struct {
+	Struct Node         `json:"struct"`
+	Path   string       `json:"path"` // File of the struct relative to the project root
+	Field  PropertyInfo `json:"field"`
+	Name   string       `json:"name"` // Name under the tag key; the field name if implied
+}
+
+// FindTaggedFields returns the struct fields tagged with key, sorted by
+// struct and then in declaration order. With a name, only fields going by
+// that name under key are returned, so FindTaggedFields(result, "json",
+// "id") finds every JSON payload with an "id" key.
+//
+// For "json", the other exported fields of a struct with json tags are
+// returned too, going by their Go name as encoding/json encodes them;
+// embedded structs are not flattened.
+func FindTaggedFields(result ProjectStructure, key, name string) []TaggedField {
+	nodes := make(map[string]Node, len(result.CodeGraph.Nodes))
+	for _, n := range result.CodeGraph.Nodes {
+		nodes[n.ID] = n
+	}
+
+	var found []TaggedField
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			for _, st := range m.Structs {
+				tagged := false
+				for _, f := range st.Properties {
+					if _, ok := f.Tags[key]; ok {
+						tagged = true
+						break
+					}
+				}
+				if !tagged {
+					continue
+				}
+				for _, f := range st.Properties {
+					tagName, named := f.TagName(key)
+					if !named && key == "json" && f.Exported && !f.Embedded && f.Tags[key] != "-" {
+						tagName, named = f.Name, true
+					}
+					_, hasKey := f.Tags[key]
+					if name == "" && (hasKey || named) || name != "" && named && tagName == name {
+						found = append(found, TaggedField{
+							Struct: nodes[st.ID],
+							Path:   filepath.ToSlash(path),
+							Field:  f,
+							Name:   tagName,
+						})
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Struct.Line < b.Struct.Line
+	})
+	return found
+}
+
+// WriteTaggedFields prints one field per line as the struct's location,
+// the field, its type, the name under the tag key and the whole tag.
+func WriteTaggedFields(w io.Writer, fields []TaggedField) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range fields {
+		name := f.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(tw, "%s:%d\t%s.%s\t%s\t%s\t%s\n", f.Path, f.Struct.Line, f.Struct.QualifiedName(),
+			embeddedName(f.Field.Name), f.Field.Type, name, f.Field.Tag)
+	}
+	return tw.Flush()
+}
+
+// WriteTaggedFieldsJSON prints the fields as an indented JSON array.
+func WriteTaggedFieldsJSON(w io.Writer, fields []TaggedField) error {
+	if fields == nil {
+		fields = []TaggedField{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fields)
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStructTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want map[string]string
+	}{
+		{``, nil},
+		{`json:"id,omitempty" db:"user_id"`, map[string]string{"json": "id,omitempty", "db": "user_id"}},
+		{`json:"a" json:"b"`, map[string]string{"json": "a"}},
+		{`json:"a\"b"`, map[string]string{"json": `a"b`}},
+		{`json:"a" bad db:"x"`, map[string]string{"json": "a"}},
+		{`json:"unterminated`, nil},
+	}
+	for _, tt := range tests {
+		if got := parseStructTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseStructTag(%q) = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestFindTaggedFields(t *testing.T) {
+	files := map[string]string{
+		"api/user.go": "package api\n\n" +
+			"type User struct {\n" +
+			"\tID    int    `json:\"id\" db:\"user_id\"`\n" +
+			"\tName  string `json:\",omitempty\"`\n" +
+			"\tEmail string\n" +
+			"\tpass  string\n" +
+			"\tSkip  bool   `json:\"-\"`\n" +
+			"}\n",
+		"store/row.go": "package store\n\n" +
+			"type Row struct {\n" +
+			"\tKey string `db:\"id\"`\n" +
+			"}\n\n" +
+			"type Plain struct{ ID int }\n",
+	}
+	tests := []struct {
+		key, name string
+		want      []string // Field and the name it goes by
+	}{
+		{key: "json", want: []string{"api.User.ID id", "api.User.Name Name", "api.User.Email Email", "api.User.Skip "}},
+		{key: "json", name: "id", want: []string{"api.User.ID id"}},
+		{key: "db", want: []string{"api.User.ID user_id", "store.Row.Key id"}},
+		{key: "db", name: "id", want: []string{"store.Row.Key id"}},
+		{key: "yaml"},
+	}
+	result := analyzeFiles(t, files)
+	for _, tt := range tests {
+		var got []string
+		for _, f := range FindTaggedFields(result, tt.key, tt.name) {
+			got = append(got, f.Struct.QualifiedName()+"."+f.Field.Name+" "+f.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindTaggedFields(%q, %q) = %q, want %q", tt.key, tt.name, got, tt.want)
+		}
+	}
+}
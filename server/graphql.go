@@ -0,0 +1,876 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// GraphQL exposes the graph as a schema with nested resolvers, so that a
+// client can fetch a package, its structs, their methods and their callers
+// in one request:
+//
+//	{
+//	  package(path: "graph") {
+//	    structs { name methods { name callers { qualifiedName } } }
+//	  }
+//	}
+//
+// Resolvers read the snapshot stored in the request context, so a query
+// running during a re-analysis sees one graph throughout.
+
+type snapshotKey struct{}
+
+func snapOf(ctx context.Context) *snapshot {
+	return ctx.Value(snapshotKey{}).(*snapshot)
+}
+
+// fileSource and packageSource are the values behind File and Package.
+type fileSource struct {
+	Path         string
+	Package      string
+	Lines        int
+	Dependencies []string
+	Structs      []graph.StructInfo
+	Interfaces   []graph.InterfaceInfo
+	Functions    []graph.FunctionInfo
+}
+
+func newFileSource(path string, m graph.ModuleInfo) fileSource {
+	return fileSource{
+		Path:         path,
+		Package:      m.Package,
+		Lines:        m.Lines,
+		Dependencies: m.Dependencies,
+		Structs:      m.Structs,
+		Interfaces:   m.Interfaces,
+		Functions:    m.Functions,
+	}
+}
+
+type packageSource struct {
+	Path  string
+	Files []string
+}
+
+var directionEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Direction",
+	Description: "Which edges of a node to follow",
+	Values: graphql.EnumValueConfigMap{
+		"OUT":  {Value: "out", Description: "Edges from the node"},
+		"IN":   {Value: "in", Description: "Edges to the node"},
+		"BOTH": {Value: "both"},
+	},
+})
+
+// Arguments shared by several fields
+var (
+	edgeArgs = graphql.FieldConfigArgument{
+		"relation":  {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Relations to follow; all when omitted"},
+		"direction": {Type: directionEnum, DefaultValue: "out"},
+	}
+	depthArgs = graphql.FieldConfigArgument{
+		"depth": {Type: graphql.Int, DefaultValue: 1, Description: "Maximum number of hops, 0 for unlimited"},
+	}
+	filterArgs = graphql.FieldConfigArgument{
+		"name":    {Type: graphql.String, Description: "Exact name"},
+		"match":   {Type: graphql.String, Description: "Regular expression matched against the qualified name"},
+		"package": {Type: graphql.String, Description: "Package directory relative to the project root"},
+	}
+)
+
+// newSchema builds the GraphQL schema. Object types refer to each other,
+// so their fields are thunks.
+func newSchema() (graphql.Schema, error) {
+	var nodeType, edgeType, functionType, structType, interfaceType, namedType, fileType, packageType *graphql.Object
+
+	metricsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Metrics",
+		Fields: graphql.Fields{
+			"cyclomatic": {Type: graphql.Int},
+			"cognitive":  {Type: graphql.Int},
+			"lines":      {Type: graphql.Int},
+			"params":     {Type: graphql.Int},
+			"returns":    {Type: graphql.Int},
+			"nesting":    {Type: graphql.Int},
+		},
+	})
+	docType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Doc",
+		Description: "A doc comment parsed into blocks",
+		Fields: graphql.Fields{
+			"blocks": {Type: graphql.NewList(graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+				Name: "DocBlock",
+				Fields: graphql.Fields{
+					"kind":  {Type: graphql.NewNonNull(graphql.String), Description: "paragraph, heading, code or list"},
+					"text":  {Type: graphql.String},
+					"items": {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				},
+			})))},
+			"deprecated": {Type: graphql.String, Description: "Notice of a \"Deprecated:\" paragraph"},
+			"links":      {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Doc link targets"},
+		},
+	})
+	nameType := func(name string) *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"name":    {Type: graphql.String},
+				"type":    {Type: graphql.String},
+				"comment": {Type: graphql.String},
+			},
+		})
+	}
+	parameterType := nameType("Parameter")
+	tagType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StructTag",
+		Description: "One key of a struct tag",
+		Fields: graphql.Fields{
+			"key":   {Type: graphql.NewNonNull(graphql.String)},
+			"value": {Type: graphql.NewNonNull(graphql.String)},
+			"name":  {Type: graphql.NewNonNull(graphql.String), Description: "Value up to the first comma, e.g. the JSON key"},
+		},
+	})
+	propertyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Property",
+		Description: "A struct field; embedded fields are named after their type",
+		Fields: graphql.Fields{
+			"name":     {Type: graphql.String},
+			"type":     {Type: graphql.String},
+			"comment":  {Type: graphql.String},
+			"doc":      {Type: docType},
+			"tag":      {Type: graphql.String, Description: "Struct tag, unquoted"},
+			"exported": {Type: graphql.NewNonNull(graphql.Boolean)},
+			"embedded": {Type: graphql.NewNonNull(graphql.Boolean)},
+			"tags": {
+				Type: graphql.NewList(graphql.NewNonNull(tagType)),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					tags := p.Source.(graph.PropertyInfo).Tags
+					keys := make([]string, 0, len(tags))
+					for key := range tags {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					out := make([]map[string]any, len(keys))
+					for i, key := range keys {
+						name, _, _ := strings.Cut(tags[key], ",")
+						out[i] = map[string]any{"key": key, "value": tags[key], "name": name}
+					}
+					return out, nil
+				},
+			},
+		},
+	})
+
+	nodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Node",
+		Description: "A package, function, method, type, constant or variable of the graph",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         {Type: graphql.NewNonNull(graphql.ID)},
+				"type":       {Type: graphql.NewNonNull(graphql.String)},
+				"name":       {Type: graphql.NewNonNull(graphql.String)},
+				"package":    {Type: graphql.String},
+				"file":       {Type: graphql.String},
+				"receiver":   {Type: graphql.String},
+				"line":       {Type: graphql.Int},
+				"column":     {Type: graphql.Int},
+				"metrics":    {Type: metricsType},
+				"deprecated": {Type: graphql.NewNonNull(graphql.Boolean)},
+				"doc":        {Type: docType, Description: "Doc comment of a package"},
+				"qualifiedName": {
+					Type:    graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(graph.Node).QualifiedName(), nil },
+				},
+				"path": {
+					Type:        graphql.String,
+					Description: "File relative to the project root",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nullable(snapOf(p.Context).fileOf[p.Source.(graph.Node).ID]), nil
+					},
+				},
+				"edges": {
+					Type: graphql.NewList(graphql.NewNonNull(edgeType)),
+					Args: edgeArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return edgesOf(snapOf(p.Context), p.Source.(graph.Node).ID, p.Args), nil
+					},
+				},
+				"neighbors": {
+					Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+					Args: edgeArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap, id := snapOf(p.Context), p.Source.(graph.Node).ID
+						var nodes []graph.Node
+						seen := make(map[string]bool)
+						for _, e := range edgesOf(snap, id, p.Args) {
+							other := e.To
+							if other == id {
+								other = e.From
+							}
+							if n, ok := snap.idx.Nodes[other]; ok && !seen[other] {
+								seen[other] = true
+								nodes = append(nodes, n)
+							}
+						}
+						return nodes, nil
+					},
+				},
+				"callers": {
+					Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+					Args: depthArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return traverse(snapOf(p.Context), p.Source.(graph.Node).ID, graph.Backward, p.Args), nil
+					},
+				},
+				"callees": {
+					Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+					Args: depthArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return traverse(snapOf(p.Context), p.Source.(graph.Node).ID, graph.Forward, p.Args), nil
+					},
+				},
+				"struct": {
+					Type: structType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						st, ok := snapOf(p.Context).structs[p.Source.(graph.Node).ID]
+						return found(st, ok), nil
+					},
+				},
+				"interface": {
+					Type: interfaceType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						it, ok := snapOf(p.Context).interfaces[p.Source.(graph.Node).ID]
+						return found(it, ok), nil
+					},
+				},
+				"namedType": {
+					Type: namedType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						t, ok := snapOf(p.Context).types[p.Source.(graph.Node).ID]
+						return found(t, ok), nil
+					},
+				},
+				"function": {
+					Type: functionType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						fn, ok := snapOf(p.Context).functions[p.Source.(graph.Node).ID]
+						return found(fn, ok), nil
+					},
+				},
+			}
+		}),
+	})
+
+	edgeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Edge",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"relation": {Type: graphql.NewNonNull(graphql.String)},
+				"line":     {Type: graphql.Int},
+				"column":   {Type: graphql.Int},
+				"from": {
+					Type: nodeType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nodeByID(snapOf(p.Context), p.Source.(graph.Edge).From), nil
+					},
+				},
+				"to": {
+					Type: nodeType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nodeByID(snapOf(p.Context), p.Source.(graph.Edge).To), nil
+					},
+				},
+			}
+		}),
+	})
+
+	// nodeField resolves the node of a declaration
+	nodeField := func(id func(any) string) *graphql.Field {
+		return &graphql.Field{
+			Type: nodeType,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return nodeByID(snapOf(p.Context), id(p.Source)), nil
+			},
+		}
+	}
+	// fileField resolves the path of the file declaring a declaration
+	fileField := func(id func(any) string) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return nullable(snapOf(p.Context).fileOf[id(p.Source)]), nil
+			},
+		}
+	}
+	functionID := func(v any) string { return v.(graph.FunctionInfo).ID }
+	structID := func(v any) string { return v.(graph.StructInfo).ID }
+	interfaceID := func(v any) string { return v.(graph.InterfaceInfo).ID }
+	namedTypeID := func(v any) string { return v.(graph.TypeInfo).ID }
+
+	functionType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FunctionInfo",
+		Description: "A function or method declaration",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         {Type: graphql.NewNonNull(graphql.ID)},
+				"name":       {Type: graphql.NewNonNull(graphql.String)},
+				"package":    {Type: graphql.String},
+				"parameters": {Type: graphql.NewList(graphql.NewNonNull(parameterType))},
+				"returnType": {Type: graphql.String},
+				"comment":    {Type: graphql.String},
+				"doc":        {Type: docType},
+				"content":    {Type: graphql.String},
+				"file":       fileField(functionID),
+				"node":       nodeField(functionID),
+				"receiver": {
+					Type: structType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						for _, e := range snap.idx.In[functionID(p.Source)] {
+							if st, ok := snap.structs[e.From]; ok && e.Relation == "has_method" {
+								return st, nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"callers": {
+					Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+					Args: depthArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return traverse(snapOf(p.Context), functionID(p.Source), graph.Backward, p.Args), nil
+					},
+				},
+				"callees": {
+					Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+					Args: depthArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return traverse(snapOf(p.Context), functionID(p.Source), graph.Forward, p.Args), nil
+					},
+				},
+			}
+		}),
+	})
+
+	structType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "StructInfo",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         {Type: graphql.NewNonNull(graphql.ID)},
+				"name":       {Type: graphql.NewNonNull(graphql.String)},
+				"comment":    {Type: graphql.String},
+				"doc":        {Type: docType},
+				"properties": {Type: graphql.NewList(graphql.NewNonNull(propertyType))},
+				"file":       fileField(structID),
+				"node":       nodeField(structID),
+				"methods": {
+					Type: graphql.NewList(graphql.NewNonNull(functionType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(graph.StructInfo).Functions, nil
+					},
+				},
+				"implements": {
+					Type: graphql.NewList(graphql.NewNonNull(interfaceType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						return related(snap.idx.Out[structID(p.Source)], "implements", false, snap.interfaces), nil
+					},
+				},
+				"embeds": {
+					Type: graphql.NewList(graphql.NewNonNull(structType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						return related(snap.idx.Out[structID(p.Source)], "embeds", false, snap.structs), nil
+					},
+				},
+				"embeddedBy": {
+					Type: graphql.NewList(graphql.NewNonNull(structType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						return related(snap.idx.In[structID(p.Source)], "embeds", true, snap.structs), nil
+					},
+				},
+				"usedBy": {
+					Type:        graphql.NewList(graphql.NewNonNull(nodeType)),
+					Description: "Declarations referring to the struct",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						var nodes []graph.Node
+						for _, id := range relatedIDs(snap.idx.In[structID(p.Source)], "references", true) {
+							nodes = append(nodes, snap.idx.Nodes[id])
+						}
+						return nodes, nil
+					},
+				},
+			}
+		}),
+	})
+
+	interfaceType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "InterfaceInfo",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				"name":    {Type: graphql.NewNonNull(graphql.String)},
+				"comment": {Type: graphql.String},
+				"doc":     {Type: docType},
+				"file":    fileField(interfaceID),
+				"node":    nodeField(interfaceID),
+				"methods": {
+					Type: graphql.NewList(graphql.NewNonNull(functionType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(graph.InterfaceInfo).Functions, nil
+					},
+				},
+				"implementedBy": {
+					Type: graphql.NewList(graphql.NewNonNull(structType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						return related(snap.idx.In[interfaceID(p.Source)], "implements", true, snap.structs), nil
+					},
+				},
+			}
+		}),
+	})
+
+	namedType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TypeInfo",
+		Description: "A named type other than a struct or interface",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				"name":    {Type: graphql.NewNonNull(graphql.String)},
+				"type":    {Type: graphql.NewNonNull(graphql.String), Description: "Underlying type, or the aliased type"},
+				"alias":   {Type: graphql.NewNonNull(graphql.Boolean)},
+				"comment": {Type: graphql.String},
+				"doc":     {Type: docType},
+				"file":    fileField(namedTypeID),
+				"node":    nodeField(namedTypeID),
+				"methods": {
+					Type: graphql.NewList(graphql.NewNonNull(functionType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(graph.TypeInfo).Functions, nil
+					},
+				},
+				"implements": {
+					Type: graphql.NewList(graphql.NewNonNull(interfaceType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						return related(snap.idx.Out[namedTypeID(p.Source)], "implements", false, snap.interfaces), nil
+					},
+				},
+			}
+		}),
+	})
+
+	fileType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "File",
+		Description: "An analyzed file and its declarations",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"path":         {Type: graphql.NewNonNull(graphql.String)},
+				"package":      {Type: graphql.String},
+				"lines":        {Type: graphql.Int},
+				"dependencies": {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"structs":      {Type: graphql.NewList(graphql.NewNonNull(structType))},
+				"interfaces":   {Type: graphql.NewList(graphql.NewNonNull(interfaceType))},
+				"types":        {Type: graphql.NewList(graphql.NewNonNull(namedType))},
+				"functions":    {Type: graphql.NewList(graphql.NewNonNull(functionType))},
+			}
+		}),
+	})
+
+	// packageDecls lists a kind of declaration over the files of a package
+	packageDecls := func(t *graphql.Object, decls func(graph.ModuleInfo) any) *graphql.Field {
+		return &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(t)),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				snap := snapOf(p.Context)
+				var all []any
+				for _, f := range p.Source.(packageSource).Files {
+					all = appendAll(all, decls(snap.modules[f]))
+				}
+				return all, nil
+			},
+		}
+	}
+	packageType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Package",
+		Description: "A directory of the project",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"path": {Type: graphql.NewNonNull(graphql.String)},
+				"name": {
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						pkg := p.Source.(packageSource)
+						return nullable(snapOf(p.Context).modules[pkg.Files[0]].Package), nil
+					},
+				},
+				"files": {
+					Type: graphql.NewList(graphql.NewNonNull(fileType)),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						snap := snapOf(p.Context)
+						var files []fileSource
+						for _, f := range p.Source.(packageSource).Files {
+							files = append(files, newFileSource(f, snap.modules[f]))
+						}
+						return files, nil
+					},
+				},
+				"structs":    packageDecls(structType, func(m graph.ModuleInfo) any { return m.Structs }),
+				"interfaces": packageDecls(interfaceType, func(m graph.ModuleInfo) any { return m.Interfaces }),
+				"types":      packageDecls(namedType, func(m graph.ModuleInfo) any { return m.Types }),
+				"functions":  packageDecls(functionType, func(m graph.ModuleInfo) any { return m.Functions }),
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"node": {
+				Type: nodeType,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return nodeByID(snapOf(p.Context), p.Args["id"].(string)), nil
+				},
+			},
+			"nodes": {
+				Type: graphql.NewList(graphql.NewNonNull(nodeType)),
+				Args: graphql.FieldConfigArgument{
+					"symbol":  {Type: graphql.String, Description: "Symbol such as pkg.Type.Method"},
+					"name":    filterArgs["name"],
+					"match":   filterArgs["match"],
+					"type":    {Type: graphql.String, Description: "Node type such as function or struct"},
+					"package": {Type: graphql.String, Description: "Package name"},
+					"limit":   {Type: graphql.Int, DefaultValue: 100},
+				},
+				Resolve: resolveNodes,
+			},
+			"structs": {
+				Type: graphql.NewList(graphql.NewNonNull(structType)),
+				Args: filterArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					snap := snapOf(p.Context)
+					return declarations(snap, snap.structs, p.Args)
+				},
+			},
+			"interfaces": {
+				Type: graphql.NewList(graphql.NewNonNull(interfaceType)),
+				Args: filterArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					snap := snapOf(p.Context)
+					return declarations(snap, snap.interfaces, p.Args)
+				},
+			},
+			"types": {
+				Type:        graphql.NewList(graphql.NewNonNull(namedType)),
+				Description: "Named types other than structs and interfaces",
+				Args:        filterArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					snap := snapOf(p.Context)
+					return declarations(snap, snap.types, p.Args)
+				},
+			},
+			"functions": {
+				Type:        graphql.NewList(graphql.NewNonNull(functionType)),
+				Description: "Functions and methods",
+				Args:        filterArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					snap := snapOf(p.Context)
+					return declarations(snap, snap.functions, p.Args)
+				},
+			},
+			"file": {
+				Type: fileType,
+				Args: graphql.FieldConfigArgument{"path": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					path := p.Args["path"].(string)
+					m, ok := snapOf(p.Context).modules[path]
+					return found(newFileSource(path, m), ok), nil
+				},
+			},
+			"files": {
+				Type: graphql.NewList(graphql.NewNonNull(fileType)),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					snap := snapOf(p.Context)
+					files := make([]fileSource, len(snap.files))
+					for i, f := range snap.files {
+						files[i] = newFileSource(f, snap.modules[f])
+					}
+					return files, nil
+				},
+			},
+			"package": {
+				Type: packageType,
+				Args: graphql.FieldConfigArgument{"path": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					for _, pkg := range packages(snapOf(p.Context)) {
+						if pkg.Path == p.Args["path"].(string) {
+							return pkg, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+			"packages": {
+				Type: graphql.NewList(graphql.NewNonNull(packageType)),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return packages(snapOf(p.Context)), nil
+				},
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// nullable turns an empty string into null.
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// found returns v, or nil when it was not found, which GraphQL reports
+// as null.
+func found(v any, ok bool) any {
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func nodeByID(snap *snapshot, id string) any {
+	n, ok := snap.idx.Nodes[id]
+	return found(n, ok)
+}
+
+// edgesOf returns the edges at id selected by the relation and direction
+// arguments.
+func edgesOf(snap *snapshot, id string, args map[string]any) []graph.Edge {
+	var edges []graph.Edge
+	switch args["direction"] {
+	case "out":
+		edges = snap.idx.Out[id]
+	case "in":
+		edges = snap.idx.In[id]
+	default:
+		edges = append(append(edges, snap.idx.Out[id]...), snap.idx.In[id]...)
+	}
+	relations := stringList(args["relation"])
+	if len(relations) == 0 {
+		return edges
+	}
+	var out []graph.Edge
+	for _, e := range edges {
+		if contains(relations, e.Relation) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// traverse returns the nodes reached from id over calls edges, up to the
+// depth argument, without id itself.
+func traverse(snap *snapshot, id string, dir graph.Direction, args map[string]any) []graph.Node {
+	opts := graph.TraverseOptions{Depth: args["depth"].(int), Relations: []string{"calls"}}
+	var nodes []graph.Node
+	for _, st := range snap.idx.Traverse([]string{id}, dir, opts) {
+		if st.Depth > 0 {
+			nodes = append(nodes, st.Node)
+		}
+	}
+	return nodes
+}
+
+// relatedIDs returns the distinct ends of the edges of one relation: their
+// sources when in is set, otherwise their targets.
+func relatedIDs(edges []graph.Edge, relation string, in bool) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, e := range edges {
+		id := e.To
+		if in {
+			id = e.From
+		}
+		if e.Relation == relation && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// related looks up the declarations at the ends of the edges of one
+// relation.
+func related[T any](edges []graph.Edge, relation string, in bool, decls map[string]T) []T {
+	var out []T
+	for _, id := range relatedIDs(edges, relation, in) {
+		if d, ok := decls[id]; ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func resolveNodes(p graphql.ResolveParams) (any, error) {
+	snap := snapOf(p.Context)
+	var re *regexp.Regexp
+	if m, ok := p.Args["match"].(string); ok {
+		var err error
+		if re, err = regexp.Compile(m); err != nil {
+			return nil, err
+		}
+	}
+	var candidates []graph.Node
+	if symbol, ok := p.Args["symbol"].(string); ok {
+		candidates = snap.idx.Resolve(symbol)
+	} else {
+		for _, n := range snap.idx.Nodes {
+			candidates = append(candidates, n)
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	}
+	name, _ := p.Args["name"].(string)
+	typ, _ := p.Args["type"].(string)
+	pkg, _ := p.Args["package"].(string)
+	limit := p.Args["limit"].(int)
+	var nodes []graph.Node
+	for _, n := range candidates {
+		if name != "" && n.Name != name || typ != "" && n.Type != typ || pkg != "" && n.Package != pkg ||
+			re != nil && !re.MatchString(n.QualifiedName()) {
+			continue
+		}
+		if limit > 0 && len(nodes) == limit {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// declarations filters declarations by the name, match and package
+// arguments, ordered by file and name.
+func declarations[T any](snap *snapshot, decls map[string]T, args map[string]any) ([]T, error) {
+	var re *regexp.Regexp
+	if m, ok := args["match"].(string); ok {
+		var err error
+		if re, err = regexp.Compile(m); err != nil {
+			return nil, err
+		}
+	}
+	name, _ := args["name"].(string)
+	pkg, hasPkg := args["package"].(string)
+	var ids []string
+	for id := range decls {
+		n := snap.idx.Nodes[id]
+		file := snap.fileOf[id]
+		if name != "" && n.Name != name || hasPkg && path.Dir(file) != pkg ||
+			re != nil && !re.MatchString(n.QualifiedName()) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := ids[i], ids[j]
+		if snap.fileOf[a] != snap.fileOf[b] {
+			return snap.fileOf[a] < snap.fileOf[b]
+		}
+		return snap.idx.Nodes[a].Line < snap.idx.Nodes[b].Line
+	})
+	out := make([]T, len(ids))
+	for i, id := range ids {
+		out[i] = decls[id]
+	}
+	return out, nil
+}
+
+// packages groups the analyzed files by directory.
+func packages(snap *snapshot) []packageSource {
+	var pkgs []packageSource
+	for _, f := range snap.files {
+		dir := path.Dir(f)
+		if len(pkgs) == 0 || pkgs[len(pkgs)-1].Path != dir {
+			pkgs = append(pkgs, packageSource{Path: dir})
+		}
+		pkgs[len(pkgs)-1].Files = append(pkgs[len(pkgs)-1].Files, f)
+	}
+	return pkgs
+}
+
+func appendAll(all []any, list any) []any {
+	switch list := list.(type) {
+	case []graph.StructInfo:
+		for _, v := range list {
+			all = append(all, v)
+		}
+	case []graph.InterfaceInfo:
+		for _, v := range list {
+			all = append(all, v)
+		}
+	case []graph.FunctionInfo:
+		for _, v := range list {
+			all = append(all, v)
+		}
+	}
+	return all
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, s := range list {
+		out = append(out, s.(string))
+	}
+	return out
+}
+
+// graphqlRequest is the body of a POST request, as sent by GraphQL
+// clients.
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// handleGraphQL answers GET ?query= and POST requests with a JSON body.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, nil, badRequest("invalid request body: %v", err))
+			return
+		}
+	} else {
+		q := r.URL.Query()
+		req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeJSON(w, nil, badRequest("invalid variables: %v", err))
+				return
+			}
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		writeJSON(w, nil, badRequest("missing query"))
+		return
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        context.WithValue(r.Context(), snapshotKey{}, s.current()),
+	})
+	writeJSON(w, result, nil)
+}
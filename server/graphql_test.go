@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"testing/fstest"
+)
+
+const testStore = `package main
+
+// Store keeps one value.
+type Store struct{ n int }
+
+func (s *Store) Put(v int) { s.n = v }
+
+func NewStore() *Store { return &Store{} }
+
+func main() {
+	s := NewStore()
+	s.Put(1)
+}
+`
+
+func TestGraphQL(t *testing.T) {
+	_, ts := startHTTP(t, fstest.MapFS{"main.go": {Data: []byte(testStore)}})
+	tests := []struct {
+		name  string
+		query string
+		want  string // Compact JSON response
+	}{
+		{
+			name:  "nested relations",
+			query: `{ structs(name: "Store") { name comment methods { name } } functions(name: "NewStore") { callers { qualifiedName path } } }`,
+			want:  `{"data":{"functions":[{"callers":[{"path":"main.go","qualifiedName":"main.main"}]}],"structs":[{"comment":"Store keeps one value.","methods":[{"name":"Put"}],"name":"Store"}]}}`,
+		},
+		{
+			name:  "symbol",
+			query: `{ nodes(symbol: "Store.Put") { type receiver line } }`,
+			want:  `{"data":{"nodes":[{"line":6,"receiver":"Store","type":"method"}]}}`,
+		},
+		{
+			name:  "package",
+			query: `{ package(path: ".") { name structs { name } functions { name } } }`,
+			want:  `{"data":{"package":{"functions":[{"name":"NewStore"},{"name":"main"}],"name":"main","structs":[{"name":"Store"}]}}}`,
+		},
+		{
+			name:  "missing node",
+			query: `{ node(id: "nope") { name } }`,
+			want:  `{"data":{"node":null}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(graphqlRequest{Query: tt.query})
+			resp, err := http.Post(ts.URL+"/graphql", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var got any
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			compact, _ := json.Marshal(got)
+			if string(compact) != tt.want {
+				t.Errorf("response = %s\nwant %s", compact, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	_, ts := startHTTP(t, fstest.MapFS{"main.go": {Data: []byte(testStore)}})
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"missing query", "/graphql", http.StatusBadRequest},
+		{"invalid variables", "/graphql?query=%7Bfiles%7D&variables=%7B", http.StatusBadRequest},
+		{"unknown field", "/graphql?query=" + url.QueryEscape("{ nope }"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var body map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if body["error"] == nil && body["errors"] == nil {
+				t.Errorf("response %v reports no error", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package server
+
+//go:generate protoc --proto_path=../proto --go_out=.. --go_opt=module=github.com/srinidhi-metadome/go-codegraph-cli --go-grpc_out=.. --go-grpc_opt=module=github.com/srinidhi-metadome/go-codegraph-cli codegraph/v1/service.proto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/codegraphpb"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCServer returns a gRPC server offering CodeGraphService, defined in
+// proto/codegraph/v1/service.proto, over the graph of s. It is not yet
+// serving; pass it a listener, which in tests can be an in-process one:
+//
+//	lis := bufconn.Listen(1 << 20)
+//	go s.GRPCServer().Serve(lis)
+//	conn, err := grpc.NewClient("passthrough:///bufnet",
+//		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+//			return lis.DialContext(ctx)
+//		}),
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	client := codegraphpb.NewCodeGraphServiceClient(conn)
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	gs := grpc.NewServer(opts...)
+	codegraphpb.RegisterCodeGraphServiceServer(gs, &grpcService{s: s})
+	return gs
+}
+
+// grpcService implements CodeGraphService.
+type grpcService struct {
+	codegraphpb.UnimplementedCodeGraphServiceServer
+	s *Server
+}
+
+func (g *grpcService) Analyze(ctx context.Context, req *codegraphpb.AnalyzeRequest) (*codegraphpb.AnalyzeResponse, error) {
+	if err := g.s.Reload(); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &codegraphpb.AnalyzeResponse{Summary: g.s.current().summaryProto()}, nil
+}
+
+func (g *grpcService) GetNode(ctx context.Context, req *codegraphpb.GetNodeRequest) (*codegraphpb.GetNodeResponse, error) {
+	snap := g.s.current()
+	n, ok := snap.idx.Nodes[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no node %q", req.GetId())
+	}
+	return &codegraphpb.GetNodeResponse{
+		Node: graph.NodeToProto(n),
+		Path: snap.fileOf[n.ID],
+		Out:  edgesToProto(snap.idx.Out[n.ID]),
+		In:   edgesToProto(snap.idx.In[n.ID]),
+	}, nil
+}
+
+// Neighbors traverses outgoing, incoming or both kinds of edges up to the
+// requested depth; with both, a node reachable either way is listed once
+// for each.
+func (g *grpcService) Neighbors(ctx context.Context, req *codegraphpb.NeighborsRequest) (*codegraphpb.NeighborsResponse, error) {
+	snap := g.s.current()
+	if _, ok := snap.idx.Nodes[req.GetId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "no node %q", req.GetId())
+	}
+	if req.GetDepth() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid depth %d", req.GetDepth())
+	}
+	var dirs []graph.Direction
+	switch req.GetDirection() {
+	case codegraphpb.Direction_DIRECTION_OUT:
+		dirs = []graph.Direction{graph.Forward}
+	case codegraphpb.Direction_DIRECTION_IN:
+		dirs = []graph.Direction{graph.Backward}
+	case codegraphpb.Direction_DIRECTION_BOTH, codegraphpb.Direction_DIRECTION_UNSPECIFIED:
+		dirs = []graph.Direction{graph.Forward, graph.Backward}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid direction %v", req.GetDirection())
+	}
+	opts := graph.TraverseOptions{Depth: int(req.GetDepth()), Relations: req.GetRelations()}
+	if opts.Depth == 0 {
+		opts.Depth = 1
+	}
+
+	resp := &codegraphpb.NeighborsResponse{}
+	for _, dir := range dirs {
+		for _, st := range snap.idx.Traverse([]string{req.GetId()}, dir, opts) {
+			if st.Depth == 0 {
+				continue
+			}
+			resp.Neighbors = append(resp.Neighbors, &codegraphpb.Neighbor{
+				Node:  graph.NodeToProto(st.Node),
+				Edge:  graph.EdgeToProto(st.Edge),
+				Depth: int32(st.Depth),
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (g *grpcService) Query(ctx context.Context, req *codegraphpb.QueryRequest) (*codegraphpb.QueryResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing query")
+	}
+	q, err := graph.ParseQuery(req.GetQuery())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := q.Run(g.s.current().idx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &codegraphpb.QueryResponse{Columns: res.Columns}
+	for _, row := range res.Rows {
+		out := &codegraphpb.QueryRow{Values: make([]*codegraphpb.QueryValue, len(row))}
+		for i, v := range row {
+			out.Values[i] = queryValueToProto(v)
+		}
+		resp.Rows = append(resp.Rows, out)
+	}
+	return resp, nil
+}
+
+// WatchChanges sends a GraphChange after each reload that changed the
+// graph; reloads finding the same graph send nothing. Reloads happening
+// while a change is being sent are reported together in the next one.
+func (g *grpcService) WatchChanges(req *codegraphpb.WatchChangesRequest, stream grpc.ServerStreamingServer[codegraphpb.GraphChange]) error {
+	reloaded, cancel := g.s.subscribe()
+	defer cancel()
+
+	last := g.s.current()
+	if req.GetInitial() {
+		if err := stream.Send(&codegraphpb.GraphChange{Summary: last.summaryProto()}); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-reloaded:
+		}
+		snap := g.s.current()
+		if snap == last {
+			continue
+		}
+		d := graph.Diff(last.result, snap.result)
+		last = snap
+		if d.Empty() {
+			continue
+		}
+		if err := stream.Send(graphChangeToProto(snap, d)); err != nil {
+			return err
+		}
+	}
+}
+
+func (snap *snapshot) summaryProto() *codegraphpb.ProjectSummary {
+	sum := snap.summary()
+	return &codegraphpb.ProjectSummary{
+		SchemaVersion: uint32(sum.SchemaVersion),
+		Nodes:         int32(sum.Nodes),
+		Edges:         int32(sum.Edges),
+		Files:         int32(sum.Files),
+		AnalyzedAt:    timestamppb.New(sum.AnalyzedAt),
+	}
+}
+
+func graphChangeToProto(snap *snapshot, d *graph.GraphDiff) *codegraphpb.GraphChange {
+	msg := &codegraphpb.GraphChange{
+		Summary:      snap.summaryProto(),
+		AddedNodes:   nodesToProto(d.AddedNodes),
+		RemovedNodes: nodesToProto(d.RemovedNodes),
+		AddedEdges:   edgeRefsToProto(d.AddedEdges),
+		RemovedEdges: edgeRefsToProto(d.RemovedEdges),
+	}
+	for _, c := range d.ChangedNodes {
+		nc := &codegraphpb.NodeChange{
+			Node:      graph.NodeToProto(c.Node),
+			File:      valueChangeToProto(c.File),
+			Signature: valueChangeToProto(c.Signature),
+			Type:      valueChangeToProto(c.Type),
+			Value:     valueChangeToProto(c.Value),
+		}
+		for _, f := range c.Fields {
+			nc.Fields = append(nc.Fields, &codegraphpb.FieldChange{Name: f.Name, Old: f.Old, New: f.New})
+		}
+		msg.ChangedNodes = append(msg.ChangedNodes, nc)
+	}
+	for _, dep := range d.AddedDependencies {
+		msg.AddedDependencies = append(msg.AddedDependencies, &codegraphpb.Dependency{From: dep.From, To: dep.To})
+	}
+	for _, dep := range d.RemovedDependencies {
+		msg.RemovedDependencies = append(msg.RemovedDependencies, &codegraphpb.Dependency{From: dep.From, To: dep.To})
+	}
+	return msg
+}
+
+func valueChangeToProto(c *graph.ValueChange) *codegraphpb.ValueChange {
+	if c == nil {
+		return nil
+	}
+	return &codegraphpb.ValueChange{Old: c.Old, New: c.New}
+}
+
+func nodesToProto(nodes []graph.Node) []*codegraphpb.Node {
+	out := make([]*codegraphpb.Node, len(nodes))
+	for i, n := range nodes {
+		out[i] = graph.NodeToProto(n)
+	}
+	return out
+}
+
+func edgesToProto(edges []graph.Edge) []*codegraphpb.Edge {
+	out := make([]*codegraphpb.Edge, len(edges))
+	for i, e := range edges {
+		out[i] = graph.EdgeToProto(e)
+	}
+	return out
+}
+
+func edgeRefsToProto(refs []graph.EdgeRef) []*codegraphpb.EdgeRef {
+	out := make([]*codegraphpb.EdgeRef, len(refs))
+	for i, r := range refs {
+		out[i] = &codegraphpb.EdgeRef{From: r.From, Relation: r.Relation, To: r.To}
+	}
+	return out
+}
+
+// queryValueToProto converts a query result cell; nil stays an empty
+// QueryValue.
+func queryValueToProto(v any) *codegraphpb.QueryValue {
+	switch v := v.(type) {
+	case nil:
+		return &codegraphpb.QueryValue{}
+	case string:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_StringValue{StringValue: v}}
+	case int:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_IntValue{IntValue: int64(v)}}
+	case bool:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_BoolValue{BoolValue: v}}
+	case graph.Node:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_NodeValue{NodeValue: graph.NodeToProto(v)}}
+	case graph.Edge:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_EdgeValue{EdgeValue: graph.EdgeToProto(v)}}
+	default:
+		return &codegraphpb.QueryValue{Kind: &codegraphpb.QueryValue_StringValue{StringValue: fmt.Sprint(v)}}
+	}
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/codegraphpb"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testMain = `package main
+
+func helper() int { return 1 }
+
+func main() { helper() }
+`
+
+// startGRPC serves the project in files over an in-process listener. The
+// files are analyzed again on every Analyze call, so tests can change them
+// in between.
+func startGRPC(t *testing.T, files fstest.MapFS) (*Server, codegraphpb.CodeGraphServiceClient) {
+	t.Helper()
+	s, err := New(func() (graph.ProjectStructure, error) {
+		return graph.AnalyzeFS(files, "test")
+	}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := s.GRPCServer()
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return s, codegraphpb.NewCodeGraphServiceClient(conn)
+}
+
+// functionID returns the ID of function name in the graph s serves.
+func functionID(t *testing.T, s *Server, name string) string {
+	t.Helper()
+	for _, n := range s.Project().CodeGraph.Nodes {
+		if n.Type == "function" && n.Name == name {
+			return n.ID
+		}
+	}
+	t.Fatalf("no function %s", name)
+	return ""
+}
+
+func TestGRPCUnary(t *testing.T) {
+	s, client := startGRPC(t, fstest.MapFS{"main.go": {Data: []byte(testMain)}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	analyzed, err := client.Analyze(ctx, &codegraphpb.AnalyzeRequest{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if sum := analyzed.GetSummary(); sum.GetFiles() != 1 || sum.GetNodes() == 0 || sum.GetEdges() == 0 {
+		t.Errorf("Analyze summary = %v, want 1 file with nodes and edges", sum)
+	}
+	mainID, helperID := functionID(t, s, "main"), functionID(t, s, "helper")
+
+	got, err := client.GetNode(ctx, &codegraphpb.GetNodeRequest{Id: mainID})
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if got.GetNode().GetName() != "main" || got.GetNode().GetType() != "function" || got.GetPath() != "main.go" {
+		t.Errorf("GetNode = %v %v in %q, want function main in main.go", got.GetNode().GetType(), got.GetNode().GetName(), got.GetPath())
+	}
+	calls := false
+	for _, e := range got.GetOut() {
+		calls = calls || e.GetRelation() == "calls" && e.GetTo() == helperID
+	}
+	if !calls {
+		t.Errorf("GetNode out edges = %v, want a call to helper", got.GetOut())
+	}
+
+	_, err = client.GetNode(ctx, &codegraphpb.GetNodeRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetNode of a missing node: got %v, want NotFound", err)
+	}
+
+	neighbors, err := client.Neighbors(ctx, &codegraphpb.NeighborsRequest{
+		Id:        mainID,
+		Relations: []string{"calls"},
+		Direction: codegraphpb.Direction_DIRECTION_OUT,
+	})
+	if err != nil {
+		t.Fatalf("Neighbors: %v", err)
+	}
+	if ns := neighbors.GetNeighbors(); len(ns) != 1 || ns[0].GetNode().GetId() != helperID || ns[0].GetDepth() != 1 {
+		t.Errorf("Neighbors = %v, want helper at depth 1", ns)
+	}
+
+	_, err = client.Neighbors(ctx, &codegraphpb.NeighborsRequest{Id: mainID, Depth: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Neighbors with a negative depth: got %v, want InvalidArgument", err)
+	}
+
+	result, err := client.Query(ctx, &codegraphpb.QueryRequest{
+		Query: `MATCH (m:function {name: "main"})-[:calls]->(f:function) RETURN f.name AS callee, f`,
+	})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if cols := result.GetColumns(); len(cols) != 2 || cols[0] != "callee" {
+		t.Errorf("Query columns = %v, want [callee f]", cols)
+	}
+	if rows := result.GetRows(); len(rows) != 1 ||
+		rows[0].GetValues()[0].GetStringValue() != "helper" || rows[0].GetValues()[1].GetNodeValue().GetId() != helperID {
+		t.Errorf("Query rows = %v, want helper", rows)
+	}
+
+	_, err = client.Query(ctx, &codegraphpb.QueryRequest{Query: "MATCH"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Query with a syntax error: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestGRPCWatchChanges(t *testing.T) {
+	files := fstest.MapFS{"main.go": {Data: []byte(testMain)}}
+	_, client := startGRPC(t, files)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchChanges(ctx, &codegraphpb.WatchChangesRequest{Initial: true})
+	if err != nil {
+		t.Fatalf("WatchChanges: %v", err)
+	}
+	initial, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving the initial summary: %v", err)
+	}
+	if initial.GetSummary().GetFiles() != 1 || len(initial.GetAddedNodes()) != 0 {
+		t.Errorf("initial change = %v, want the summary of 1 file and no changes", initial)
+	}
+
+	// A reload finding the same graph sends nothing, so the next change
+	// received is the one adding extra
+	if _, err := client.Analyze(ctx, &codegraphpb.AnalyzeRequest{}); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	files["extra.go"] = &fstest.MapFile{Data: []byte("package main\n\nfunc extra() { helper() }\n")}
+	if _, err := client.Analyze(ctx, &codegraphpb.AnalyzeRequest{}); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	change, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("receiving the change: %v", err)
+	}
+	if added := change.GetAddedNodes(); len(added) != 1 || added[0].GetName() != "extra" {
+		t.Errorf("added nodes = %v, want extra", added)
+	}
+	if len(change.GetRemovedNodes()) != 0 {
+		t.Errorf("removed nodes = %v, want none", change.GetRemovedNodes())
+	}
+	edge := false
+	for _, e := range change.GetAddedEdges() {
+		edge = edge || e.GetFrom() == "main.extra" && e.GetRelation() == "calls" && e.GetTo() == "main.helper"
+	}
+	if !edge {
+		t.Errorf("added edges = %v, want main.extra calls main.helper", change.GetAddedEdges())
+	}
+	if change.GetSummary().GetFiles() != 2 {
+		t.Errorf("summary after the change = %v, want 2 files", change.GetSummary())
+	}
+}
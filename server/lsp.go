@@ -0,0 +1,515 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// The Language Server Protocol is spoken in part: call hierarchy, type
+// hierarchy, workspace symbols and references, answered from the graph
+// instead of by type-checking. Positions come from the last analysis, so
+// they lag behind unsaved edits. Columns are byte offsets, which agree
+// with the UTF-16 offsets of LSP for ASCII source.
+
+// JSON-RPC error codes defined by LSP
+const (
+	lspServerNotInitialized = -32002
+	lspRequestFailed        = -32803
+)
+
+// LSP symbol kinds
+var lspSymbolKinds = map[string]int{
+	"function":          12,
+	"method":            6,
+	"interface_method":  6,
+	"struct":            23,
+	"interface":         11,
+	"type":              5,
+	"constant":          14,
+	"variable":          13,
+	"external_function": 12,
+	"package":           4,
+}
+
+// lspDeprecated is the symbol tag of deprecated declarations
+const lspDeprecated = 1
+
+type lspPosition struct {
+	Line      int `json:"line"`      // 0-based
+	Character int `json:"character"` // 0-based
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspTextDocumentPosition struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+// lspHierarchyItem is both a CallHierarchyItem and a TypeHierarchyItem.
+type lspHierarchyItem struct {
+	Name           string   `json:"name"`
+	Kind           int      `json:"kind"`
+	Tags           []int    `json:"tags,omitempty"`
+	Detail         string   `json:"detail,omitempty"`
+	URI            string   `json:"uri"`
+	Range          lspRange `json:"range"`
+	SelectionRange lspRange `json:"selectionRange"`
+}
+
+type lspSymbolInformation struct {
+	Name          string      `json:"name"`
+	Kind          int         `json:"kind"`
+	Tags          []int       `json:"tags,omitempty"`
+	Location      lspLocation `json:"location"`
+	ContainerName string      `json:"containerName,omitempty"`
+}
+
+// lspOccurrence is a definition of, or reference to, a node in a file.
+type lspOccurrence struct {
+	node   string
+	line   int // 1-based
+	column int // 1-based byte column
+}
+
+// lspSession is the state of one client connection.
+type lspSession struct {
+	s           *Server
+	root        string // Absolute project directory, slash-separated
+	initialized bool
+	shutdown    bool
+
+	// Occurrences per file, computed for snap
+	snap        *snapshot
+	occurrences map[string][]lspOccurrence
+}
+
+// ServeLSP answers LSP requests read from r on w until the client exits or
+// r is exhausted. root is the project directory that the graph's file paths
+// are relative to.
+func (s *Server) ServeLSP(r io.Reader, w io.Writer, root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	l := &lspSession{s: s, root: filepath.ToSlash(abs)}
+	in := bufio.NewReader(r)
+	for {
+		body, err := readLSPMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := writeLSPMessage(w, rpcResponse{ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.ID == nil {
+			// Notifications: only exit needs handling; documents are read
+			// from the graph, not tracked
+			if req.Method == "exit" {
+				return nil
+			}
+			continue
+		}
+		resp := rpcResponse{ID: req.ID}
+		if resp.Result, err = l.handle(req); err != nil {
+			resp.Result, resp.Error = nil, toRPCError(err)
+		}
+		if err := writeLSPMessage(w, resp); err != nil {
+			return err
+		}
+	}
+}
+
+// readLSPMessage reads one message framed by a Content-Length header.
+func readLSPMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	_, err = io.ReadFull(r, body)
+	return body, err
+}
+
+func writeLSPMessage(w io.Writer, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+func (l *lspSession) handle(req rpcRequest) (any, error) {
+	switch {
+	case req.Method == "initialize":
+		l.initialized = true
+		return map[string]any{
+			"capabilities": map[string]any{
+				"callHierarchyProvider":   true,
+				"typeHierarchyProvider":   true,
+				"workspaceSymbolProvider": true,
+				"referencesProvider":      true,
+			},
+			"serverInfo": map[string]string{"name": "codegraph", "version": "1.0.0"},
+		}, nil
+	case !l.initialized:
+		return nil, &rpcError{lspServerNotInitialized, "initialize has not been called"}
+	case l.shutdown:
+		return nil, &rpcError{rpcInvalidRequest, "server is shutting down"}
+	}
+
+	snap := l.s.current()
+	if snap != l.snap {
+		l.snap, l.occurrences = snap, lspOccurrences(snap)
+	}
+	switch req.Method {
+	case "shutdown":
+		l.shutdown = true
+		return nil, nil
+	case "textDocument/prepareCallHierarchy":
+		return l.prepareHierarchy(req.Params, "function", "method")
+	case "callHierarchy/incomingCalls":
+		return l.calls(req.Params, false)
+	case "callHierarchy/outgoingCalls":
+		return l.calls(req.Params, true)
+	case "textDocument/prepareTypeHierarchy":
+		return l.prepareHierarchy(req.Params, "struct", "interface", "type")
+	case "typeHierarchy/supertypes":
+		return l.typeHierarchy(req.Params, true)
+	case "typeHierarchy/subtypes":
+		return l.typeHierarchy(req.Params, false)
+	case "workspace/symbol":
+		return l.workspaceSymbols(req.Params)
+	case "textDocument/references":
+		return l.references(req.Params)
+	}
+	return nil, &rpcError{rpcMethodNotFound, fmt.Sprintf("method %q not supported", req.Method)}
+}
+
+// lspOccurrences finds where every node is declared and referenced, from
+// the node positions and the positions of the edges pointing at them.
+func lspOccurrences(snap *snapshot) map[string][]lspOccurrence {
+	occs := make(map[string][]lspOccurrence)
+	for _, n := range snap.result.CodeGraph.Nodes {
+		if file := snap.fileOf[n.ID]; file != "" && n.Line > 0 {
+			occs[file] = append(occs[file], lspOccurrence{node: n.ID, line: n.Line, column: n.Column})
+		}
+	}
+	for _, e := range snap.result.CodeGraph.Edges {
+		if file := snap.fileOf[e.From]; file != "" && e.Line > 0 {
+			occs[file] = append(occs[file], lspOccurrence{node: e.To, line: e.Line, column: e.Column})
+		}
+	}
+	return occs
+}
+
+// uri returns the file URI of a path relative to the project root.
+func (l *lspSession) uri(file string) string {
+	u := url.URL{Scheme: "file", Path: l.root + "/" + file}
+	return u.String()
+}
+
+// file returns the path relative to the project root of a file URI.
+func (l *lspSession) file(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", fmt.Errorf("not a file URI: %q", uri)
+	}
+	rel, ok := strings.CutPrefix(u.Path, l.root+"/")
+	if !ok {
+		return "", fmt.Errorf("%s is outside the project %s", u.Path, l.root)
+	}
+	return rel, nil
+}
+
+// rangeAt returns the range of a node's name at a 1-based position.
+func (l *lspSession) rangeAt(n graph.Node, line, column int) lspRange {
+	start := lspPosition{Line: line - 1, Character: column - 1}
+	return lspRange{Start: start, End: lspPosition{Line: start.Line, Character: start.Character + len(n.Name)}}
+}
+
+// nodeAt returns the node named at a position: the one whose declaration
+// or reference covers it.
+func (l *lspSession) nodeAt(uri string, pos lspPosition) (graph.Node, bool) {
+	file, err := l.file(uri)
+	if err != nil {
+		return graph.Node{}, false
+	}
+	line, column := pos.Line+1, pos.Character+1
+	for _, occ := range l.occurrences[file] {
+		n, ok := l.snap.idx.Nodes[occ.node]
+		if ok && occ.line == line && column >= occ.column && column <= occ.column+len(n.Name) {
+			return n, true
+		}
+	}
+	return graph.Node{}, false
+}
+
+// item describes a declared node; ok is false for nodes without a
+// location, such as functions of other modules.
+func (l *lspSession) item(n graph.Node) (item lspHierarchyItem, ok bool) {
+	file := l.snap.fileOf[n.ID]
+	if file == "" || n.Line == 0 {
+		return item, false
+	}
+	rng := l.rangeAt(n, n.Line, n.Column)
+	detail := n.Package
+	if fn, ok := l.snap.functions[n.ID]; ok {
+		detail = graph.FunctionSignature(n.Receiver, fn)
+	}
+	var tags []int
+	if n.Deprecated {
+		tags = []int{lspDeprecated}
+	}
+	return lspHierarchyItem{
+		Name:           n.Name,
+		Kind:           lspSymbolKinds[n.Type],
+		Tags:           tags,
+		Detail:         detail,
+		URI:            l.uri(file),
+		Range:          rng,
+		SelectionRange: rng,
+	}, true
+}
+
+// itemNode finds the node of an item the client passes back. Items are
+// located by position rather than node ID, since IDs change when the
+// project is analyzed again.
+func (l *lspSession) itemNode(params json.RawMessage) (graph.Node, error) {
+	var p struct {
+		Item lspHierarchyItem `json:"item"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return graph.Node{}, err
+	}
+	n, ok := l.nodeAt(p.Item.URI, p.Item.SelectionRange.Start)
+	if !ok {
+		return graph.Node{}, &rpcError{lspRequestFailed, fmt.Sprintf("%s is no longer at %s:%d", p.Item.Name, p.Item.URI, p.Item.SelectionRange.Start.Line+1)}
+	}
+	return n, nil
+}
+
+// prepareHierarchy returns the item at a position if it is of one of the
+// given types, and null otherwise.
+func (l *lspSession) prepareHierarchy(params json.RawMessage, types ...string) (any, error) {
+	var p lspTextDocumentPosition
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	n, ok := l.nodeAt(p.TextDocument.URI, p.Position)
+	if !ok || !contains(types, n.Type) {
+		return nil, nil
+	}
+	item, ok := l.item(n)
+	if !ok {
+		return nil, nil
+	}
+	return []lspHierarchyItem{item}, nil
+}
+
+// calls returns the incoming calls of an item, grouped by caller, or its
+// outgoing calls, grouped by callee. The ranges are those of the calls in
+// the caller's file.
+func (l *lspSession) calls(params json.RawMessage, outgoing bool) (any, error) {
+	n, err := l.itemNode(params)
+	if err != nil {
+		return nil, err
+	}
+	edges := l.snap.idx.In[n.ID]
+	if outgoing {
+		edges = l.snap.idx.Out[n.ID]
+	}
+	type call struct {
+		item   lspHierarchyItem
+		ranges []lspRange
+	}
+	var order []string
+	byNode := make(map[string]*call)
+	for _, e := range edges {
+		other := e.From
+		if outgoing {
+			other = e.To
+		}
+		if e.Relation != "calls" {
+			continue
+		}
+		c := byNode[other]
+		if c == nil {
+			item, ok := l.item(l.snap.idx.Nodes[other])
+			if !ok {
+				continue
+			}
+			c = &call{item: item}
+			byNode[other] = c
+			order = append(order, other)
+		}
+		if e.Line > 0 {
+			c.ranges = append(c.ranges, l.rangeAt(l.snap.idx.Nodes[e.To], e.Line, e.Column))
+		}
+	}
+	key := "from"
+	if outgoing {
+		key = "to"
+	}
+	result := []map[string]any{}
+	for _, id := range order {
+		c := byNode[id]
+		if c.ranges == nil {
+			c.ranges = []lspRange{}
+		}
+		result = append(result, map[string]any{key: c.item, "fromRanges": c.ranges})
+	}
+	return result, nil
+}
+
+// typeHierarchy returns the supertypes of a type, the interfaces it
+// implements and the structs it embeds, or its subtypes, the structs
+// implementing an interface or embedding a struct.
+func (l *lspSession) typeHierarchy(params json.RawMessage, super bool) (any, error) {
+	n, err := l.itemNode(params)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, relation := range []string{"implements", "embeds"} {
+		if super {
+			ids = append(ids, relatedIDs(l.snap.idx.Out[n.ID], relation, false)...)
+		} else {
+			ids = append(ids, relatedIDs(l.snap.idx.In[n.ID], relation, true)...)
+		}
+	}
+	items := []lspHierarchyItem{}
+	for _, id := range ids {
+		if item, ok := l.item(l.snap.idx.Nodes[id]); ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// workspaceSymbols returns the declarations whose name or qualified name
+// contains the query, ignoring case; the best matches come first, those
+// whose name starts with the query.
+func (l *lspSession) workspaceSymbols(params json.RawMessage) (any, error) {
+	var p struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	const limit = 200
+	query := strings.ToLower(p.Query)
+	type match struct {
+		n      graph.Node
+		prefix bool
+	}
+	var matches []match
+	for _, n := range l.snap.idx.Nodes {
+		name := strings.ToLower(n.Name)
+		if l.snap.fileOf[n.ID] == "" || !strings.Contains(strings.ToLower(n.QualifiedName()), query) {
+			continue
+		}
+		matches = append(matches, match{n, strings.HasPrefix(name, query)})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.prefix != b.prefix {
+			return a.prefix
+		}
+		return a.n.QualifiedName() < b.n.QualifiedName()
+	})
+	symbols := []lspSymbolInformation{}
+	for _, m := range matches {
+		if len(symbols) == limit {
+			break
+		}
+		item, ok := l.item(m.n)
+		if !ok {
+			continue
+		}
+		container := m.n.Package
+		if m.n.Receiver != "" {
+			container += "." + m.n.Receiver
+		}
+		symbols = append(symbols, lspSymbolInformation{
+			Name:          m.n.Name,
+			Kind:          item.Kind,
+			Tags:          item.Tags,
+			Location:      lspLocation{URI: item.URI, Range: item.Range},
+			ContainerName: container,
+		})
+	}
+	return symbols, nil
+}
+
+// references returns the places referring to the node at a position: calls,
+// type uses and other references, and its declaration if asked for.
+func (l *lspSession) references(params json.RawMessage) (any, error) {
+	var p struct {
+		lspTextDocumentPosition
+		Context struct {
+			IncludeDeclaration bool `json:"includeDeclaration"`
+		} `json:"context"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	n, ok := l.nodeAt(p.TextDocument.URI, p.Position)
+	if !ok {
+		return nil, nil
+	}
+	locations := []lspLocation{}
+	seen := make(map[lspLocation]bool)
+	add := func(file string, line, column int) {
+		loc := lspLocation{URI: l.uri(file), Range: l.rangeAt(n, line, column)}
+		if !seen[loc] {
+			seen[loc] = true
+			locations = append(locations, loc)
+		}
+	}
+	if file := l.snap.fileOf[n.ID]; p.Context.IncludeDeclaration && file != "" && n.Line > 0 {
+		add(file, n.Line, n.Column)
+	}
+	for _, e := range l.snap.idx.In[n.ID] {
+		if file := l.snap.fileOf[e.From]; file != "" && e.Line > 0 {
+			add(file, e.Line, e.Column)
+		}
+	}
+	return locations, nil
+}
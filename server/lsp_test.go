@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+func TestServeLSP(t *testing.T) {
+	files := fstest.MapFS{"main.go": {Data: []byte(testStore)}}
+	s, err := New(func() (graph.ProjectStructure, error) {
+		return graph.AnalyzeFS(files, "test")
+	}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	uri := "file://" + filepath.ToSlash(root) + "/main.go"
+	// Positions are 0-based: NewStore is declared on line 8 and called on
+	// line 11 of testStore
+	newStore := `{"textDocument":{"uri":"` + uri + `"},"position":{"line":7,"character":6}}`
+	item := `{"name":"NewStore","kind":12,"uri":"` + uri + `","range":{"start":{"line":7,"character":5},"end":{"line":7,"character":13}},"selectionRange":{"start":{"line":7,"character":5},"end":{"line":7,"character":13}}}`
+
+	tests := []struct {
+		name, method, params string
+		want                 string // Result or error as compact JSON
+	}{
+		{
+			name:   "before initialize",
+			method: "workspace/symbol", params: `{"query":"Store"}`,
+			want: `{"error":{"code":-32002,"message":"initialize has not been called"}}`,
+		},
+		{
+			name:   "initialize",
+			method: "initialize", params: `{}`,
+			want: `{"result":{"capabilities":{"callHierarchyProvider":true,"referencesProvider":true,"typeHierarchyProvider":true,"workspaceSymbolProvider":true},"serverInfo":{"name":"codegraph","version":"1.0.0"}}}`,
+		},
+		{
+			name:   "prepare call hierarchy",
+			method: "textDocument/prepareCallHierarchy", params: newStore,
+			want: `{"result":[{"name":"NewStore","kind":12,"detail":"func NewStore() *Store","uri":"` + uri + `","range":{"start":{"line":7,"character":5},"end":{"line":7,"character":13}},"selectionRange":{"start":{"line":7,"character":5},"end":{"line":7,"character":13}}}]}`,
+		},
+		{
+			name:   "incoming calls",
+			method: "callHierarchy/incomingCalls", params: `{"item":` + item + `}`,
+			want: `{"result":[{"from":{"name":"main","kind":12,"detail":"func main()","uri":"` + uri + `","range":{"start":{"line":9,"character":5},"end":{"line":9,"character":9}},"selectionRange":{"start":{"line":9,"character":5},"end":{"line":9,"character":9}}},"fromRanges":[{"start":{"line":10,"character":6},"end":{"line":10,"character":14}}]}]}`,
+		},
+		{
+			name:   "references",
+			method: "textDocument/references", params: strings.TrimSuffix(newStore, "}") + `,"context":{"includeDeclaration":true}}`,
+			want: `{"result":[{"uri":"` + uri + `","range":{"start":{"line":7,"character":5},"end":{"line":7,"character":13}}},{"uri":"` + uri + `","range":{"start":{"line":10,"character":6},"end":{"line":10,"character":14}}}]}`,
+		},
+		{
+			name:   "workspace symbols",
+			method: "workspace/symbol", params: `{"query":"Put"}`,
+			want: `{"result":[{"name":"Put","kind":6,"location":{"uri":"` + uri + `","range":{"start":{"line":5,"character":16},"end":{"line":5,"character":19}}},"containerName":"main.Store"}]}`,
+		},
+		{
+			name:   "unknown method",
+			method: "textDocument/hover", params: newStore,
+			want: `{"error":{"code":-32601,"message":"method \"textDocument/hover\" not supported"}}`,
+		},
+	}
+
+	var in strings.Builder
+	for i, tt := range tests {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":%s}`, i, tt.method, tt.params)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	exit := `{"jsonrpc":"2.0","method":"exit"}`
+	fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(exit), exit)
+
+	var out strings.Builder
+	if err := s.ServeLSP(strings.NewReader(in.String()), &out, root); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(strings.NewReader(out.String()))
+	for i, tt := range tests {
+		body, err := readLSPMessage(r)
+		if err != nil {
+			t.Fatalf("response %d: %v", i, err)
+		}
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if id := string(resp["id"]); id != fmt.Sprint(i) {
+			t.Errorf("%s: id = %s, want %d", tt.name, id, i)
+		}
+		delete(resp, "id")
+		delete(resp, "jsonrpc")
+		got, _ := json.Marshal(resp)
+		if string(got) != tt.want {
+			t.Errorf("%s: response = %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
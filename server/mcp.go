@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The Model Context Protocol lets AI assistants call tools of a server
+// over newline-delimited JSON-RPC 2.0 on stdin and stdout. ServeMCP offers
+// the tools of mcpTools, answering from the current snapshot.
+
+// mcpProtocolVersions are the protocol revisions spoken, newest first.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // Absent for notifications
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     json.RawMessage
+	Result any
+	Error  *rpcError
+}
+
+// MarshalJSON writes exactly one of result and error, as JSON-RPC
+// requires; a nil result is written as null.
+func (r rpcResponse) MarshalJSON() ([]byte, error) {
+	m := map[string]any{"jsonrpc": "2.0", "id": r.ID}
+	if r.Error != nil {
+		m["error"] = r.Error
+	} else {
+		m["result"] = r.Result
+	}
+	return json.Marshal(m)
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// mcpContent is one block of a tool result; only text is produced.
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// ServeMCP answers MCP requests read from r on w until r is exhausted.
+// Requests are handled one at a time, in order.
+func (s *Server) ServeMCP(r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	send := func(resp rpcResponse) error {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+
+	for {
+		line, err := in.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if resp, ok := s.handleMCP(line); ok {
+				if err := send(resp); err != nil {
+					return err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleMCP answers one message; ok is false for notifications, which get
+// no response.
+func (s *Server) handleMCP(line []byte) (resp rpcResponse, ok bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcResponse{ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, err.Error()}}, true
+	}
+	if req.ID == nil {
+		// Notifications such as notifications/initialized need no action
+		return rpcResponse{}, false
+	}
+	resp.ID = req.ID
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{rpcInvalidRequest, "not a JSON-RPC 2.0 request"}
+		return resp, true
+	}
+
+	var err error
+	switch req.Method {
+	case "initialize":
+		resp.Result, err = mcpInitialize(req.Params)
+	case "ping":
+		resp.Result = struct{}{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": mcpTools}
+	case "tools/call":
+		resp.Result, err = s.callTool(req.Params)
+	default:
+		err = &rpcError{rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+	}
+	if err != nil {
+		resp.Result, resp.Error = nil, toRPCError(err)
+	}
+	return resp, true
+}
+
+// toRPCError reports err as is when it is an rpcError, and otherwise as
+// invalid parameters, the usual cause of a failing request.
+func toRPCError(err error) *rpcError {
+	var rerr *rpcError
+	if !errors.As(err, &rerr) {
+		rerr = &rpcError{rpcInvalidParams, err.Error()}
+	}
+	return rerr
+}
+
+// mcpInitialize agrees on the protocol version the client asks for when
+// it is spoken, otherwise offers the newest.
+func mcpInitialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	version := mcpProtocolVersions[0]
+	for _, v := range mcpProtocolVersions {
+		if v == p.ProtocolVersion {
+			version = v
+		}
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]string{"name": "codegraph", "version": "1.0.0"},
+		"instructions": "Tools for navigating a Go project by its code graph. Symbols are written " +
+			"\"Name\", \"pkg.Name\", \"Type.Method\" or \"pkg.Type.Method\", or given as node IDs.",
+	}, nil
+}
+
+// callTool runs a tool. Failures of the tool itself, such as an unknown
+// symbol, are reported in the result for the model to read; only a
+// malformed call is a protocol error.
+func (s *Server) callTool(params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string   `json:"name"`
+		Arguments toolArgs `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	for _, t := range mcpTools {
+		if t.Name != p.Name {
+			continue
+		}
+		text, err := t.call(s, s.current(), p.Arguments)
+		if err != nil {
+			return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}, nil
+	}
+	return nil, fmt.Errorf("unknown tool %q", p.Name)
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+func TestServeMCP(t *testing.T) {
+	files := fstest.MapFS{"main.go": {Data: []byte(testStore)}}
+	s, err := New(func() (graph.ProjectStructure, error) {
+		return graph.AnalyzeFS(files, "test")
+	}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		request  string
+		response string // Empty for notifications
+	}{
+		{
+			name:     "initialize",
+			request:  `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+			response: `{"id":1,"jsonrpc":"2.0","result":{"capabilities":{"tools":{}},"instructions":"Tools for navigating a Go project by its code graph. Symbols are written \"Name\", \"pkg.Name\", \"Type.Method\" or \"pkg.Type.Method\", or given as node IDs.","protocolVersion":"2024-11-05","serverInfo":{"name":"codegraph","version":"1.0.0"}}}`,
+		},
+		{
+			name:    "notification",
+			request: `{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		},
+		{
+			name:     "callers",
+			request:  `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_callers","arguments":{"symbol":"NewStore"}}}`,
+			response: `{"id":2,"jsonrpc":"2.0","result":{"content":[{"type":"text","text":"main.NewStore  main.go:8\n  main.main  main.go:10\n"}]}}`,
+		},
+		{
+			name:     "type definition",
+			request:  `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_type_definition","arguments":{"symbol":"Store"}}}`,
+			response: `{"id":3,"jsonrpc":"2.0","result":{"content":[{"type":"text","text":"// main.go:4\ntype Store struct\n\nMethods:\n\tfunc (*Store) Put(v int)\n"}]}}`,
+		},
+		{
+			name:     "package API",
+			request:  `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"list_package_api","arguments":{"package":"main"}}}`,
+			response: `{"id":4,"jsonrpc":"2.0","result":{"content":[{"type":"text","text":"package main  // main, 1 file(s)\n\nfunc NewStore() *Store\n\ntype Store struct  // Store keeps one value.\n\tfunc (*Store) Put(v int)\n"}]}}`,
+		},
+		{
+			name:     "unknown symbol",
+			request:  `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"get_callees","arguments":{"symbol":"missing"}}}`,
+			response: `{"id":5,"jsonrpc":"2.0","result":{"content":[{"type":"text","text":"no symbol matches \"missing\"; find_symbol searches by pattern"}],"isError":true}}`,
+		},
+		{
+			name:     "missing argument",
+			request:  `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"find_symbol","arguments":{}}}`,
+			response: `{"id":6,"jsonrpc":"2.0","result":{"content":[{"type":"text","text":"missing argument \"query\""}],"isError":true}}`,
+		},
+		{
+			name:     "unknown tool",
+			request:  `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"nope"}}`,
+			response: `{"error":{"code":-32602,"message":"unknown tool \"nope\""},"id":7,"jsonrpc":"2.0"}`,
+		},
+		{
+			name:     "unknown method",
+			request:  `{"jsonrpc":"2.0","id":8,"method":"nope"}`,
+			response: `{"error":{"code":-32601,"message":"method \"nope\" not found"},"id":8,"jsonrpc":"2.0"}`,
+		},
+		{
+			name:     "not JSON-RPC 2.0",
+			request:  `{"id":9,"method":"ping"}`,
+			response: `{"error":{"code":-32600,"message":"not a JSON-RPC 2.0 request"},"id":9,"jsonrpc":"2.0"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := s.ServeMCP(strings.NewReader(tt.request+"\n"), &out); err != nil {
+				t.Fatal(err)
+			}
+			want := tt.response
+			if want != "" {
+				want += "\n"
+			}
+			if out.String() != want {
+				t.Errorf("response = %s\nwant %s", out.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,525 @@
+package server
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// mcpTool is a tool offered to MCP clients; call returns the text handed
+// to the model.
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	call        func(s *Server, snap *snapshot, args toolArgs) (string, error)
+}
+
+// objectSchema is the JSON schema of a tool's arguments.
+func objectSchema(required []string, properties map[string]any) map[string]any {
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func property(typ, description string) map[string]any {
+	return map[string]any{"type": typ, "description": description}
+}
+
+var (
+	symbolProperty = property("string", `Symbol such as "Name", "pkg.Name", "Type.Method" or "pkg.Type.Method", or a node ID`)
+	depthProperty  = property("integer", "Levels of calls to follow, 0 for unlimited (default 1)")
+)
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "find_symbol",
+		Description: "Find functions, methods, types, constants and variables by symbol, or by a case-insensitive regular expression matched against qualified names such as graph.Index.Resolve. Returns name, kind, location and node ID.",
+		InputSchema: objectSchema([]string{"query"}, map[string]any{
+			"query": property("string", "Symbol or regular expression"),
+			"type":  property("string", "Only nodes of this kind: function, method, struct, interface, interface_method, type, constant, variable or external_function"),
+			"limit": property("integer", "Maximum number of results (default 20)"),
+		}),
+		call: findSymbol,
+	},
+	{
+		Name:        "get_callers",
+		Description: "Show the functions and methods calling a symbol, as a tree up to the given depth. Calls through interfaces and function values are not included.",
+		InputSchema: objectSchema([]string{"symbol"}, map[string]any{"symbol": symbolProperty, "depth": depthProperty}),
+		call: func(s *Server, snap *snapshot, args toolArgs) (string, error) {
+			return callTree(snap, args, graph.Backward)
+		},
+	},
+	{
+		Name:        "get_callees",
+		Description: "Show the functions and methods a symbol calls, including those of other modules, as a tree up to the given depth.",
+		InputSchema: objectSchema([]string{"symbol"}, map[string]any{"symbol": symbolProperty, "depth": depthProperty}),
+		call: func(s *Server, snap *snapshot, args toolArgs) (string, error) {
+			return callTree(snap, args, graph.Forward)
+		},
+	},
+	{
+		Name:        "get_type_definition",
+		Description: "Show the declaration of a struct, interface or other named type with its methods, the interfaces it implements or its implementations, and embedding.",
+		InputSchema: objectSchema([]string{"symbol"}, map[string]any{"symbol": symbolProperty}),
+		call:        typeDefinition,
+	},
+	{
+		Name:        "list_package_api",
+		Description: "List the exported constants, variables, functions, types and methods of a package with their signatures and the first sentence of their doc comments.",
+		InputSchema: objectSchema([]string{"package"}, map[string]any{
+			"package":            property("string", "Package directory relative to the project root, or package name"),
+			"include_unexported": property("boolean", "Also list unexported declarations"),
+		}),
+		call: packageAPI,
+	},
+	{
+		Name:        "get_source",
+		Description: "Show the source of a symbol's declaration including its doc comment, or the given lines of a file.",
+		InputSchema: objectSchema(nil, map[string]any{
+			"symbol":     symbolProperty,
+			"file":       property("string", "File path relative to the project root, instead of symbol"),
+			"start_line": property("integer", "First line of file to show (default 1)"),
+			"end_line":   property("integer", "Last line of file to show (default the end)"),
+		}),
+		call: getSource,
+	},
+}
+
+// toolArgs are the arguments of a tool call as decoded from JSON.
+type toolArgs map[string]any
+
+func (a toolArgs) str(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+func (a toolArgs) required(name string) (string, error) {
+	s := a.str(name)
+	if s == "" {
+		return "", fmt.Errorf("missing argument %q", name)
+	}
+	return s, nil
+}
+
+func (a toolArgs) integer(name string, def int) (int, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return def, nil
+	case float64:
+		if v >= 0 && v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("argument %q must be a non-negative integer", name)
+}
+
+func (a toolArgs) boolean(name string) bool {
+	b, _ := a[name].(bool)
+	return b
+}
+
+// resolveSymbol returns the nodes named by the symbol argument.
+func resolveSymbol(snap *snapshot, args toolArgs) ([]graph.Node, error) {
+	symbol, err := args.required("symbol")
+	if err != nil {
+		return nil, err
+	}
+	nodes := snap.idx.Resolve(symbol)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no symbol matches %q; find_symbol searches by pattern", symbol)
+	}
+	return nodes, nil
+}
+
+// location returns "path:line" of a node, with the path relative to the
+// project root when known.
+func location(snap *snapshot, n graph.Node) string {
+	file := snap.fileOf[n.ID]
+	if file == "" {
+		return n.Location()
+	}
+	if n.Line == 0 {
+		return file
+	}
+	return file + ":" + strconv.Itoa(n.Line)
+}
+
+func findSymbol(s *Server, snap *snapshot, args toolArgs) (string, error) {
+	query, err := args.required("query")
+	if err != nil {
+		return "", err
+	}
+	limit, err := args.integer("limit", 20)
+	if err != nil {
+		return "", err
+	}
+	matches := snap.idx.Resolve(query)
+	if len(matches) == 0 {
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return "", fmt.Errorf("invalid query: %v", err)
+		}
+		for _, n := range snap.idx.Nodes {
+			if re.MatchString(n.QualifiedName()) {
+				matches = append(matches, n)
+			}
+		}
+	}
+	if typ := args.str("type"); typ != "" {
+		kept := matches[:0]
+		for _, n := range matches {
+			if n.Type == typ {
+				kept = append(kept, n)
+			}
+		}
+		matches = kept
+	}
+	if len(matches) == 0 {
+		return "No symbols found.", nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].QualifiedName(), matches[j].QualifiedName()
+		return a < b || a == b && matches[i].ID < matches[j].ID
+	})
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for i, n := range matches {
+		if limit > 0 && i == limit {
+			break
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", n.QualifiedName(), n.Type, location(snap, n), n.ID)
+	}
+	tw.Flush()
+	if limit > 0 && len(matches) > limit {
+		fmt.Fprintf(&b, "... and %d more; narrow the query or raise limit\n", len(matches)-limit)
+	}
+	return b.String(), nil
+}
+
+func callTree(snap *snapshot, args toolArgs, dir graph.Direction) (string, error) {
+	nodes, err := resolveSymbol(snap, args)
+	if err != nil {
+		return "", err
+	}
+	depth, err := args.integer("depth", 1)
+	if err != nil {
+		return "", err
+	}
+	opts := graph.TraverseOptions{Depth: depth, Relations: []string{"calls"}}
+	var b strings.Builder
+	for i, n := range nodes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if err := snap.idx.WriteTree(&b, n.ID, dir, opts); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+func typeDefinition(s *Server, snap *snapshot, args toolArgs) (string, error) {
+	nodes, err := resolveSymbol(snap, args)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		if n.Type != "struct" && n.Type != "interface" && n.Type != "type" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "// %s\n", location(snap, n))
+		if src, _, _, err := s.declSource(snap, n); err == nil {
+			b.WriteString(src + "\n")
+		} else if t, ok := snap.types[n.ID]; ok {
+			b.WriteString(graph.TypeDeclaration(t) + "\n")
+		} else {
+			fmt.Fprintf(&b, "type %s %s\n", n.Name, n.Type)
+		}
+
+		var methods []graph.FunctionInfo
+		var related []string
+		out, in := snap.idx.Out[n.ID], snap.idx.In[n.ID]
+		if st, ok := snap.structs[n.ID]; ok {
+			methods = st.Functions
+			related = append(related, relatedLine(snap, "Implements", relatedIDs(out, "implements", false)))
+			related = append(related, relatedLine(snap, "Embeds", relatedIDs(out, "embeds", false)))
+			related = append(related, relatedLine(snap, "Embedded by", relatedIDs(in, "embeds", true)))
+		} else if it, ok := snap.interfaces[n.ID]; ok {
+			methods = it.Functions
+			related = append(related, relatedLine(snap, "Implemented by", relatedIDs(in, "implements", true)))
+		} else if t, ok := snap.types[n.ID]; ok {
+			methods = t.Functions
+			related = append(related, relatedLine(snap, "Implements", relatedIDs(out, "implements", false)))
+		}
+		if len(methods) > 0 && n.Type != "interface" {
+			b.WriteString("\nMethods:\n")
+			for _, fn := range methods {
+				fmt.Fprintf(&b, "\t%s\n", graph.FunctionSignature(n.Name, fn))
+			}
+		}
+		for _, line := range related {
+			b.WriteString(line)
+		}
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("%s is not a type", nodes[0].QualifiedName())
+	}
+	return b.String(), nil
+}
+
+func relatedLine(snap *snapshot, label string, ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = snap.idx.Nodes[id].QualifiedName()
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%s: %s\n", label, strings.Join(names, ", "))
+}
+
+// synopsis returns the first sentence of a doc comment as a trailing Go
+// comment.
+func synopsis(comment string) string {
+	first, _, _ := strings.Cut(strings.TrimSpace(comment), "\n")
+	if i := strings.Index(first, ". "); i >= 0 {
+		first = first[:i+1]
+	}
+	if first == "" {
+		return ""
+	}
+	return "  // " + first
+}
+
+func packageAPI(s *Server, snap *snapshot, args toolArgs) (string, error) {
+	pkg, err := args.required("package")
+	if err != nil {
+		return "", err
+	}
+	pkg = strings.Trim(pkg, "/")
+	all := args.boolean("include_unexported")
+	show := func(name string) bool { return all || isExported(name) }
+
+	var files []string
+	name := ""
+	for _, f := range snap.files {
+		if path.Dir(f) == pkg || snap.modules[f].Package == pkg {
+			files = append(files, f)
+			name = snap.modules[f].Package
+		}
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no package %q; packages are named by directory relative to the project root", pkg)
+	}
+
+	var consts, vars, funcs, types []string
+	for _, f := range files {
+		m := snap.modules[f]
+		for _, c := range m.Constants {
+			if show(c.Name) {
+				consts = append(consts, strings.TrimSpace("const "+c.Name+" "+c.Type)+valueSuffix(c.Value))
+			}
+		}
+		for _, v := range m.Variables {
+			if show(v.Name) {
+				vars = append(vars, strings.TrimSpace("var "+v.Name+" "+v.Type)+valueSuffix(v.Value))
+			}
+		}
+		for _, fn := range m.Functions {
+			if show(fn.Name) {
+				funcs = append(funcs, graph.FunctionSignature("", fn)+synopsis(fn.Comment))
+			}
+		}
+		for _, st := range m.Structs {
+			if show(st.Name) {
+				types = append(types, typeAPI("type "+st.Name+" struct"+synopsis(st.Comment), st.Name, st.Functions, show))
+			}
+		}
+		for _, it := range m.Interfaces {
+			if show(it.Name) {
+				types = append(types, typeAPI("type "+it.Name+" interface"+synopsis(it.Comment), "", it.Functions, show))
+			}
+		}
+		for _, t := range m.Types {
+			if show(t.Name) {
+				types = append(types, typeAPI(graph.TypeDeclaration(t)+synopsis(t.Comment), t.Name, t.Functions, show))
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s  // %s, %d file(s)\n", name, pkg, len(files))
+	for _, section := range [][]string{consts, vars, funcs, types} {
+		if len(section) == 0 {
+			continue
+		}
+		sort.Strings(section)
+		b.WriteString("\n" + strings.Join(section, "\n") + "\n")
+	}
+	return b.String(), nil
+}
+
+func valueSuffix(value string) string {
+	if value == "" || len(value) > 60 {
+		return ""
+	}
+	return " = " + value
+}
+
+// typeAPI renders a type followed by its methods, indented; receiver is
+// empty for the methods of an interface.
+func typeAPI(decl, receiver string, methods []graph.FunctionInfo, show func(string) bool) string {
+	lines := []string{decl}
+	var ms []string
+	for _, fn := range methods {
+		if show(fn.Name) {
+			sig := graph.FunctionSignature(receiver, fn)
+			if receiver == "" {
+				sig = strings.TrimPrefix(sig, "func ")
+			}
+			ms = append(ms, "\t"+sig+synopsis(fn.Comment))
+		}
+	}
+	sort.Strings(ms)
+	return strings.Join(append(lines, ms...), "\n")
+}
+
+func isExported(name string) bool {
+	return name != "" && strings.ToUpper(name[:1]) == name[:1] && name[0] != '_'
+}
+
+func getSource(s *Server, snap *snapshot, args toolArgs) (string, error) {
+	if args.str("symbol") == "" {
+		return fileLines(s, args)
+	}
+	nodes, err := resolveSymbol(snap, args)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		src, start, end, err := s.declSource(snap, n)
+		if err != nil {
+			return "", err
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "// %s:%d-%d\n%s\n", snap.fileOf[n.ID], start, end, src)
+	}
+	return b.String(), nil
+}
+
+func fileLines(s *Server, args toolArgs) (string, error) {
+	file, err := args.required("file")
+	if err != nil {
+		return "", fmt.Errorf("give a symbol or a file")
+	}
+	if s.opts.Files == nil {
+		return "", fmt.Errorf("source files are not available for a graph loaded from a file")
+	}
+	data, err := fs.ReadFile(s.opts.Files, strings.TrimPrefix(path.Clean(file), "/"))
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	start, err := args.integer("start_line", 1)
+	if err != nil {
+		return "", err
+	}
+	end, err := args.integer("end_line", len(lines))
+	if err != nil {
+		return "", err
+	}
+	start, end = max(start, 1), min(end, len(lines))
+	if start > end {
+		return "", fmt.Errorf("%s has %d lines", file, len(lines))
+	}
+	return fmt.Sprintf("// %s:%d-%d\n%s\n", file, start, end, strings.Join(lines[start-1:end], "\n")), nil
+}
+
+// declSource returns the source of the declaration of n with its doc
+// comment, and the lines it spans. For a graph loaded from a file only the
+// recorded content of functions is available.
+func (s *Server) declSource(snap *snapshot, n graph.Node) (src string, start, end int, err error) {
+	file := snap.fileOf[n.ID]
+	if s.opts.Files == nil || file == "" {
+		if fn, ok := snap.functions[n.ID]; ok && fn.Content != "" {
+			return fn.Content, n.Line, n.Line + strings.Count(fn.Content, "\n"), nil
+		}
+		return "", 0, 0, fmt.Errorf("no source available for %s in a graph loaded from a file", n.QualifiedName())
+	}
+	data, err := fs.ReadFile(s.opts.Files, file)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, data, parser.ParseComments)
+	if f == nil {
+		return "", 0, 0, err
+	}
+	line := func(p token.Pos) int { return fset.Position(p).Line }
+	text := func(from, to token.Pos) string {
+		return string(data[fset.Position(from).Offset:fset.Position(to).Offset])
+	}
+	for _, decl := range f.Decls {
+		if n.Line < line(decl.Pos()) || n.Line > line(decl.End()) {
+			continue
+		}
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			from := d.Pos()
+			if d.Doc != nil {
+				from = d.Doc.Pos()
+			}
+			return text(from, d.End()), line(from), line(d.End()), nil
+		case *ast.GenDecl:
+			from := d.Pos()
+			if d.Doc != nil {
+				from = d.Doc.Pos()
+			}
+			if !d.Lparen.IsValid() {
+				return text(from, d.End()), line(from), line(d.End()), nil
+			}
+			// Only the spec of the node within a group
+			for _, spec := range d.Specs {
+				if n.Line < line(spec.Pos()) || n.Line > line(spec.End()) {
+					continue
+				}
+				var doc *ast.CommentGroup
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					doc = spec.Doc
+				case *ast.ValueSpec:
+					doc = spec.Doc
+				}
+				src, from := d.Tok.String()+" "+text(spec.Pos(), spec.End()), spec.Pos()
+				if doc != nil {
+					src, from = text(doc.Pos(), doc.End())+"\n"+src, doc.Pos()
+				}
+				return src, line(from), line(spec.End()), nil
+			}
+			return text(from, d.End()), line(from), line(d.End()), nil
+		}
+	}
+	return "", 0, 0, fmt.Errorf("no declaration of %s at %s:%d", n.QualifiedName(), file, n.Line)
+}
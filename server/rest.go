@@ -0,0 +1,387 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// registerREST adds the JSON endpoints:
+//
+//	GET  /api/project                   summary of the graph
+//	POST /api/analyze                   analyze the project again
+//	GET  /api/nodes?name=&match=&symbol=&type=&package=&limit=
+//	GET  /api/nodes/{id}                a node with its edges
+//	GET  /api/nodes/{id}/neighbors?relation=&direction=out|in|both
+//	GET  /api/nodes/{id}/callers?depth=&relation=
+//	GET  /api/nodes/{id}/callees?depth=&relation=
+//	GET  /api/paths?from=&to=&relation=&all=&max=&limit=
+//	GET  /api/files                     analyzed file paths
+//	GET  /api/files/{path...}           ModuleInfo of a file
+//	GET  /api/query?q=                  a graph query expression
+func (s *Server) registerREST(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/project", s.handleProject)
+	mux.HandleFunc("POST /api/analyze", s.handleAnalyze)
+	mux.HandleFunc("GET /api/nodes", s.handleSearch)
+	mux.HandleFunc("GET /api/nodes/{id}", s.handleNode)
+	mux.HandleFunc("GET /api/nodes/{id}/neighbors", s.handleNeighbors)
+	mux.HandleFunc("GET /api/nodes/{id}/callers", s.handleTraversal(graph.Backward))
+	mux.HandleFunc("GET /api/nodes/{id}/callees", s.handleTraversal(graph.Forward))
+	mux.HandleFunc("GET /api/paths", s.handlePaths)
+	mux.HandleFunc("GET /api/files", s.handleFiles)
+	mux.HandleFunc("GET /api/files/{path...}", s.handleFile)
+	mux.HandleFunc("GET /api/query", s.handleQuery)
+}
+
+// httpError is an error with the status it is reported with.
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e *httpError) Error() string { return e.msg }
+
+func badRequest(format string, args ...any) error {
+	return &httpError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &httpError{http.StatusNotFound, fmt.Sprintf(format, args...)}
+}
+
+// writeJSON sends v, or err as {"error": "..."} when err is not nil.
+func writeJSON(w http.ResponseWriter, v any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusInternalServerError
+		if he, ok := err.(*httpError); ok {
+			status = he.status
+		}
+		w.WriteHeader(status)
+		v = map[string]string{"error": err.Error()}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// projectSummary describes the graph being served.
+type projectSummary struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Nodes         int       `json:"nodes"`
+	Edges         int       `json:"edges"`
+	Files         int       `json:"files"`
+	AnalyzedAt    time.Time `json:"analyzedAt"`
+}
+
+func (snap *snapshot) summary() projectSummary {
+	return projectSummary{
+		SchemaVersion: snap.result.SchemaVersion,
+		Nodes:         len(snap.result.CodeGraph.Nodes),
+		Edges:         len(snap.result.CodeGraph.Edges),
+		Files:         len(snap.files),
+		AnalyzedAt:    snap.analyzedAt,
+	}
+}
+
+func (s *Server) handleProject(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.current().summary(), nil)
+}
+
+func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	if err := s.Reload(); err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	writeJSON(w, s.current().summary(), nil)
+}
+
+// handleSearch finds nodes by exact name, regular expression or symbol
+// ("pkg.Type.Method"), optionally narrowed by type and package.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	snap := s.current()
+	q := r.URL.Query()
+	limit, err := intParam(q.Get("limit"), 100)
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	var re *regexp.Regexp
+	if m := q.Get("match"); m != "" {
+		if re, err = regexp.Compile(m); err != nil {
+			writeJSON(w, nil, badRequest("invalid match: %v", err))
+			return
+		}
+	}
+
+	var candidates []graph.Node
+	if symbol := q.Get("symbol"); symbol != "" {
+		candidates = snap.idx.Resolve(symbol)
+	} else {
+		for _, n := range snap.idx.Nodes {
+			candidates = append(candidates, n)
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	}
+
+	nodes := []graph.Node{}
+	name, typ, pkg := q.Get("name"), q.Get("type"), q.Get("package")
+	for _, n := range candidates {
+		if name != "" && n.Name != name || typ != "" && n.Type != typ || pkg != "" && n.Package != pkg ||
+			re != nil && !re.MatchString(n.QualifiedName()) {
+			continue
+		}
+		if limit > 0 && len(nodes) == limit {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	writeJSON(w, nodes, nil)
+}
+
+// nodeDetail is a node with the edges at it.
+type nodeDetail struct {
+	graph.Node
+	Path string       `json:"path,omitempty"` // File relative to the project root
+	Out  []graph.Edge `json:"out"`
+	In   []graph.Edge `json:"in"`
+}
+
+func (s *Server) node(snap *snapshot, r *http.Request) (graph.Node, error) {
+	id := r.PathValue("id")
+	n, ok := snap.idx.Nodes[id]
+	if !ok {
+		return n, notFound("no node %q", id)
+	}
+	return n, nil
+}
+
+func (s *Server) handleNode(w http.ResponseWriter, r *http.Request) {
+	snap := s.current()
+	n, err := s.node(snap, r)
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	d := nodeDetail{Node: n, Path: snap.fileOf[n.ID], Out: snap.idx.Out[n.ID], In: snap.idx.In[n.ID]}
+	if d.Out == nil {
+		d.Out = []graph.Edge{}
+	}
+	if d.In == nil {
+		d.In = []graph.Edge{}
+	}
+	writeJSON(w, d, nil)
+}
+
+// neighbor is a node adjacent to the one asked about, with the edge
+// linking them.
+type neighbor struct {
+	Node graph.Node `json:"node"`
+	Edge graph.Edge `json:"edge"`
+}
+
+func (s *Server) handleNeighbors(w http.ResponseWriter, r *http.Request) {
+	snap := s.current()
+	n, err := s.node(snap, r)
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	q := r.URL.Query()
+	relations := listParam(q.Get("relation"))
+	direction := q.Get("direction")
+	if direction == "" {
+		direction = "both"
+	}
+	var edges []graph.Edge
+	switch direction {
+	case "out":
+		edges = snap.idx.Out[n.ID]
+	case "in":
+		edges = snap.idx.In[n.ID]
+	case "both":
+		edges = append(append(edges, snap.idx.Out[n.ID]...), snap.idx.In[n.ID]...)
+	default:
+		writeJSON(w, nil, badRequest("invalid direction %q (want out, in or both)", direction))
+		return
+	}
+	out := []neighbor{}
+	for _, e := range edges {
+		if len(relations) > 0 && !contains(relations, e.Relation) {
+			continue
+		}
+		other := e.To
+		if other == n.ID {
+			other = e.From
+		}
+		if on, ok := snap.idx.Nodes[other]; ok {
+			out = append(out, neighbor{Node: on, Edge: e})
+		}
+	}
+	writeJSON(w, out, nil)
+}
+
+// step is a node reached by a traversal; Edge is nil for the root.
+type step struct {
+	Node  graph.Node  `json:"node"`
+	Depth int         `json:"depth"`
+	Edge  *graph.Edge `json:"edge,omitempty"`
+}
+
+// handleTraversal serves callers (Backward) or callees (Forward) of a node
+// up to ?depth= hops, default 1, over ?relation=, default calls.
+func (s *Server) handleTraversal(dir graph.Direction) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap := s.current()
+		n, err := s.node(snap, r)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		q := r.URL.Query()
+		depth, err := intParam(q.Get("depth"), 1)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		opts := graph.TraverseOptions{Depth: depth, Relations: listParam(q.Get("relation"))}
+		if opts.Relations == nil {
+			opts.Relations = []string{"calls"}
+		}
+		steps := []step{}
+		for _, st := range snap.idx.Traverse([]string{n.ID}, dir, opts) {
+			out := step{Node: st.Node, Depth: st.Depth}
+			if st.Depth > 0 {
+				e := st.Edge
+				out.Edge = &e
+			}
+			steps = append(steps, out)
+		}
+		writeJSON(w, steps, nil)
+	}
+}
+
+// handlePaths finds the shortest path, or with ?all=true every simple path
+// of at most ?max= edges, between two symbols.
+func (s *Server) handlePaths(w http.ResponseWriter, r *http.Request) {
+	snap := s.current()
+	q := r.URL.Query()
+	from, err := resolve(snap, q.Get("from"))
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	to, err := resolve(snap, q.Get("to"))
+	if err != nil {
+		writeJSON(w, nil, err)
+		return
+	}
+	opts := graph.TraverseOptions{Relations: listParam(q.Get("relation"))}
+	if opts.Relations == nil {
+		opts.Relations = []string{"calls"}
+	}
+	paths := [][]graph.Edge{}
+	if q.Get("all") == "true" {
+		maxLen, err := intParam(q.Get("max"), 6)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		limit, err := intParam(q.Get("limit"), 100)
+		if err != nil {
+			writeJSON(w, nil, err)
+			return
+		}
+		if all := snap.idx.AllPaths(from, to, maxLen, limit, opts); all != nil {
+			paths = all
+		}
+	} else if p := snap.idx.ShortestPath(from, to, opts); p != nil {
+		paths = append(paths, p)
+	}
+	writeJSON(w, paths, nil)
+}
+
+func (s *Server) handleFiles(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.current().files, nil)
+}
+
+func (s *Server) handleFile(w http.ResponseWriter, r *http.Request) {
+	path := r.PathValue("path")
+	m, ok := s.current().modules[path]
+	if !ok {
+		writeJSON(w, nil, notFound("no file %q", path))
+		return
+	}
+	writeJSON(w, m, nil)
+}
+
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	src := r.URL.Query().Get("q")
+	if src == "" {
+		writeJSON(w, nil, badRequest("missing query parameter q"))
+		return
+	}
+	q, err := graph.ParseQuery(src)
+	if err != nil {
+		writeJSON(w, nil, badRequest("%v", err))
+		return
+	}
+	res, err := q.Run(s.current().idx)
+	if err != nil {
+		writeJSON(w, nil, badRequest("%v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	res.WriteJSON(w)
+}
+
+// resolve turns a symbol or node ID into node IDs.
+func resolve(snap *snapshot, symbol string) ([]string, error) {
+	if symbol == "" {
+		return nil, badRequest("missing symbol")
+	}
+	nodes := snap.idx.Resolve(symbol)
+	if len(nodes) == 0 {
+		return nil, notFound("no symbol matches %q", symbol)
+	}
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids, nil
+}
+
+func intParam(v string, def int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, badRequest("invalid number %q", v)
+	}
+	return n, nil
+}
+
+// listParam splits a comma-separated parameter, returning nil when empty.
+func listParam(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
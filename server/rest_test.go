@@ -0,0 +1,81 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// startHTTP serves the project in files, with GraphQL, over a test server.
+func startHTTP(t *testing.T, files fstest.MapFS) (*Server, *httptest.Server) {
+	t.Helper()
+	s, err := New(func() (graph.ProjectStructure, error) {
+		return graph.AnalyzeFS(files, "test")
+	}, Options{GraphQL: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func TestREST(t *testing.T) {
+	s, ts := startHTTP(t, fstest.MapFS{"main.go": {Data: []byte(testMain)}})
+	mainID, helperID := functionID(t, s, "main"), functionID(t, s, "helper")
+	tests := []struct {
+		method, path string
+		status       int
+		contains     []string
+	}{
+		{"GET", "/api/project", 200, []string{`"nodes": 3`, `"files": 1`}},
+		{"POST", "/api/analyze", 200, []string{`"files": 1`}},
+		{"GET", "/api/nodes?symbol=main", 200, []string{`"id": "` + mainID + `"`}},
+		{"GET", "/api/nodes?name=helper&type=function", 200, []string{`"id": "` + helperID + `"`}},
+		{"GET", "/api/nodes?match=" + url.QueryEscape("^main\\.h"), 200, []string{`"name": "helper"`}},
+		{"GET", "/api/nodes?match=(", 400, []string{"invalid match"}},
+		{"GET", "/api/nodes?limit=x", 400, []string{`invalid number \"x\"`}},
+		{"GET", "/api/nodes/" + mainID, 200, []string{`"relation": "calls"`, `"to": "` + helperID + `"`}},
+		{"GET", "/api/nodes/nope", 404, []string{`no node \"nope\"`}},
+		{"GET", "/api/nodes/" + helperID + "/callers", 200, []string{`"depth": 1`, `"name": "main"`}},
+		{"GET", "/api/nodes/" + mainID + "/callees?depth=x", 400, []string{"invalid number"}},
+		{"GET", "/api/paths?from=main&to=helper", 200, []string{`"from": "` + mainID + `"`, `"to": "` + helperID + `"`}},
+		{"GET", "/api/paths?from=main&to=missing", 404, []string{"missing"}},
+		{"GET", "/api/files", 200, []string{`"main.go"`}},
+		{"GET", "/api/files/main.go", 200, []string{`"returnType": "int"`}},
+		{"GET", "/api/files/other.go", 404, []string{"other.go"}},
+		{"GET", "/api/query?q=" + url.QueryEscape("MATCH (f)-[:calls]->(g) RETURN f.name, g.name"), 200, []string{`"f.name": "main"`, `"g.name": "helper"`}},
+		{"GET", "/api/query?q=RETURN", 400, []string{"expected MATCH"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d\n%s", resp.StatusCode, tt.status, body)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(string(body), s) {
+					t.Errorf("response lacks %s:\n%s", s, body)
+				}
+			}
+		})
+	}
+}
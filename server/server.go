@@ -0,0 +1,188 @@
+// Package server serves a code graph to other programs over HTTP, gRPC,
+// MCP and LSP.
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// LoadFunc produces the graph to serve, by analyzing a project or reading a
+// graph file. It is called again on every re-analysis.
+type LoadFunc func() (graph.ProjectStructure, error)
+
+// Options selects the APIs a Server offers besides REST.
+type Options struct {
+	GraphQL bool  // Serve a GraphQL schema at /graphql
+	Files   fs.FS // Project files, for showing source; nil for a graph loaded from a file
+}
+
+// Server holds an analyzed project and answers queries about it. The graph
+// is replaced as a whole on re-analysis, so every request sees a
+// consistent snapshot.
+type Server struct {
+	load   LoadFunc
+	opts   Options
+	schema graphql.Schema
+
+	// reloadMu serializes re-analyses; mu guards the snapshot
+	reloadMu sync.Mutex
+	mu       sync.RWMutex
+	snap     *snapshot
+
+	// subs are signalled after every reload, for streaming changes
+	subsMu sync.Mutex
+	subs   map[chan struct{}]bool
+}
+
+// snapshot is one analysis of the project with its lookup structures.
+type snapshot struct {
+	result     graph.ProjectStructure
+	idx        *graph.Index
+	modules    map[string]graph.ModuleInfo // Keyed by slash-separated path
+	files      []string
+	analyzedAt time.Time
+
+	// Declarations by the ID of their node, and the file declaring each node
+	structs    map[string]graph.StructInfo
+	interfaces map[string]graph.InterfaceInfo
+	types      map[string]graph.TypeInfo
+	functions  map[string]graph.FunctionInfo // Including methods
+	fileOf     map[string]string
+}
+
+// New loads the graph once and returns a Server for it.
+func New(load LoadFunc, opts Options) (*Server, error) {
+	s := &Server{load: load, opts: opts}
+	if opts.GraphQL {
+		schema, err := newSchema()
+		if err != nil {
+			return nil, fmt.Errorf("graphql schema: %w", err)
+		}
+		s.schema = schema
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload loads the graph again and swaps it in once it is complete;
+// requests keep being answered from the previous graph meanwhile.
+func (s *Server) Reload() error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	result, err := s.load()
+	if err != nil {
+		return err
+	}
+	snap := &snapshot{
+		result:     result,
+		idx:        graph.NewIndex(result.CodeGraph),
+		modules:    make(map[string]graph.ModuleInfo),
+		analyzedAt: time.Now(),
+		structs:    make(map[string]graph.StructInfo),
+		interfaces: make(map[string]graph.InterfaceInfo),
+		types:      make(map[string]graph.TypeInfo),
+		functions:  make(map[string]graph.FunctionInfo),
+		fileOf:     graph.NodePaths(result),
+	}
+	for _, pkg := range result.Project {
+		for path, m := range pkg.Modules {
+			path = filepath.ToSlash(path)
+			snap.modules[path] = m
+			snap.files = append(snap.files, path)
+			snap.addDeclarations(m)
+		}
+	}
+	sort.Strings(snap.files)
+
+	s.mu.Lock()
+	s.snap = snap
+	s.mu.Unlock()
+	s.notify()
+	return nil
+}
+
+// subscribe returns a channel signalled after every reload. Signals are
+// coalesced, so a slow subscriber compares the snapshot it has seen last
+// with the current one rather than counting reloads. cancel unsubscribes.
+func (s *Server) subscribe() (reloaded <-chan struct{}, cancel func()) {
+	ch := make(chan struct{}, 1)
+	s.subsMu.Lock()
+	if s.subs == nil {
+		s.subs = make(map[chan struct{}]bool)
+	}
+	s.subs[ch] = true
+	s.subsMu.Unlock()
+	return ch, func() {
+		s.subsMu.Lock()
+		delete(s.subs, ch)
+		s.subsMu.Unlock()
+	}
+}
+
+func (s *Server) notify() {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (snap *snapshot) addDeclarations(m graph.ModuleInfo) {
+	for _, st := range m.Structs {
+		snap.structs[st.ID] = st
+		for _, fn := range st.Functions {
+			snap.functions[fn.ID] = fn
+		}
+	}
+	for _, it := range m.Interfaces {
+		snap.interfaces[it.ID] = it
+	}
+	for _, t := range m.Types {
+		snap.types[t.ID] = t
+		for _, fn := range t.Functions {
+			snap.functions[fn.ID] = fn
+		}
+	}
+	for _, fn := range m.Functions {
+		snap.functions[fn.ID] = fn
+	}
+}
+
+// current returns the snapshot to answer a request from.
+func (s *Server) current() *snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+// Project returns the graph currently served.
+func (s *Server) Project() graph.ProjectStructure {
+	return s.current().result
+}
+
+// Handler returns the REST API, rooted at /api/, and the GraphQL endpoint
+// if enabled.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	s.registerREST(mux)
+	if s.opts.GraphQL {
+		mux.HandleFunc("GET /graphql", s.handleGraphQL)
+		mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	}
+	return mux
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/srinidhi-metadome/go-codegraph-cli/graph"
+)
+
+// Watch reloads the graph whenever the Go files of src change, checking
+// every interval until ctx is done. Errors, such as a file that does not
+// parse halfway through an edit, are passed to report and the previous
+// graph stays in place until the next change.
+func (s *Server) Watch(ctx context.Context, src graph.Source, interval time.Duration, report func(error)) {
+	last, err := src.Fingerprint()
+	if err != nil {
+		report(err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fp, err := src.Fingerprint()
+		if err != nil {
+			report(err)
+			continue
+		}
+		if fp == last {
+			continue
+		}
+		last = fp
+		if err := s.Reload(); err != nil {
+			report(err)
+		}
+	}
+}